@@ -0,0 +1,84 @@
+package cloudsdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/internal/types"
+)
+
+// TokenSource supplies the bearer token used to authenticate requests.
+// It is called by the transport before every request and must be safe for concurrent use.
+type TokenSource = types.TokenSource
+
+// TokenFetchFunc fetches a new bearer token and reports when it expires.
+// A zero expiry means the token is cached until it is invalidated.
+type TokenFetchFunc func(ctx context.Context) (token string, expiry time.Time, err error)
+
+// defaultTokenExpiryDelta is how long before expiry a cached token is refreshed.
+const defaultTokenExpiryDelta = 30 * time.Second
+
+// StaticTokenSource returns a TokenSource that always returns the same token; an
+// empty token is reported as an error. New uses it for the token argument when no
+// TokenSource is configured.
+func StaticTokenSource(token string) TokenSource {
+	return types.StaticTokenSource(token)
+}
+
+// CachedTokenSource caches tokens returned by a TokenFetchFunc.
+// The token is refreshed shortly before it expires, and immediately after
+// Invalidate is called with it (which the transport does when the API rejects it).
+type CachedTokenSource struct {
+	fetch       TokenFetchFunc
+	expiryDelta time.Duration
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewCachedTokenSource creates a CachedTokenSource backed by fetch.
+func NewCachedTokenSource(fetch TokenFetchFunc) *CachedTokenSource {
+	return &CachedTokenSource{
+		fetch:       fetch,
+		expiryDelta: defaultTokenExpiryDelta,
+	}
+}
+
+// Token returns the cached token, fetching a new one if none is cached or it is about to expire.
+func (s *CachedTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiry.IsZero() || time.Now().Add(s.expiryDelta).Before(s.expiry)) {
+		return s.token, nil
+	}
+
+	token, expiry, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	if token == "" {
+		return "", fmt.Errorf("token source returned an empty token")
+	}
+
+	s.token = token
+	s.expiry = expiry
+	return s.token, nil
+}
+
+// Invalidate discards the cached token if it is rejected, so the next call to
+// Token fetches a fresh one. A rejected token that has already been replaced is
+// ignored, so requests failing together with the same token refresh it once.
+func (s *CachedTokenSource) Invalidate(rejected string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != rejected {
+		return
+	}
+	s.token = ""
+	s.expiry = time.Time{}
+}
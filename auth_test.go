@@ -0,0 +1,181 @@
+package cloudsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestStaticTokenSource tests the static token source
+func TestStaticTokenSource(t *testing.T) {
+	token, err := StaticTokenSource("abc").Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token != "abc" {
+		t.Errorf("expected token %q, got %q", "abc", token)
+	}
+
+	if _, err := StaticTokenSource("").Token(context.Background()); err == nil {
+		t.Error("expected error for empty static token")
+	}
+}
+
+// TestCachedTokenSource tests caching, expiry and invalidation
+func TestCachedTokenSource(t *testing.T) {
+	t.Run("caches token without expiry", func(t *testing.T) {
+		var calls int32
+		ts := NewCachedTokenSource(func(_ context.Context) (string, time.Time, error) {
+			atomic.AddInt32(&calls, 1)
+			return "token-1", time.Time{}, nil
+		})
+
+		for i := 0; i < 3; i++ {
+			token, err := ts.Token(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if token != "token-1" {
+				t.Errorf("expected token-1, got %q", token)
+			}
+		}
+		if calls != 1 {
+			t.Errorf("expected 1 fetch, got %d", calls)
+		}
+	})
+
+	t.Run("refreshes near expiry", func(t *testing.T) {
+		var calls int32
+		ts := NewCachedTokenSource(func(_ context.Context) (string, time.Time, error) {
+			atomic.AddInt32(&calls, 1)
+			return "token", time.Now().Add(time.Second), nil
+		})
+
+		_, _ = ts.Token(context.Background())
+		_, _ = ts.Token(context.Background())
+		if calls != 2 {
+			t.Errorf("expected token within expiry delta to be refetched, got %d fetches", calls)
+		}
+	})
+
+	t.Run("invalidate forces refetch", func(t *testing.T) {
+		var calls int32
+		ts := NewCachedTokenSource(func(_ context.Context) (string, time.Time, error) {
+			n := atomic.AddInt32(&calls, 1)
+			if n == 1 {
+				return "old", time.Time{}, nil
+			}
+			return "new", time.Time{}, nil
+		})
+
+		_, _ = ts.Token(context.Background())
+		ts.Invalidate("old")
+		token, _ := ts.Token(context.Background())
+		if token != "new" {
+			t.Errorf("expected new token after invalidate, got %q", token)
+		}
+	})
+
+	t.Run("replaced token is not invalidated again", func(t *testing.T) {
+		var calls int32
+		ts := NewCachedTokenSource(func(_ context.Context) (string, time.Time, error) {
+			return fmt.Sprintf("token-%d", atomic.AddInt32(&calls, 1)), time.Time{}, nil
+		})
+
+		_, _ = ts.Token(context.Background())
+		// Requests rejected together all report the same token
+		for i := 0; i < 3; i++ {
+			ts.Invalidate("token-1")
+			_, _ = ts.Token(context.Background())
+		}
+		if calls != 2 {
+			t.Errorf("expected a single refresh, got %d fetches", calls)
+		}
+	})
+
+	t.Run("propagates fetch error", func(t *testing.T) {
+		ts := NewCachedTokenSource(func(_ context.Context) (string, time.Time, error) {
+			return "", time.Time{}, errors.New("boom")
+		})
+		if _, err := ts.Token(context.Background()); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+// TestWithTokenSource tests that every service client refreshes tokens on 401
+func TestWithTokenSource(t *testing.T) {
+	if _, err := New("https://api.example.com", "", WithTokenSource(StaticTokenSource("abc"))); err != nil {
+		t.Fatalf("expected empty token to be accepted with a token source, got %v", err)
+	}
+
+	var fetches int32
+	ts := NewCachedTokenSource(func(_ context.Context) (string, time.Time, error) {
+		if atomic.AddInt32(&fetches, 1) == 1 {
+			return "expired", time.Time{}, nil
+		}
+		return "valid", time.Time{}, nil
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"userId": "user-1"})
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL, "", WithTokenSource(ts))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if _, err := client.IAM().Users().Get(context.Background()); err != nil {
+		t.Fatalf("expected request to succeed after refresh, got %v", err)
+	}
+	if fetches != 2 {
+		t.Errorf("expected 2 token fetches, got %d", fetches)
+	}
+}
+
+// TestWithTokenSource_ExpiredTokenCode tests the refresh on IAM's 403 for an expired token
+func TestWithTokenSource_ExpiredTokenCode(t *testing.T) {
+	var fetches int32
+	ts := NewCachedTokenSource(func(_ context.Context) (string, time.Time, error) {
+		if atomic.AddInt32(&fetches, 1) == 1 {
+			return "expired", time.Time{}, nil
+		}
+		return "valid", time.Time{}, nil
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer valid" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errorCode": 1003, "message": "Forbidden"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"userId": "user-1"})
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL, "", WithTokenSource(ts))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if _, err := client.IAM().Users().Get(context.Background()); err != nil {
+		t.Fatalf("expected request to succeed after refresh, got %v", err)
+	}
+	if fetches != 2 {
+		t.Errorf("expected 2 token fetches, got %d", fetches)
+	}
+}
@@ -0,0 +1,66 @@
+package cloudsdk
+
+import (
+	"context"
+	"time"
+
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+)
+
+// CallOption customizes a single SDK operation. Every service method accepts
+// call options as trailing arguments:
+//
+//	server, err := vpsClient.Servers().Get(ctx, serverID,
+//		cloudsdk.WithCallTimeout(5*time.Second),
+//		cloudsdk.WithResponseMetadata(&md),
+//	)
+type CallOption = internalhttp.CallOption
+
+// WithCallOptions returns a context that applies opts to every SDK request made with it.
+// Options are layered on top of any call options already carried by ctx, and
+// options passed to a method are layered on top of those.
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	return internalhttp.WithCallOptions(ctx, opts...)
+}
+
+// WithCallHeader sets a request header for a single call. It overrides headers
+// the SDK method sets itself, except Authorization.
+func WithCallHeader(name, value string) CallOption {
+	return internalhttp.WithCallHeader(name, value)
+}
+
+// WithNamespace runs a single call in namespace by sending the X-Namespace header.
+// An empty namespace selects the default namespace.
+//
+//	repo, err := vrmClient.Repositories().Get(ctx, repositoryID, cloudsdk.WithNamespace("team-a"))
+func WithNamespace(namespace string) CallOption {
+	return internalhttp.WithCallNamespace(namespace)
+}
+
+// WithCallTimeout bounds each request of a single call, retries included. It
+// replaces the client timeout; a shorter context deadline still applies.
+func WithCallTimeout(d time.Duration) CallOption {
+	return internalhttp.WithCallTimeout(d)
+}
+
+// DryRun collects the mutating requests of calls made with WithDryRun.
+type DryRun = internalhttp.DryRun
+
+// DryRunRequest is a request recorded by a dry run, with secrets in its body masked.
+type DryRunRequest = internalhttp.DryRunRequest
+
+// ErrDryRun is returned by calls whose mutating request was recorded by a dry run.
+var ErrDryRun = internalhttp.ErrDryRun
+
+// WithDryRun records the POST, PUT, PATCH and DELETE requests of a single call in
+// dr instead of sending them; the call then fails with ErrDryRun. Read requests
+// are still sent.
+//
+//	var dr cloudsdk.DryRun
+//	_, err := vpsClient.Servers().Create(ctx, req, cloudsdk.WithDryRun(&dr))
+//	if errors.Is(err, cloudsdk.ErrDryRun) {
+//		fmt.Printf("%s %s\n%s\n", dr.Requests()[0].Method, dr.Requests()[0].Path, dr.Requests()[0].Body)
+//	}
+func WithDryRun(dr *DryRun) CallOption {
+	return internalhttp.WithCallDryRun(dr)
+}
@@ -0,0 +1,57 @@
+package cloudsdk_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	"github.com/Zillaforge/cloud-sdk/cloudsdktest"
+	repmod "github.com/Zillaforge/cloud-sdk/models/vrm/repositories"
+)
+
+func TestCallOptions_ServiceMethods(t *testing.T) {
+	ctx := context.Background()
+	fake := cloudsdktest.NewServer()
+	defer fake.Close()
+
+	client, err := cloudsdk.New(fake.URL, "token", cloudsdk.WithHTTPClient(fake.Client()))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	project, err := client.Project(ctx, cloudsdktest.DefaultProjectID)
+	if err != nil {
+		t.Fatalf("Project() failed: %v", err)
+	}
+	repos := project.VRM().Repositories()
+	req := &repmod.CreateRepositoryRequest{Name: "ubuntu", OperatingSystem: "linux"}
+
+	// A dry run records the create without sending it
+	var dr cloudsdk.DryRun
+	if _, err := repos.Create(ctx, req, cloudsdk.WithDryRun(&dr), cloudsdk.WithNamespace("private")); !errors.Is(err, cloudsdk.ErrDryRun) {
+		t.Fatalf("expected ErrDryRun, got %v", err)
+	}
+	recorded := dr.Requests()
+	if len(recorded) != 1 || recorded[0].Method != "POST" || !strings.HasSuffix(recorded[0].Path, "/repository") {
+		t.Fatalf("unexpected recorded requests: %+v", recorded)
+	}
+	if recorded[0].Header.Get("X-Namespace") != "private" {
+		t.Errorf("expected the namespace header to be recorded, got %v", recorded[0].Header)
+	}
+	if list, err := repos.List(ctx, nil, cloudsdk.WithNamespace("private")); err != nil || len(list) != 0 {
+		t.Fatalf("expected nothing to be created, got %d repositories, %v", len(list), err)
+	}
+
+	// The namespace option scopes every method
+	created, err := repos.Create(ctx, req, cloudsdk.WithNamespace("private"), cloudsdk.WithCallTimeout(cloudsdktest.DefaultTransitionDelay*10))
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if _, err := repos.Get(ctx, created.ID, cloudsdk.WithNamespace("private")); err != nil {
+		t.Errorf("expected the repository in its namespace, got %v", err)
+	}
+	if _, err := repos.Get(ctx, created.ID); !cloudsdk.IsNotFound(err) {
+		t.Errorf("expected the repository to be hidden from the default namespace, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package cloudsdk
+
+import (
+	"sync"
+	"time"
+
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+)
+
+// WithCircuitBreaker enables a circuit breaker per service endpoint (/iam, /vps, /vrm).
+// After threshold consecutive network, timeout or 5xx failures, calls to that service
+// fail immediately with an SDKError whose Meta["category"] is "circuit_open".
+// After cooldown a single probe request is sent; success closes the circuit again.
+// Breakers are shared by all service clients created from the same Client.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) ClientOption {
+	return func(c *Client) {
+		c.breakers = &breakerSet{
+			threshold: threshold,
+			cooldown:  cooldown,
+			breakers:  make(map[string]*internalhttp.Breaker),
+		}
+	}
+}
+
+// breakerSet hands out one circuit breaker per service, creating it on first use.
+type breakerSet struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	breakers map[string]*internalhttp.Breaker
+}
+
+// get returns the breaker guarding service.
+func (s *breakerSet) get(service string) *internalhttp.Breaker {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.breakers[service]
+	if !ok {
+		b = internalhttp.NewBreaker(service, s.threshold, s.cooldown)
+		s.breakers[service] = b
+	}
+	return b
+}
@@ -0,0 +1,49 @@
+package cloudsdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestWithCircuitBreaker tests that breakers are keyed by service endpoint
+func TestWithCircuitBreaker(t *testing.T) {
+	var iamCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/iam") {
+			atomic.AddInt32(&iamCalls, 1)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL, "test-token", WithCircuitBreaker(2, time.Minute), WithRetryPolicy(NoRetryPolicy()))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		_, _ = client.IAM().Users().Get(context.Background())
+	}
+
+	_, err = client.IAM().Users().Get(context.Background())
+	var sdkErr *SDKError
+	if !errors.As(err, &sdkErr) || sdkErr.Meta["category"] != "circuit_open" {
+		t.Fatalf("expected circuit_open error, got %v", err)
+	}
+	if got := atomic.LoadInt32(&iamCalls); got != 2 {
+		t.Errorf("expected 2 IAM requests, got %d", got)
+	}
+
+	if client.breakers.get("iam") == client.breakers.get("vps") {
+		t.Error("expected separate breakers per service")
+	}
+	if client.breakers.get("vrm").State() != "closed" {
+		t.Error("expected VRM breaker to be unaffected by IAM failures")
+	}
+}
@@ -5,14 +5,15 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
 	"github.com/Zillaforge/cloud-sdk/internal/types"
-	iamprojects "github.com/Zillaforge/cloud-sdk/models/iam/projects"
 	iam "github.com/Zillaforge/cloud-sdk/modules/iam/core"
 	vps "github.com/Zillaforge/cloud-sdk/modules/vps/core"
 	vrm "github.com/Zillaforge/cloud-sdk/modules/vrm/core"
+	"github.com/Zillaforge/cloud-sdk/tracing"
 )
 
 // Logger defines the interface for logging SDK operations.
@@ -20,11 +21,38 @@ type Logger = types.Logger
 
 // Client is the main entry point for the Cloud SDK.
 // It manages authentication, base URL, and HTTP client configuration.
+// A Client is safe for concurrent use; the service and project clients it hands
+// out are created once and shared.
 type Client struct {
-	baseURL    string
-	token      string
-	httpClient *http.Client
-	logger     Logger
+	baseURL     string
+	token       string
+	tokenSource TokenSource
+	httpClient  *http.Client
+	logger      Logger
+
+	defaultProject string
+	retryPolicy    *RetryPolicy
+
+	idempotencyKeys   bool
+	rateLimitCallback func(info RateLimitInfo)
+	limiters          *limiterSet
+	breakers          *breakerSet
+	middleware        []Middleware
+	tracer            tracing.Tracer
+	metrics           MetricsRecorder
+	errorHeaders      []string
+	requestLogging    *internalhttp.RequestLogging
+	connectionPool    *ConnectionPool
+	projectCacheTTL   time.Duration
+
+	iamOnce sync.Once
+	iam     *iam.Client
+
+	projectsOnce sync.Once
+	projects     *projectResolver
+
+	projectClientsMu sync.Mutex
+	projectClients   map[string]*ProjectClient
 }
 
 // ClientOption is a functional option for configuring the Client.
@@ -51,9 +79,27 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 	}
 }
 
+// WithTokenSource sets a TokenSource that supplies the bearer token for every request.
+// When set, the token passed to New may be empty. Sources that implement
+// Invalidate(rejected string) are refreshed and the request replayed once when
+// the API rejects the token: HTTP 401, or error code 1003 which IAM returns with a
+// 403 for an invalid or expired token.
+func WithTokenSource(ts TokenSource) ClientOption {
+	return func(c *Client) {
+		c.tokenSource = ts
+	}
+}
+
+// WithDefaultProject sets the project ID or projectSysCode used by DefaultProject.
+func WithDefaultProject(projectIDOrCode string) ClientOption {
+	return func(c *Client) {
+		c.defaultProject = projectIDOrCode
+	}
+}
+
 // New creates a new Cloud SDK client.
 // baseURL must be a valid URL with scheme (e.g., "https://api.example.com").
-// token must be a non-empty bearer token for authentication.
+// token must be a non-empty bearer token for authentication unless WithTokenSource is used.
 func New(baseURL, token string, opts ...ClientOption) (*Client, error) {
 	// Validate base URL
 	parsedURL, err := url.Parse(baseURL)
@@ -64,18 +110,15 @@ func New(baseURL, token string, opts ...ClientOption) (*Client, error) {
 		return nil, fmt.Errorf("base URL must include scheme (e.g., https://)")
 	}
 
-	// Validate token
-	if token == "" {
-		return nil, fmt.Errorf("token cannot be empty")
-	}
-
 	// Create client with defaults
+	defaultHTTPClient := &http.Client{
+		Timeout: 30 * time.Second, // Default 30s timeout
+	}
 	client := &Client{
-		baseURL: baseURL,
-		token:   token,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second, // Default 30s timeout
-		},
+		baseURL:         baseURL,
+		token:           token,
+		httpClient:      defaultHTTPClient,
+		projectCacheTTL: DefaultProjectCacheTTL,
 	}
 
 	// Apply options
@@ -83,6 +126,24 @@ func New(baseURL, token string, opts ...ClientOption) (*Client, error) {
 		opt(client)
 	}
 
+	// Every service shares one connection pool; clients supplied through
+	// WithHTTPClient keep their own transport
+	if client.httpClient == defaultHTTPClient {
+		pool := DefaultConnectionPool()
+		if client.connectionPool != nil {
+			pool = *client.connectionPool
+		}
+		defaultHTTPClient.Transport = pool.transport()
+	}
+
+	// Validate token
+	if client.tokenSource == nil {
+		if token == "" {
+			return nil, fmt.Errorf("token cannot be empty")
+		}
+		client.tokenSource = StaticTokenSource(token)
+	}
+
 	return client, nil
 }
 
@@ -94,86 +155,148 @@ func NewClient(baseURL, token string) *Client {
 }
 
 // ProjectClient provides access to project-scoped service clients.
+// It is safe for concurrent use; its service clients are created on first use
+// and reused afterwards.
 type ProjectClient struct {
 	client    *Client
 	projectID string
+
+	vpsOnce sync.Once
+	vps     *vps.Client
+	vrmOnce sync.Once
+	vrm     *vrm.Client
+
+	mu      sync.Mutex
+	project *Project
+}
+
+// projectClient returns the shared ProjectClient for projectID.
+func (c *Client) projectClient(projectID string) *ProjectClient {
+	c.projectClientsMu.Lock()
+	defer c.projectClientsMu.Unlock()
+
+	if pc, ok := c.projectClients[projectID]; ok {
+		return pc
+	}
+	if c.projectClients == nil {
+		c.projectClients = make(map[string]*ProjectClient)
+	}
+	pc := &ProjectClient{client: c, projectID: projectID}
+	c.projectClients[projectID] = pc
+	return pc
 }
 
 // Project creates a project-scoped client for the given project ID or project code.
-// If projectIDOrCode is a project ID, it creates the client directly.
-// If it's a project code, it resolves the project ID by listing projects and finding the one with matching extra.iservice.projectSysCode.
+// Keys shaped like a UUID are fetched as project IDs; other keys, and IDs IAM does
+// not know, are matched against extra.iservice.projectSysCode across every page
+// of the caller's projects. Resolved projects are cached (see WithProjectCacheTTL),
+// so repeated calls for the same project do not reach IAM.
 // Returns an error if no matching project is found or if multiple projects match the code.
 func (c *Client) Project(ctx context.Context, projectIDOrCode string) (*ProjectClient, error) {
-	iamClient := c.IAM()
-
-	// Try to get the project directly as projectID
-	_, err := iamClient.Projects().Get(ctx, projectIDOrCode)
-	if err == nil {
-		// It's a valid projectID
-		return &ProjectClient{
-			client:    c,
-			projectID: projectIDOrCode,
-		}, nil
-	}
-
-	// Assume it's a projectCode, list all projects to find matching projectSysCode
-	projects, listErr := iamClient.Projects().List(ctx, nil)
-	if listErr != nil {
-		return nil, fmt.Errorf("failed to list projects: %w", listErr)
-	}
-
-	var matchingProjects []*iamprojects.ProjectMembership
-	for _, pm := range projects {
-		if pm.Project != nil && pm.Project.Extra != nil {
-			if iservice, ok := pm.Project.Extra["iservice"].(map[string]interface{}); ok {
-				if sysCode, ok := iservice["projectSysCode"].(string); ok && sysCode == projectIDOrCode {
-					matchingProjects = append(matchingProjects, pm)
-				}
-			}
-		}
+	project, err := c.resolver().resolve(ctx, projectIDOrCode)
+	if err != nil {
+		return nil, err
 	}
+	return c.projectClientFor(project), nil
+}
 
-	if len(matchingProjects) == 0 {
-		return nil, fmt.Errorf("no project found with projectSysCode %s, please use projectID instead", projectIDOrCode)
+// DefaultProject creates a project-scoped client for the configured default project.
+// The default project is set with WithDefaultProject or by the credential provider
+// used in NewFromEnvironment.
+func (c *Client) DefaultProject(ctx context.Context) (*ProjectClient, error) {
+	if c.defaultProject == "" {
+		return nil, fmt.Errorf("no default project configured")
 	}
+	return c.Project(ctx, c.defaultProject)
+}
 
-	if len(matchingProjects) > 1 {
-		return nil, fmt.Errorf("multiple projects found with projectSysCode %s, please use projectID instead", projectIDOrCode)
-	}
+// ProjectID returns the ID of the bound project.
+func (pc *ProjectClient) ProjectID() string {
+	return pc.projectID
+}
 
-	projectID := matchingProjects[0].Project.ProjectID
-	return &ProjectClient{
-		client:    c,
-		projectID: projectID,
-	}, nil
+// Project returns the metadata of the bound project as of its last resolution
+// by Client.Project, ProjectByName or DefaultProject.
+func (pc *ProjectClient) Project() *Project {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	return pc.project
 }
 
-// VPS returns a project-scoped VPS service client.
+// VPS returns the project-scoped VPS service client.
 // All VPS operations will be performed within the context of the bound project.
 func (pc *ProjectClient) VPS() *vps.Client {
-	// Append /vps to baseURL for VPS service endpoints
-	vpsBaseURL := pc.client.baseURL + "/vps"
-	return vps.NewClient(vpsBaseURL, pc.client.token, pc.projectID, pc.client.httpClient, pc.client.logger)
+	pc.vpsOnce.Do(func() {
+		// Append /vps to baseURL for VPS service endpoints
+		vpsBaseURL := pc.client.baseURL + "/vps"
+		pc.vps = vps.NewClient(vpsBaseURL, pc.client.token, pc.projectID, pc.client.httpClient, pc.client.logger, pc.client.httpOptions("vps", pc.projectID)...)
+	})
+	return pc.vps
 }
 
-// VRM returns a project-scoped VRM service client.
+// VRM returns the project-scoped VRM service client.
 // All VRM operations will be performed within the context of the bound project.
 func (pc *ProjectClient) VRM() *vrm.Client {
-	// Append /vrm to baseURL for VRM service endpoints
-	vrmBaseURL := pc.client.baseURL + "/vrm"
-	return vrm.NewClient(vrmBaseURL, pc.client.token, pc.projectID, pc.client.httpClient, pc.client.logger)
+	pc.vrmOnce.Do(func() {
+		// Append /vrm to baseURL for VRM service endpoints
+		vrmBaseURL := pc.client.baseURL + "/vrm"
+		pc.vrm = vrm.NewClient(vrmBaseURL, pc.client.token, pc.projectID, pc.client.httpClient, pc.client.logger, pc.client.httpOptions("vrm", pc.projectID)...)
+	})
+	return pc.vrm
 }
 
-// IAM returns a non-project-scoped IAM service client.
+// IAM returns the non-project-scoped IAM service client.
 // IAM operations are global to the authenticated user and don't require a project context.
 func (c *Client) IAM() *iam.Client {
-	// Append /iam to baseURL for IAM service endpoints
-	iamBaseURL := c.baseURL + "/iam"
+	c.iamOnce.Do(func() {
+		// Append /iam to baseURL for IAM service endpoints
+		iamBaseURL := c.baseURL + "/iam"
 
-	// Create internal HTTP client with retry and error handling
-	baseClient := internalhttp.NewClient(iamBaseURL, c.token, c.httpClient, c.logger)
+		// Create internal HTTP client with retry and error handling
+		baseClient := internalhttp.NewClient(iamBaseURL, c.token, c.httpClient, c.logger, c.httpOptions("iam", "")...)
+		c.iam = iam.NewClient(baseClient)
+	})
+	return c.iam
+}
 
-	return iam.NewClient(baseClient)
+// httpOptions returns the internal HTTP client options for a service client.
+// projectID is empty for non-project-scoped services.
+func (c *Client) httpOptions(service, projectID string) []internalhttp.Option {
+	var opts []internalhttp.Option
+	if c.tokenSource != nil {
+		opts = append(opts, internalhttp.WithTokenSource(c.tokenSource))
+	}
+	if c.retryPolicy != nil {
+		opts = append(opts, internalhttp.WithRetryPolicy(c.retryPolicy))
+	}
+	if c.idempotencyKeys {
+		opts = append(opts, internalhttp.WithIdempotencyKeys(true))
+	}
+	if c.rateLimitCallback != nil {
+		opts = append(opts, internalhttp.WithRateLimitCallback(c.rateLimitCallback))
+	}
+	if c.limiters != nil {
+		opts = append(opts, internalhttp.WithLimiter(c.limiters.get(service, projectID)))
+	}
+	if c.breakers != nil {
+		opts = append(opts, internalhttp.WithBreaker(c.breakers.get(service)))
+	}
+	if len(c.middleware) > 0 {
+		opts = append(opts, internalhttp.WithMiddleware(c.middleware...))
+	}
+	if c.tracer != nil {
+		opts = append(opts, internalhttp.WithTracer(c.tracer))
+	}
+	if c.metrics != nil {
+		opts = append(opts, internalhttp.WithMetrics(c.metrics, service))
+	}
+	if len(c.errorHeaders) > 0 {
+		opts = append(opts, internalhttp.WithErrorHeaders(c.errorHeaders...))
+	}
+	if c.requestLogging != nil {
+		opts = append(opts, internalhttp.WithRequestLogging(*c.requestLogging))
+	}
+	return opts
 }
 
 // BaseURL returns the configured base URL.
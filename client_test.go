@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -250,10 +251,10 @@ func TestClient_Project(t *testing.T) {
 	// Create mock IAM server
 	iamServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
-		if r.Method == http.MethodGet && r.URL.Path == "/iam/api/v1/project/proj-123" {
+		if r.Method == http.MethodGet && r.URL.Path == "/iam/api/v1/project/00000000-0000-4000-8000-000000000123" {
 			w.WriteHeader(http.StatusOK)
 			response := map[string]interface{}{
-				"projectId":   "proj-123",
+				"projectId":   "00000000-0000-4000-8000-000000000123",
 				"displayName": "Test Project",
 			}
 			_ = json.NewEncoder(w).Encode(response)
@@ -268,7 +269,7 @@ func TestClient_Project(t *testing.T) {
 		t.Fatalf("New() failed: %v", err)
 	}
 
-	projectClient, err := client.Project(context.Background(), "proj-123")
+	projectClient, err := client.Project(context.Background(), "00000000-0000-4000-8000-000000000123")
 	if err != nil {
 		t.Fatalf("Project() failed: %v", err)
 	}
@@ -277,8 +278,8 @@ func TestClient_Project(t *testing.T) {
 		t.Fatal("expected project client, got nil")
 	}
 
-	if projectClient.projectID != "proj-123" {
-		t.Errorf("expected projectID %q, got %q", "proj-123", projectClient.projectID)
+	if projectClient.projectID != "00000000-0000-4000-8000-000000000123" {
+		t.Errorf("expected projectID %q, got %q", "00000000-0000-4000-8000-000000000123", projectClient.projectID)
 	}
 
 	if projectClient.client != client {
@@ -293,11 +294,11 @@ func mockIAMServerForProjectTests(t *testing.T, projects []iamprojects.ProjectMe
 		w.Header().Set("Content-Type", "application/json")
 
 		// Handle GET /iam/api/v1/project/{projectID}
-		if r.Method == http.MethodGet && r.URL.Path == "/iam/api/v1/project/proj-123" {
-			t.Logf("Handling project get request for proj-123")
+		if r.Method == http.MethodGet && r.URL.Path == "/iam/api/v1/project/00000000-0000-4000-8000-000000000123" {
+			t.Logf("Handling project get request for 00000000-0000-4000-8000-000000000123")
 			w.WriteHeader(http.StatusOK)
 			project := map[string]interface{}{
-				"projectId":   "proj-123",
+				"projectId":   "00000000-0000-4000-8000-000000000123",
 				"displayName": "Test Project",
 				"extra": map[string]interface{}{
 					"iservice": map[string]interface{}{
@@ -349,7 +350,7 @@ func TestProject_WithValidProjectID(t *testing.T) {
 	}
 
 	// Test with valid project ID
-	projectClient, err := client.Project(context.Background(), "proj-123")
+	projectClient, err := client.Project(context.Background(), "00000000-0000-4000-8000-000000000123")
 	if err != nil {
 		t.Fatalf("Project() failed: %v", err)
 	}
@@ -358,8 +359,8 @@ func TestProject_WithValidProjectID(t *testing.T) {
 		t.Fatal("expected project client, got nil")
 	}
 
-	if projectClient.projectID != "proj-123" {
-		t.Errorf("expected projectID 'proj-123', got '%s'", projectClient.projectID)
+	if projectClient.projectID != "00000000-0000-4000-8000-000000000123" {
+		t.Errorf("expected projectID '00000000-0000-4000-8000-000000000123', got '%s'", projectClient.projectID)
 	}
 
 	if projectClient.client != client {
@@ -528,10 +529,10 @@ func TestProjectClient_ServiceClients(t *testing.T) {
 	// Create mock IAM server
 	iamServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
-		if r.Method == http.MethodGet && r.URL.Path == "/iam/api/v1/project/proj-123" {
+		if r.Method == http.MethodGet && r.URL.Path == "/iam/api/v1/project/00000000-0000-4000-8000-000000000123" {
 			w.WriteHeader(http.StatusOK)
 			response := map[string]interface{}{
-				"projectId":   "proj-123",
+				"projectId":   "00000000-0000-4000-8000-000000000123",
 				"displayName": "Test Project",
 			}
 			_ = json.NewEncoder(w).Encode(response)
@@ -546,7 +547,7 @@ func TestProjectClient_ServiceClients(t *testing.T) {
 		t.Fatalf("failed to create client: %v", err)
 	}
 
-	projectClient, err := client.Project(context.Background(), "proj-123")
+	projectClient, err := client.Project(context.Background(), "00000000-0000-4000-8000-000000000123")
 	if err != nil {
 		t.Fatalf("Project() failed: %v", err)
 	}
@@ -578,6 +579,80 @@ func TestProjectClient_ServiceClients(t *testing.T) {
 	}
 }
 
+func TestClient_ReusesClients(t *testing.T) {
+	iamServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"projectId": "00000000-0000-4000-8000-000000000123"})
+	}))
+	defer iamServer.Close()
+
+	client, err := New(iamServer.URL, "test-token")
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	// Concurrent callers share one ProjectClient and one set of service clients
+	const workers = 8
+	projectClients := make([]*ProjectClient, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pc, err := client.Project(context.Background(), "00000000-0000-4000-8000-000000000123")
+			if err != nil {
+				t.Errorf("Project() failed: %v", err)
+				return
+			}
+			_ = pc.VPS().Servers()
+			_ = pc.VRM().Tags()
+			projectClients[i] = pc
+		}(i)
+	}
+	wg.Wait()
+
+	pc := projectClients[0]
+	for _, other := range projectClients[1:] {
+		if other != pc {
+			t.Fatal("expected Project() to return the same ProjectClient")
+		}
+	}
+	if pc.VPS() != pc.VPS() || pc.VRM() != pc.VRM() || client.IAM() != client.IAM() {
+		t.Error("expected service clients to be reused")
+	}
+	if pc.VPS().Servers() != pc.VPS().Servers() {
+		t.Error("expected sub-clients to be reused")
+	}
+}
+
+func TestWithConnectionPool(t *testing.T) {
+	client, err := New("https://api.example.com", "test-token")
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	transport, ok := client.HTTPClient().Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected an *http.Transport, got %T", client.HTTPClient().Transport)
+	}
+	if want := DefaultConnectionPool().MaxIdleConnsPerHost; transport.MaxIdleConnsPerHost != want {
+		t.Errorf("expected MaxIdleConnsPerHost %d, got %d", want, transport.MaxIdleConnsPerHost)
+	}
+
+	pool := ConnectionPool{MaxIdleConns: 10, MaxIdleConnsPerHost: 5, MaxConnsPerHost: 20, IdleConnTimeout: time.Second}
+	client, _ = New("https://api.example.com", "test-token", WithConnectionPool(pool), WithTimeout(time.Second))
+	transport = client.HTTPClient().Transport.(*http.Transport)
+	if transport.MaxIdleConns != 10 || transport.MaxIdleConnsPerHost != 5 || transport.MaxConnsPerHost != 20 || transport.IdleConnTimeout != time.Second {
+		t.Errorf("expected the configured pool, got %d/%d/%d/%v", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.MaxConnsPerHost, transport.IdleConnTimeout)
+	}
+
+	// A caller-supplied HTTP client is left untouched
+	custom := &http.Client{}
+	client, _ = New("https://api.example.com", "test-token", WithHTTPClient(custom), WithConnectionPool(pool))
+	if client.HTTPClient() != custom || custom.Transport != nil {
+		t.Error("expected WithHTTPClient to keep its own transport")
+	}
+}
+
 // containsString checks if a string contains a substring
 func containsString(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && containsSubstring(s, substr))
@@ -591,3 +666,15 @@ func containsSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+// TestClient_DefaultProject tests DefaultProject without a configured project
+func TestClient_DefaultProject(t *testing.T) {
+	client, err := New("https://api.example.com", "test-token")
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if _, err := client.DefaultProject(context.Background()); err == nil {
+		t.Error("expected error when no default project is configured")
+	}
+}
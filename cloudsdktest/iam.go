@@ -0,0 +1,133 @@
+package cloudsdktest
+
+import (
+	"net/http"
+
+	iamcommon "github.com/Zillaforge/cloud-sdk/models/iam/common"
+	"github.com/Zillaforge/cloud-sdk/models/iam/projects"
+	"github.com/Zillaforge/cloud-sdk/models/iam/users"
+)
+
+// Project seeded into every fake IAM, resolvable by ID or by projectSysCode.
+const (
+	DefaultProjectID      = "00000000-0000-4000-8000-0000000000b1"
+	DefaultProjectSysCode = "FAKE0001"
+)
+
+// IAM is the state of the fake IAM service: the calling user and the projects
+// they are a member of.
+type IAM struct {
+	st *state
+
+	user     users.User
+	projects *collection[projects.ProjectMembership]
+}
+
+func newIAM(st *state) *IAM {
+	i := &IAM{
+		st: st,
+		user: users.User{
+			UserID:      DefaultUserID,
+			Account:     DefaultUserName,
+			DisplayName: DefaultUserName,
+			Namespace:   DefaultNamespace,
+			Email:       DefaultUserName + "@example.com",
+			Extra:       map[string]interface{}{},
+			CreatedAt:   st.timestamp(),
+			UpdatedAt:   st.timestamp(),
+		},
+		projects: newCollection[projects.ProjectMembership](),
+	}
+	i.addProject(DefaultProjectID, "fake-project", DefaultProjectSysCode)
+	return i
+}
+
+// SetUser replaces the user returned by GET /user.
+func (i *IAM) SetUser(u users.User) {
+	i.st.mu.Lock()
+	defer i.st.mu.Unlock()
+	i.user = u
+}
+
+// AddProject adds a project the user is a member of and returns its ID.
+// A non-empty sysCode is stored as extra.iservice.projectSysCode, so
+// cloudsdk.Client.Project can resolve it.
+func (i *IAM) AddProject(displayName, sysCode string) string {
+	i.st.mu.Lock()
+	defer i.st.mu.Unlock()
+	return i.addProject(i.st.newID(), displayName, sysCode)
+}
+
+func (i *IAM) addProject(id, displayName, sysCode string) string {
+	extra := map[string]interface{}{}
+	if sysCode != "" {
+		extra["iservice"] = map[string]interface{}{"projectSysCode": sysCode}
+	}
+	i.projects.put(id, &projects.ProjectMembership{
+		Project: &projects.Project{
+			ProjectID:   id,
+			DisplayName: displayName,
+			Extra:       extra,
+			Namespace:   DefaultNamespace,
+			CreatedAt:   i.st.timestamp(),
+			UpdatedAt:   i.st.timestamp(),
+		},
+		GlobalPermissionID: "global-member",
+		GlobalPermission:   &iamcommon.Permission{ID: "global-member", Label: "MEMBER"},
+		UserPermissionID:   "user-member",
+		UserPermission:     &iamcommon.Permission{ID: "user-member", Label: "MEMBER"},
+		TenantRole:         iamcommon.TenantRoleMember,
+		Extra:              map[string]interface{}{},
+	})
+	return id
+}
+
+// register mounts the IAM routes under prefix.
+func (i *IAM) register(mux *http.ServeMux, prefix string) {
+	h := i.st.handler
+	mux.HandleFunc("GET "+prefix+"/user", h(http.StatusOK, i.getUser))
+	mux.HandleFunc("GET "+prefix+"/projects", h(http.StatusOK, i.listProjects))
+	mux.HandleFunc("GET "+prefix+"/project/{id}", h(http.StatusOK, i.getProject))
+}
+
+func (i *IAM) getUser(_ *http.Request) (interface{}, error) {
+	return i.user, nil
+}
+
+// listProjects pages through the user's projects in the requested namespace.
+// order=desc returns the newest first.
+func (i *IAM) listProjects(r *http.Request) (interface{}, error) {
+	list := i.projects.list(func(m *projects.ProjectMembership) bool {
+		return inNamespace(r, m.Project.Namespace)
+	})
+	if r.URL.Query().Get("order") == "desc" {
+		for a, b := 0, len(list)-1; a < b; a, b = a+1, b-1 {
+			list[a], list[b] = list[b], list[a]
+		}
+	}
+	page, total, err := paginate(r, list)
+	if err != nil {
+		return nil, err
+	}
+	return projects.ListProjectsResponse{Projects: page, Total: total}, nil
+}
+
+func (i *IAM) getProject(r *http.Request) (interface{}, error) {
+	m, ok := i.projects.get(r.PathValue("id"))
+	if !ok || !inNamespace(r, m.Project.Namespace) {
+		return nil, notFound("project", r.PathValue("id"))
+	}
+	p := m.Project
+	return projects.GetProjectResponse{
+		ProjectID:        p.ProjectID,
+		DisplayName:      p.DisplayName,
+		Description:      p.Description,
+		Extra:            p.Extra,
+		Namespace:        p.Namespace,
+		Frozen:           p.Frozen,
+		GlobalPermission: m.GlobalPermission,
+		UserPermission:   m.UserPermission,
+		CreatedAt:        p.CreatedAt,
+		UpdatedAt:        p.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,102 @@
+package cloudsdktest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	"github.com/Zillaforge/cloud-sdk/models/iam/projects"
+)
+
+// newSDKClient starts a fake and returns a top-level SDK client for it.
+func newSDKClient(t *testing.T, opts ...Option) (*Server, *cloudsdk.Client) {
+	t.Helper()
+	fake := NewServer(opts...)
+	t.Cleanup(fake.Close)
+	client, err := cloudsdk.New(fake.URL, "token", cloudsdk.WithHTTPClient(fake.Client()))
+	if err != nil {
+		t.Fatalf("cloudsdk.New() failed: %v", err)
+	}
+	return fake, client
+}
+
+func TestIAM_User(t *testing.T) {
+	_, client := newSDKClient(t)
+
+	user, err := client.IAM().Users().Get(context.Background())
+	if err != nil {
+		t.Fatalf("Users().Get() failed: %v", err)
+	}
+	if user.UserID != DefaultUserID || user.Account != DefaultUserName {
+		t.Errorf("unexpected user: %+v", user)
+	}
+}
+
+func TestIAM_ProjectResolution(t *testing.T) {
+	ctx := context.Background()
+	fake, client := newSDKClient(t)
+	otherID := fake.IAM.AddProject("other", "OTHER01")
+
+	tests := []struct {
+		name   string
+		lookup string
+		wantID string
+	}{
+		{name: "by ID", lookup: DefaultProjectID, wantID: DefaultProjectID},
+		{name: "by sys code", lookup: DefaultProjectSysCode, wantID: DefaultProjectID},
+		{name: "added project by sys code", lookup: "OTHER01", wantID: otherID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, err := client.Project(ctx, tt.lookup)
+			if err != nil {
+				t.Fatalf("Project() failed: %v", err)
+			}
+			if got := project.VPS().ProjectID(); got != tt.wantID {
+				t.Errorf("expected project %s, got %s", tt.wantID, got)
+			}
+		})
+	}
+
+	if _, err := client.Project(ctx, "MISSING"); err == nil {
+		t.Error("expected an error for an unknown project")
+	}
+}
+
+func TestIAM_ListProjectsPagination(t *testing.T) {
+	ctx := context.Background()
+	fake, client := newSDKClient(t)
+	for _, name := range []string{"b", "c", "d"} {
+		fake.IAM.AddProject(name, "")
+	}
+
+	offset, limit, order := 1, 2, "desc"
+	page, err := client.IAM().Projects().List(ctx, &projects.ListProjectsOptions{Offset: &offset, Limit: &limit, Order: &order})
+	if err != nil {
+		t.Fatalf("Projects().List() failed: %v", err)
+	}
+	if len(page) != 2 || page[0].Project.DisplayName != "c" || page[1].Project.DisplayName != "b" {
+		var names []string
+		for _, m := range page {
+			names = append(names, m.Project.DisplayName)
+		}
+		t.Errorf("expected [c b], got %v", names)
+	}
+}
+
+func TestIAM_NamespaceHeader(t *testing.T) {
+	fake := NewServer()
+	defer fake.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, fake.URL+"/iam/api/v1/project/"+DefaultProjectID, nil)
+	req.Header.Set("X-Namespace", "private")
+	resp, err := fake.Client().Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 outside the project's namespace, got %d", resp.StatusCode)
+	}
+}
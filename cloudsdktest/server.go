@@ -0,0 +1,381 @@
+// Package cloudsdktest provides an in-memory fake of the Zillaforge cloud APIs for
+// testing code that uses the SDK without a live platform.
+//
+// NewServer starts an httptest.Server serving the VPS, VRM and IAM APIs. Its URL
+// can be passed to cloudsdk.New, or with the service suffix to the service
+// constructors:
+//
+//	fake := cloudsdktest.NewServer(cloudsdktest.WithTransitionDelay(50 * time.Millisecond))
+//	defer fake.Close()
+//
+//	client, _ := cloudsdk.New(fake.URL, "any-token", cloudsdk.WithHTTPClient(fake.Client()))
+//	project, err := client.Project(ctx, cloudsdktest.DefaultProjectSysCode)
+//	server, err := project.VPS().Servers().Create(ctx, &servers.ServerCreateRequest{...})
+//
+// The fake keeps state per project and namespace and simulates asynchronous status
+// transitions (BUILD→ACTIVE, creating→available, PENDING→ACTIVE, queued→saving→active)
+// after the configured delay.
+package cloudsdktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Identity of the fake caller, recorded as the owner of created resources.
+const (
+	DefaultUserID    = "00000000-0000-4000-8000-0000000000aa"
+	DefaultUserName  = "fake-user"
+	DefaultNamespace = "public"
+)
+
+// Error codes returned in the {"errorCode", "message"} error body.
+const (
+	ErrorCodeBadRequest = 1001
+	ErrorCodeForbidden  = 1003
+	ErrorCodeNotFound   = 1004
+	ErrorCodeInternal   = 2001
+)
+
+// namespaceHeader selects the namespace a request operates in.
+const namespaceHeader = "X-Namespace"
+
+// DefaultTransitionDelay is the time a resource spends in a transitional status.
+const DefaultTransitionDelay = 100 * time.Millisecond
+
+// Option configures a fake Server.
+type Option func(*config)
+
+type config struct {
+	delay time.Duration
+	now   func() time.Time
+}
+
+// WithTransitionDelay sets how long resources stay in transitional states such as
+// BUILD, creating or PENDING before moving on. Zero makes transitions complete on
+// the next request.
+func WithTransitionDelay(d time.Duration) Option {
+	return func(c *config) {
+		c.delay = d
+	}
+}
+
+// WithClock replaces the clock used to time status transitions, so tests can
+// advance time explicitly instead of sleeping.
+func WithClock(now func() time.Time) Option {
+	return func(c *config) {
+		c.now = now
+	}
+}
+
+// Server is a running fake of the cloud APIs.
+type Server struct {
+	// URL is the base URL of the fake, without a service suffix
+	URL string
+
+	// VPS exposes the fake VPS state for seeding and inspection
+	VPS *VPS
+
+	// VRM exposes the fake VRM state for seeding and inspection
+	VRM *VRM
+
+	// IAM exposes the fake IAM state for seeding and inspection
+	IAM *IAM
+
+	httpServer *httptest.Server
+}
+
+// NewServer starts a fake serving the VPS, VRM and IAM APIs under /vps, /vrm and
+// /iam, matching the layout cloudsdk.New expects. Callers must Close it when done.
+func NewServer(opts ...Option) *Server {
+	cfg := &config{delay: DefaultTransitionDelay, now: time.Now}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	st := &state{cfg: cfg}
+	s := &Server{VPS: newVPS(st), IAM: newIAM(st)}
+	s.VRM = newVRM(st, s.VPS)
+
+	mux := http.NewServeMux()
+	s.VPS.register(mux, "/vps/api/v1/project/{project}")
+	s.VRM.register(mux, "/vrm/api/v1/project/{project}")
+	s.IAM.register(mux, "/iam/api/v1")
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, ErrorCodeNotFound, fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+	})
+
+	s.httpServer = httptest.NewServer(mux)
+	s.URL = s.httpServer.URL
+	st.baseURL = s.URL
+	return s
+}
+
+// Close shuts down the fake.
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// clientTimeout bounds requests made through Server.Client. The SDK derives its
+// per-request deadline from the HTTP client timeout, so it must not be zero.
+const clientTimeout = 30 * time.Second
+
+// Client returns an HTTP client configured to talk to the fake.
+func (s *Server) Client() *http.Client {
+	client := *s.httpServer.Client()
+	client.Timeout = clientTimeout
+	return &client
+}
+
+// state is the clock, ID sequence and transition queue shared by all fake services.
+// All fields are guarded by mu.
+type state struct {
+	mu      sync.Mutex
+	cfg     *config
+	baseURL string
+	nextID  int
+	nextReq int
+	events  []event
+}
+
+// event is a scheduled state change, such as BUILD→ACTIVE.
+type event struct {
+	at    time.Time
+	apply func()
+}
+
+// newID returns a deterministic UUID-shaped identifier.
+func (st *state) newID() string {
+	st.nextID++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", st.nextID)
+}
+
+// now returns the current fake time.
+func (st *state) now() time.Time {
+	return st.cfg.now()
+}
+
+// timestamp returns the current fake time in the API's string format.
+func (st *state) timestamp() string {
+	return st.now().UTC().Format(time.RFC3339)
+}
+
+// timePtr returns the current fake time for models with *time.Time timestamps.
+func (st *state) timePtr() *time.Time {
+	t := st.now().UTC().Truncate(time.Second)
+	return &t
+}
+
+// after schedules apply to run once the transition delay has elapsed.
+func (st *state) after(apply func()) {
+	st.events = append(st.events, event{at: st.now().Add(st.cfg.delay), apply: apply})
+}
+
+// advance applies every due event in schedule order. Callers must hold mu.
+func (st *state) advance() {
+	now := st.now()
+	sort.SliceStable(st.events, func(i, j int) bool { return st.events[i].at.Before(st.events[j].at) })
+
+	due := 0
+	for due < len(st.events) && !st.events[due].at.After(now) {
+		due++
+	}
+	ready := st.events[:due]
+	st.events = append([]event(nil), st.events[due:]...)
+	for _, e := range ready {
+		e.apply()
+	}
+}
+
+// handler wraps a fake endpoint: it locks the state, assigns an X-Request-Id,
+// applies due transitions, and writes the returned value as JSON with the given
+// success status.
+// A nil result is written as an empty body.
+func (st *state) handler(status int, fn func(r *http.Request) (interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		st.mu.Lock()
+		st.nextReq++
+		w.Header().Set("X-Request-Id", fmt.Sprintf("req-%06d", st.nextReq))
+		st.advance()
+		result, err := fn(r)
+		var body []byte
+		if err == nil && result != nil {
+			body, err = json.Marshal(result)
+		}
+		st.mu.Unlock()
+
+		if err != nil {
+			if apiErr, ok := err.(*apiError); ok {
+				writeError(w, apiErr.status, apiErr.code, apiErr.message)
+				return
+			}
+			writeError(w, http.StatusInternalServerError, ErrorCodeInternal, err.Error())
+			return
+		}
+
+		if body == nil {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write(body)
+	}
+}
+
+// apiError is an error response produced by a fake endpoint.
+type apiError struct {
+	status  int
+	code    int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return &apiError{status: http.StatusBadRequest, code: ErrorCodeBadRequest, message: fmt.Sprintf(format, args...)}
+}
+
+func notFound(kind, id string) error {
+	return &apiError{status: http.StatusNotFound, code: ErrorCodeNotFound, message: fmt.Sprintf("%s %s not found", kind, id)}
+}
+
+func conflict(format string, args ...interface{}) error {
+	return &apiError{status: http.StatusConflict, code: ErrorCodeBadRequest, message: fmt.Sprintf(format, args...)}
+}
+
+// writeError writes the API's standard error body.
+func writeError(w http.ResponseWriter, status, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"errorCode": code, "message": message})
+}
+
+// decode reads the JSON request body into v.
+func decode(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return badRequest("invalid request body: %v", err)
+	}
+	return nil
+}
+
+// collection keeps resources by ID in creation order.
+type collection[T any] struct {
+	order []string
+	items map[string]*T
+}
+
+func newCollection[T any]() *collection[T] {
+	return &collection[T]{items: make(map[string]*T)}
+}
+
+func (c *collection[T]) put(id string, v *T) {
+	if _, ok := c.items[id]; !ok {
+		c.order = append(c.order, id)
+	}
+	c.items[id] = v
+}
+
+func (c *collection[T]) get(id string) (*T, bool) {
+	v, ok := c.items[id]
+	return v, ok
+}
+
+func (c *collection[T]) remove(id string) {
+	if _, ok := c.items[id]; !ok {
+		return
+	}
+	delete(c.items, id)
+	for i, existing := range c.order {
+		if existing == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// list returns the items accepted by keep, in creation order.
+func (c *collection[T]) list(keep func(*T) bool) []*T {
+	out := make([]*T, 0, len(c.order))
+	for _, id := range c.order {
+		if v := c.items[id]; keep == nil || keep(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// matches reports whether a resource field equals a list query filter.
+// An empty filter matches everything.
+func matches(filter, value string) bool {
+	return filter == "" || filter == value
+}
+
+// matchesName reports whether a resource name passes a name query filter, which
+// matches by substring. An empty filter matches everything.
+func matchesName(filter, name string) bool {
+	return filter == "" || strings.Contains(name, filter)
+}
+
+// requestNamespace returns the namespace selected by the request.
+func requestNamespace(r *http.Request) string {
+	if ns := r.Header.Get(namespaceHeader); ns != "" {
+		return ns
+	}
+	return DefaultNamespace
+}
+
+// inNamespace reports whether a resource in namespace is visible to the request.
+func inNamespace(r *http.Request, namespace string) bool {
+	return namespace == requestNamespace(r)
+}
+
+// paginate applies the limit and offset query parameters to items and returns
+// the page with the total before paging. A missing or -1 limit returns everything.
+func paginate[T any](r *http.Request, items []*T) ([]*T, int, error) {
+	total := len(items)
+	q := r.URL.Query()
+
+	offset := 0
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, 0, badRequest("invalid offset %q", v)
+		}
+		offset = n
+	}
+	limit := -1
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < -1 {
+			return nil, 0, badRequest("invalid limit %q", v)
+		}
+		limit = n
+	}
+
+	if offset > total {
+		offset = total
+	}
+	end := total
+	if limit >= 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return items[offset:end], total, nil
+}
+
+// nonNil returns items, or an empty slice so lists encode as [] rather than null.
+func nonNil[T any](items []*T) []*T {
+	if items == nil {
+		return []*T{}
+	}
+	return items
+}
@@ -0,0 +1,113 @@
+package cloudsdktest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// manualClock is a clock that only moves when advanced.
+type manualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newManualClock() *manualClock {
+	return &manualClock{now: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestNewServer_UnknownRoute(t *testing.T) {
+	fake := NewServer()
+	defer fake.Close()
+
+	resp, err := fake.Client().Get(fake.URL + "/nope")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", resp.StatusCode)
+	}
+	var body struct {
+		ErrorCode int    `json:"errorCode"`
+		Message   string `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode error body: %v", err)
+	}
+	if body.ErrorCode != ErrorCodeNotFound || !strings.Contains(body.Message, "/nope") {
+		t.Errorf("unexpected error body: %+v", body)
+	}
+}
+
+func TestNewServer_InvalidBody(t *testing.T) {
+	fake := NewServer()
+	defer fake.Close()
+
+	resp, err := fake.Client().Post(fake.URL+"/vps/api/v1/project/p1/volumes", "application/json", strings.NewReader("{"))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestState_Advance(t *testing.T) {
+	clock := newManualClock()
+	st := &state{cfg: &config{delay: time.Second, now: clock.Now}}
+
+	var applied []string
+	st.after(func() { applied = append(applied, "first") })
+	clock.Advance(500 * time.Millisecond)
+	st.after(func() { applied = append(applied, "second") })
+
+	tests := []struct {
+		name    string
+		advance time.Duration
+		want    []string
+	}{
+		{name: "nothing due", advance: 0, want: nil},
+		{name: "first due", advance: 500 * time.Millisecond, want: []string{"first"}},
+		{name: "second due", advance: 500 * time.Millisecond, want: []string{"first", "second"}},
+		{name: "queue drained", advance: time.Hour, want: []string{"first", "second"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock.Advance(tt.advance)
+			st.advance()
+			if strings.Join(applied, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v applied, got %v", tt.want, applied)
+			}
+		})
+	}
+}
+
+func TestState_NewID(t *testing.T) {
+	st := &state{cfg: &config{}}
+	first, second := st.newID(), st.newID()
+	if first == second {
+		t.Fatal("expected unique IDs")
+	}
+	if len(first) != 36 || strings.Count(first, "-") != 4 {
+		t.Errorf("expected a UUID-shaped ID, got %q", first)
+	}
+}
@@ -0,0 +1,481 @@
+package cloudsdktest
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+
+	"github.com/Zillaforge/cloud-sdk/models/vps/floatingips"
+	"github.com/Zillaforge/cloud-sdk/models/vps/networks"
+	"github.com/Zillaforge/cloud-sdk/models/vps/securitygroups"
+)
+
+// Network statuses reported by the fake.
+const (
+	networkStatusBuild  = "BUILD"
+	networkStatusActive = "ACTIVE"
+)
+
+// Networks
+
+// networkIn returns the network with the request's {id} in the request's project.
+func (v *VPS) networkIn(r *http.Request) (*networks.Network, error) {
+	n, ok := v.networks.get(r.PathValue("id"))
+	if !ok || n.ProjectID != r.PathValue("project") {
+		return nil, notFound("network", r.PathValue("id"))
+	}
+	return n, nil
+}
+
+func (v *VPS) listNetworks(r *http.Request) (interface{}, error) {
+	q := r.URL.Query()
+	list := v.networks.list(func(n *networks.Network) bool {
+		return n.ProjectID == r.PathValue("project") &&
+			matchesName(q.Get("name"), n.Name) &&
+			matches(q.Get("user_id"), n.UserID) &&
+			matches(q.Get("status"), n.Status) &&
+			matches(q.Get("router_id"), n.RouterID)
+	})
+	return networks.NetworkListResponse{Networks: list}, nil
+}
+
+func (v *VPS) createNetwork(r *http.Request) (interface{}, error) {
+	var req networks.NetworkCreateRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, badRequest("name is required")
+	}
+	prefix, err := netip.ParsePrefix(req.CIDR)
+	if err != nil || !prefix.Addr().Is4() || prefix.Bits() > 29 {
+		return nil, badRequest("invalid cidr %q", req.CIDR)
+	}
+	prefix = prefix.Masked()
+	gateway := req.Gateway
+	if gateway == "" {
+		gateway = prefix.Addr().Next().String()
+	} else if addr, err := netip.ParseAddr(gateway); err != nil || !prefix.Contains(addr) {
+		return nil, badRequest("gateway %s is not in %s", req.Gateway, prefix)
+	}
+
+	projectID := r.PathValue("project")
+	project, user := owner(projectID)
+	n := &networks.Network{
+		ID:          v.st.newID(),
+		Name:        req.Name,
+		Description: req.Description,
+		CIDR:        prefix.String(),
+		Gateway:     gateway,
+		GWState:     true,
+		Namespace:   DefaultNamespace,
+		Project:     &project,
+		ProjectID:   projectID,
+		RouterID:    req.RouterID,
+		Status:      networkStatusBuild,
+		SubnetID:    v.st.newID(),
+		User:        &user,
+		UserID:      user.ID,
+		CreatedAt:   v.st.timestamp(),
+		UpdatedAt:   v.st.timestamp(),
+	}
+	v.networks.put(n.ID, n)
+	v.st.after(func() {
+		if n.Status == networkStatusBuild {
+			n.Status = networkStatusActive
+		}
+	})
+	return n, nil
+}
+
+func (v *VPS) getNetwork(r *http.Request) (interface{}, error) {
+	return v.networkIn(r)
+}
+
+func (v *VPS) updateNetwork(r *http.Request) (interface{}, error) {
+	n, err := v.networkIn(r)
+	if err != nil {
+		return nil, err
+	}
+	var req networks.NetworkUpdateRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Name != "" {
+		n.Name = req.Name
+	}
+	if req.Description != "" {
+		n.Description = req.Description
+	}
+	n.UpdatedAt = v.st.timestamp()
+	return n, nil
+}
+
+func (v *VPS) deleteNetwork(r *http.Request) (interface{}, error) {
+	n, err := v.networkIn(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(v.ports(n.ID)) > 0 {
+		return nil, conflict("network %s still has ports", n.ID)
+	}
+	v.networks.remove(n.ID)
+	delete(v.nextHost, n.ID)
+	return nil, nil
+}
+
+func (v *VPS) listPorts(r *http.Request) (interface{}, error) {
+	n, err := v.networkIn(r)
+	if err != nil {
+		return nil, err
+	}
+	return v.ports(n.ID), nil
+}
+
+// ports returns a port for every server NIC on networkID.
+func (v *VPS) ports(networkID string) []*networks.NetworkPort {
+	ports := []*networks.NetworkPort{}
+	for _, srv := range v.servers.list(nil) {
+		nics, ok := v.nics[srv.ID]
+		if !ok {
+			continue
+		}
+		for _, nic := range nics.list(nil) {
+			if nic.NetworkID != networkID {
+				continue
+			}
+			ports = append(ports, &networks.NetworkPort{
+				ID:        nic.ID,
+				Addresses: nic.Addresses,
+				Server: &networks.ServerSummary{
+					ID:        srv.ID,
+					Name:      srv.Name,
+					Status:    string(srv.Status),
+					ProjectID: srv.ProjectID,
+					UserID:    srv.UserID,
+				},
+			})
+		}
+	}
+	return ports
+}
+
+// Floating IPs
+
+// floatingIPIn returns the floating IP with the request's {id} in the request's project.
+func (v *VPS) floatingIPIn(r *http.Request) (*floatingips.FloatingIP, error) {
+	fip, ok := v.floatingIPs.get(r.PathValue("id"))
+	if !ok || fip.ProjectID != r.PathValue("project") {
+		return nil, notFound("floating IP", r.PathValue("id"))
+	}
+	return fip, nil
+}
+
+func (v *VPS) listFloatingIPs(r *http.Request) (interface{}, error) {
+	q := r.URL.Query()
+	list := v.floatingIPs.list(func(f *floatingips.FloatingIP) bool {
+		return f.ProjectID == r.PathValue("project") &&
+			matchesName(q.Get("name"), f.Name) &&
+			matches(q.Get("status"), string(f.Status)) &&
+			matches(q.Get("user_id"), f.UserID) &&
+			matches(q.Get("device_type"), f.DeviceType) &&
+			matches(q.Get("device_id"), f.DeviceID) &&
+			matches(q.Get("extnet_id"), f.ExtNetID) &&
+			matches(q.Get("address"), f.Address)
+	})
+	return floatingips.FloatingIPListResponse{FloatingIPs: list}, nil
+}
+
+// createFloatingIP allocates a floating IP that stays PENDING until the transition
+// delay passes or it is approved or rejected.
+func (v *VPS) createFloatingIP(r *http.Request) (interface{}, error) {
+	var req floatingips.FloatingIPCreateRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	fip := v.newFloatingIP(r.PathValue("project"), req)
+	v.st.after(func() {
+		if fip.Status == floatingips.FloatingIPStatusPending {
+			fip.Status = floatingips.FloatingIPStatusActive
+			fip.ApprovedAt = v.st.timestamp()
+		}
+	})
+	return fip, nil
+}
+
+// newFloatingIP stores a PENDING floating IP with the next documentation-range address.
+func (v *VPS) newFloatingIP(projectID string, req floatingips.FloatingIPCreateRequest) *floatingips.FloatingIP {
+	v.nextFloatIP++
+	project, user := owner(projectID)
+	id := v.st.newID()
+	fip := &floatingips.FloatingIP{
+		ID:          id,
+		UUID:        id,
+		Name:        req.Name,
+		Description: req.Description,
+		Address:     fmt.Sprintf("203.0.113.%d", v.nextFloatIP%254+1),
+		ExtNetID:    req.ExtNetID,
+		ProjectID:   projectID,
+		Project:     &project,
+		Namespace:   DefaultNamespace,
+		UserID:      user.ID,
+		User:        &user,
+		Status:      floatingips.FloatingIPStatusPending,
+		CreatedAt:   v.st.timestamp(),
+		UpdatedAt:   v.st.timestamp(),
+	}
+	v.floatingIPs.put(id, fip)
+	return fip
+}
+
+func (v *VPS) getFloatingIP(r *http.Request) (interface{}, error) {
+	return v.floatingIPIn(r)
+}
+
+func (v *VPS) updateFloatingIP(r *http.Request) (interface{}, error) {
+	fip, err := v.floatingIPIn(r)
+	if err != nil {
+		return nil, err
+	}
+	var req floatingips.FloatingIPUpdateRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Name != "" {
+		fip.Name = req.Name
+	}
+	if req.Description != "" {
+		fip.Description = req.Description
+	}
+	if req.Reserved != nil {
+		fip.Reserved = *req.Reserved
+	}
+	fip.UpdatedAt = v.st.timestamp()
+	return fip, nil
+}
+
+func (v *VPS) deleteFloatingIP(r *http.Request) (interface{}, error) {
+	fip, err := v.floatingIPIn(r)
+	if err != nil {
+		return nil, err
+	}
+	if fip.PortID != "" {
+		return nil, conflict("floating IP %s is associated with %s", fip.ID, fip.DeviceID)
+	}
+	v.floatingIPs.remove(fip.ID)
+	return nil, nil
+}
+
+func (v *VPS) approveFloatingIP(r *http.Request) (interface{}, error) {
+	return nil, v.reviewFloatingIP(r, floatingips.FloatingIPStatusActive)
+}
+
+func (v *VPS) rejectFloatingIP(r *http.Request) (interface{}, error) {
+	return nil, v.reviewFloatingIP(r, floatingips.FloatingIPStatusRejected)
+}
+
+// reviewFloatingIP settles a PENDING floating IP immediately.
+func (v *VPS) reviewFloatingIP(r *http.Request, status floatingips.FloatingIPStatus) error {
+	fip, err := v.floatingIPIn(r)
+	if err != nil {
+		return err
+	}
+	if fip.Status != floatingips.FloatingIPStatusPending {
+		return conflict("floating IP %s is %s, not PENDING", fip.ID, fip.Status)
+	}
+	fip.Status = status
+	fip.ApprovedAt = v.st.timestamp()
+	return nil
+}
+
+func (v *VPS) disassociateFloatingIP(r *http.Request) (interface{}, error) {
+	fip, err := v.floatingIPIn(r)
+	if err != nil {
+		return nil, err
+	}
+	if fip.PortID == "" {
+		return nil, conflict("floating IP %s is not associated", fip.ID)
+	}
+	v.disassociate(fip)
+	return fip, nil
+}
+
+// disassociate unbinds fip from its NIC and server.
+func (v *VPS) disassociate(fip *floatingips.FloatingIP) {
+	if srv, ok := v.servers.get(fip.DeviceID); ok {
+		srv.PublicIPs = removeStrings(srv.PublicIPs, []string{fip.Address})
+		if nic, ok := v.nics[srv.ID].get(fip.PortID); ok {
+			nic.FloatingIP = nil
+		}
+	}
+	fip.PortID = ""
+	fip.DeviceID = ""
+	fip.DeviceName = ""
+	fip.DeviceType = ""
+	fip.UpdatedAt = v.st.timestamp()
+}
+
+// Security groups
+
+// securityGroupIn returns the security group with the request's {id} in the request's project.
+func (v *VPS) securityGroupIn(r *http.Request) (*securitygroups.SecurityGroup, error) {
+	sg, ok := v.securityGroups.get(r.PathValue("id"))
+	if !ok || sg.ProjectID != r.PathValue("project") {
+		return nil, notFound("security group", r.PathValue("id"))
+	}
+	return sg, nil
+}
+
+func (v *VPS) listSecurityGroups(r *http.Request) (interface{}, error) {
+	q := r.URL.Query()
+	list := []securitygroups.SecurityGroup{}
+	for _, sg := range v.securityGroups.list(func(sg *securitygroups.SecurityGroup) bool {
+		return sg.ProjectID == r.PathValue("project") &&
+			matchesName(q.Get("name"), sg.Name) &&
+			matches(q.Get("user_id"), sg.UserID)
+	}) {
+		list = append(list, *sg)
+	}
+	return securitygroups.SecurityGroupListResponse{SecurityGroups: list}, nil
+}
+
+func (v *VPS) createSecurityGroup(r *http.Request) (interface{}, error) {
+	var req securitygroups.SecurityGroupCreateRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, badRequest("name is required")
+	}
+
+	projectID := r.PathValue("project")
+	project, user := owner(projectID)
+	sg := &securitygroups.SecurityGroup{
+		ID:          v.st.newID(),
+		Name:        req.Name,
+		Description: req.Description,
+		ProjectID:   projectID,
+		Project:     &project,
+		UserID:      user.ID,
+		User:        &user,
+		Namespace:   DefaultNamespace,
+		Rules:       []securitygroups.SecurityGroupRule{},
+		CreatedAt:   v.st.timestamp(),
+		UpdatedAt:   v.st.timestamp(),
+	}
+	for _, ruleReq := range req.Rules {
+		rule, err := v.newRule(ruleReq)
+		if err != nil {
+			return nil, err
+		}
+		sg.Rules = append(sg.Rules, rule)
+	}
+	v.securityGroups.put(sg.ID, sg)
+	return sg, nil
+}
+
+func (v *VPS) getSecurityGroup(r *http.Request) (interface{}, error) {
+	return v.securityGroupIn(r)
+}
+
+func (v *VPS) updateSecurityGroup(r *http.Request) (interface{}, error) {
+	sg, err := v.securityGroupIn(r)
+	if err != nil {
+		return nil, err
+	}
+	var req securitygroups.SecurityGroupUpdateRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		sg.Name = *req.Name
+	}
+	if req.Description != nil {
+		sg.Description = *req.Description
+	}
+	sg.UpdatedAt = v.st.timestamp()
+	return sg, nil
+}
+
+func (v *VPS) deleteSecurityGroup(r *http.Request) (interface{}, error) {
+	sg, err := v.securityGroupIn(r)
+	if err != nil {
+		return nil, err
+	}
+	for _, nics := range v.nics {
+		for _, nic := range nics.list(nil) {
+			if containsString(nic.SGIDs, sg.ID) {
+				return nil, conflict("security group %s is in use by NIC %s", sg.ID, nic.ID)
+			}
+		}
+	}
+	v.securityGroups.remove(sg.ID)
+	return nil, nil
+}
+
+func (v *VPS) createSecurityGroupRule(r *http.Request) (interface{}, error) {
+	sg, err := v.securityGroupIn(r)
+	if err != nil {
+		return nil, err
+	}
+	var req securitygroups.SecurityGroupRuleCreateRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	rule, err := v.newRule(req)
+	if err != nil {
+		return nil, err
+	}
+	sg.Rules = append(sg.Rules, rule)
+	sg.UpdatedAt = v.st.timestamp()
+	return rule, nil
+}
+
+func (v *VPS) deleteSecurityGroupRule(r *http.Request) (interface{}, error) {
+	sg, err := v.securityGroupIn(r)
+	if err != nil {
+		return nil, err
+	}
+	for i, rule := range sg.Rules {
+		if rule.ID == r.PathValue("rule") {
+			sg.Rules = append(sg.Rules[:i], sg.Rules[i+1:]...)
+			sg.UpdatedAt = v.st.timestamp()
+			return nil, nil
+		}
+	}
+	return nil, notFound("rule", r.PathValue("rule"))
+}
+
+// newRule validates a rule request and assigns it an ID.
+func (v *VPS) newRule(req securitygroups.SecurityGroupRuleCreateRequest) (securitygroups.SecurityGroupRule, error) {
+	switch req.Direction {
+	case securitygroups.DirectionIngress, securitygroups.DirectionEgress:
+	default:
+		return securitygroups.SecurityGroupRule{}, badRequest("invalid direction %q", req.Direction)
+	}
+	switch req.Protocol {
+	case securitygroups.ProtocolTCP, securitygroups.ProtocolUDP, securitygroups.ProtocolICMP, securitygroups.ProtocolAny:
+	default:
+		return securitygroups.SecurityGroupRule{}, badRequest("invalid protocol %q", req.Protocol)
+	}
+	if _, err := netip.ParsePrefix(req.RemoteCIDR); err != nil {
+		return securitygroups.SecurityGroupRule{}, badRequest("invalid remote_cidr %q", req.RemoteCIDR)
+	}
+
+	rule := securitygroups.SecurityGroupRule{
+		ID:         v.st.newID(),
+		Direction:  req.Direction,
+		Protocol:   req.Protocol,
+		RemoteCIDR: req.RemoteCIDR,
+	}
+	if req.PortMin != nil {
+		rule.PortMin = *req.PortMin
+	}
+	if req.PortMax != nil {
+		rule.PortMax = *req.PortMax
+	}
+	if rule.PortMin > rule.PortMax {
+		return securitygroups.SecurityGroupRule{}, badRequest("port_min %d exceeds port_max %d", rule.PortMin, rule.PortMax)
+	}
+	return rule, nil
+}
@@ -0,0 +1,466 @@
+package cloudsdktest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Zillaforge/cloud-sdk/models/vps/common"
+	"github.com/Zillaforge/cloud-sdk/models/vps/floatingips"
+	"github.com/Zillaforge/cloud-sdk/models/vps/servers"
+	"github.com/Zillaforge/cloud-sdk/models/vps/volumes"
+)
+
+// serverIn returns the server with the request's {id} in the request's project.
+func (v *VPS) serverIn(r *http.Request) (*servers.Server, error) {
+	srv, ok := v.servers.get(r.PathValue("id"))
+	if !ok || srv.ProjectID != r.PathValue("project") {
+		return nil, notFound("server", r.PathValue("id"))
+	}
+	return srv, nil
+}
+
+func (v *VPS) listServers(r *http.Request) (interface{}, error) {
+	q := r.URL.Query()
+	list := v.servers.list(func(s *servers.Server) bool {
+		return s.ProjectID == r.PathValue("project") &&
+			matchesName(q.Get("name"), s.Name) &&
+			matches(q.Get("user_id"), s.UserID) &&
+			matches(q.Get("status"), string(s.Status)) &&
+			matches(q.Get("flavor_id"), s.FlavorID) &&
+			matches(q.Get("image_id"), s.ImageID)
+	})
+	return servers.ServersListResponse{Servers: list}, nil
+}
+
+func (v *VPS) createServer(r *http.Request) (interface{}, error) {
+	var req servers.ServerCreateRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Name == "" || req.ImageID == "" {
+		return nil, badRequest("name and image_id are required")
+	}
+	flavor, ok := v.flavors.get(req.FlavorID)
+	if !ok {
+		return nil, badRequest("flavor %s not found", req.FlavorID)
+	}
+	if len(req.NICs) == 0 {
+		return nil, badRequest("at least one NIC is required")
+	}
+	projectID := r.PathValue("project")
+	for _, nic := range req.NICs {
+		if n, ok := v.networks.get(nic.NetworkID); !ok || n.ProjectID != projectID {
+			return nil, badRequest("network %s not found", nic.NetworkID)
+		}
+		if _, err := v.securityGroupRefs(projectID, nicSecurityGroups(nic, req.SGIDs)); err != nil {
+			return nil, err
+		}
+	}
+	var keypair *common.IDName
+	if req.KeypairID != "" {
+		kp, ok := v.keypairs.get(req.KeypairID)
+		if !ok {
+			return nil, badRequest("keypair %s not found", req.KeypairID)
+		}
+		keypair = &common.IDName{ID: kp.ID, Name: kp.Name}
+	}
+
+	project, user := owner(projectID)
+	id := v.st.newID()
+	srv := &servers.Server{
+		ID:           id,
+		UUID:         id,
+		Name:         req.Name,
+		Description:  req.Description,
+		Status:       servers.ServerStatusBuild,
+		FlavorID:     flavor.ID,
+		Flavor:       &common.IDName{ID: flavor.ID, Name: flavor.Name},
+		ImageID:      req.ImageID,
+		ProjectID:    projectID,
+		Project:      &project,
+		UserID:       user.ID,
+		User:         &user,
+		KeypairID:    req.KeypairID,
+		Keypair:      keypair,
+		AZ:           "nova",
+		Namespace:    DefaultNamespace,
+		RootDiskSize: flavor.Disk,
+		BootScript:   req.BootScript,
+		CreatedAt:    v.st.timestamp(),
+		UpdatedAt:    v.st.timestamp(),
+	}
+	v.servers.put(id, srv)
+
+	v.nics[id] = newCollection[servers.ServerNIC]()
+	for _, nicReq := range req.NICs {
+		if _, err := v.createNIC(srv, nicReq.NetworkID, nicReq.FixedIP, nicSecurityGroups(nicReq, req.SGIDs)); err != nil {
+			return nil, err
+		}
+	}
+
+	v.st.after(func() {
+		if srv.Status == servers.ServerStatusBuild {
+			srv.Status = servers.ServerStatusActive
+			srv.UpdatedAt = v.st.timestamp()
+		}
+	})
+	return srv, nil
+}
+
+func (v *VPS) getServer(r *http.Request) (interface{}, error) {
+	return v.serverIn(r)
+}
+
+func (v *VPS) updateServer(r *http.Request) (interface{}, error) {
+	srv, err := v.serverIn(r)
+	if err != nil {
+		return nil, err
+	}
+	var req servers.ServerUpdateRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Name != "" {
+		srv.Name = req.Name
+	}
+	if req.Description != "" {
+		srv.Description = req.Description
+	}
+	srv.UpdatedAt = v.st.timestamp()
+	return srv, nil
+}
+
+// deleteServer marks the server DELETED and removes it, with its NICs and volume
+// attachments, once the transition delay has passed.
+func (v *VPS) deleteServer(r *http.Request) (interface{}, error) {
+	srv, err := v.serverIn(r)
+	if err != nil {
+		return nil, err
+	}
+	if srv.Status == servers.ServerStatusDeleted {
+		return nil, nil
+	}
+	srv.Status = servers.ServerStatusDeleted
+	v.st.after(func() {
+		for _, nic := range v.nics[srv.ID].list(nil) {
+			v.releaseFloatingIP(nic.ID)
+		}
+		delete(v.nics, srv.ID)
+		for _, vol := range v.volumes.list(func(vol *volumes.Volume) bool { return attachedTo(vol, srv.ID) }) {
+			vol.Attachments = nil
+			vol.Status = volumes.VolumeStatusAvailable
+		}
+		v.servers.remove(srv.ID)
+	})
+	return nil, nil
+}
+
+// serverAction applies power and lifecycle actions.
+func (v *VPS) serverAction(r *http.Request) (interface{}, error) {
+	srv, err := v.serverIn(r)
+	if err != nil {
+		return nil, err
+	}
+	var req servers.ServerActionRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+
+	transition := func(from, via, to servers.ServerStatus) error {
+		if srv.Status != from {
+			return conflict("cannot %s server in status %s", req.Action, srv.Status)
+		}
+		srv.Status = via
+		v.st.after(func() {
+			if srv.Status == via {
+				srv.Status = to
+				srv.UpdatedAt = v.st.timestamp()
+			}
+		})
+		return nil
+	}
+
+	switch req.Action {
+	case servers.ServerActionStop:
+		return nil, transition(servers.ServerStatusActive, servers.ServerStatusActive, servers.ServerStatusShutoff)
+	case servers.ServerActionStart:
+		return nil, transition(servers.ServerStatusShutoff, servers.ServerStatusShutoff, servers.ServerStatusActive)
+	case servers.ServerActionReboot:
+		return nil, transition(servers.ServerStatusActive, servers.ServerStatusReboot, servers.ServerStatusActive)
+	case servers.ServerActionResize:
+		flavor, ok := v.flavors.get(req.FlavorID)
+		if !ok {
+			return nil, badRequest("flavor %s not found", req.FlavorID)
+		}
+		srv.FlavorID = flavor.ID
+		srv.Flavor = &common.IDName{ID: flavor.ID, Name: flavor.Name}
+	case servers.ServerActionExtendRoot:
+		if req.RootSize <= srv.RootDiskSize {
+			return nil, badRequest("root_size must exceed the current size %d", srv.RootDiskSize)
+		}
+		srv.RootDiskSize = req.RootSize
+	case servers.ServerActionGetPwd:
+		if req.PrivateKey == "" {
+			return nil, badRequest("private_key is required for get_pwd")
+		}
+		return servers.ServerActionResponse{Password: "fake-password"}, nil
+	case servers.ServerActionApprove, servers.ServerActionReject:
+		// Accepted without effect
+	default:
+		return nil, badRequest("unknown action %q", req.Action)
+	}
+	srv.UpdatedAt = v.st.timestamp()
+	return nil, nil
+}
+
+func (v *VPS) serverMetrics(r *http.Request) (interface{}, error) {
+	if _, err := v.serverIn(r); err != nil {
+		return nil, err
+	}
+	metricType := r.URL.Query().Get("type")
+	if metricType == "" {
+		metricType = "cpu"
+	}
+	now := v.st.now().Unix()
+	measures := make([]servers.Measure, 3)
+	for i := range measures {
+		measures[i] = servers.Measure{Granularity: 60, Timestamp: now - int64(60*(len(measures)-i)), Value: float64(10 * (i + 1))}
+	}
+	return servers.ServerMetricsResponse{{Name: metricType, Measures: measures}}, nil
+}
+
+func (v *VPS) serverVNCURL(r *http.Request) (interface{}, error) {
+	srv, err := v.serverIn(r)
+	if err != nil {
+		return nil, err
+	}
+	if srv.Status != servers.ServerStatusActive {
+		return nil, conflict("server %s is not ACTIVE", srv.ID)
+	}
+	return servers.ServerConsoleURLResponse{URL: fmt.Sprintf("%s/vnc/%s?token=fake", v.st.baseURL, srv.ID)}, nil
+}
+
+// NICs
+
+// createNIC attaches a NIC on networkID to srv, allocating an address when fixedIP is empty.
+func (v *VPS) createNIC(srv *servers.Server, networkID, fixedIP string, sgIDs []string) (*servers.ServerNIC, error) {
+	network, ok := v.networks.get(networkID)
+	if !ok || network.ProjectID != srv.ProjectID {
+		return nil, badRequest("network %s not found", networkID)
+	}
+	groups, err := v.securityGroupRefs(srv.ProjectID, sgIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	address := fixedIP
+	if address == "" {
+		address = v.allocateAddress(network)
+	}
+	id := v.st.newID()
+	nic := &servers.ServerNIC{
+		ID:             id,
+		MAC:            fmt.Sprintf("fa:16:3e:%02x:%02x:%02x", v.st.nextID>>16&0xff, v.st.nextID>>8&0xff, v.st.nextID&0xff),
+		NetworkID:      network.ID,
+		Network:        &common.IDName{ID: network.ID, Name: network.Name},
+		Addresses:      []string{address},
+		SecurityGroups: groups,
+		SGIDs:          append([]string(nil), sgIDs...),
+	}
+	v.nics[srv.ID].put(id, nic)
+	srv.PrivateIPs = append(srv.PrivateIPs, address)
+	return nic, nil
+}
+
+// nicSecurityGroups returns the NIC's own security groups, or the server-wide ones
+// when the NIC sets none.
+func nicSecurityGroups(nic servers.ServerNICCreateRequest, serverSGIDs []string) []string {
+	if len(nic.SGIDs) == 0 {
+		return serverSGIDs
+	}
+	return nic.SGIDs
+}
+
+// securityGroupRefs resolves security group IDs in projectID.
+func (v *VPS) securityGroupRefs(projectID string, ids []string) ([]*common.IDName, error) {
+	refs := make([]*common.IDName, 0, len(ids))
+	for _, id := range ids {
+		sg, ok := v.securityGroups.get(id)
+		if !ok || sg.ProjectID != projectID {
+			return nil, badRequest("security group %s not found", id)
+		}
+		refs = append(refs, &common.IDName{ID: sg.ID, Name: sg.Name})
+	}
+	return refs, nil
+}
+
+// nicIn returns the NIC with the request's {nic} on the request's server.
+func (v *VPS) nicIn(r *http.Request) (*servers.Server, *servers.ServerNIC, error) {
+	srv, err := v.serverIn(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	nic, ok := v.nics[srv.ID].get(r.PathValue("nic"))
+	if !ok {
+		return nil, nil, notFound("nic", r.PathValue("nic"))
+	}
+	return srv, nic, nil
+}
+
+func (v *VPS) listNICs(r *http.Request) (interface{}, error) {
+	srv, err := v.serverIn(r)
+	if err != nil {
+		return nil, err
+	}
+	return servers.ServerNICsListResponse{NICs: v.nics[srv.ID].list(nil)}, nil
+}
+
+func (v *VPS) addNIC(r *http.Request) (interface{}, error) {
+	srv, err := v.serverIn(r)
+	if err != nil {
+		return nil, err
+	}
+	var req servers.ServerNICCreateRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	return v.createNIC(srv, req.NetworkID, req.FixedIP, req.SGIDs)
+}
+
+func (v *VPS) updateNIC(r *http.Request) (interface{}, error) {
+	srv, nic, err := v.nicIn(r)
+	if err != nil {
+		return nil, err
+	}
+	var req servers.ServerNICUpdateRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	groups, err := v.securityGroupRefs(srv.ProjectID, req.SGIDs)
+	if err != nil {
+		return nil, err
+	}
+	nic.SGIDs = append([]string(nil), req.SGIDs...)
+	nic.SecurityGroups = groups
+	return nic, nil
+}
+
+func (v *VPS) deleteNIC(r *http.Request) (interface{}, error) {
+	srv, nic, err := v.nicIn(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(v.nics[srv.ID].order) == 1 {
+		return nil, conflict("cannot remove the last NIC of server %s", srv.ID)
+	}
+	v.releaseFloatingIP(nic.ID)
+	v.nics[srv.ID].remove(nic.ID)
+	srv.PrivateIPs = removeStrings(srv.PrivateIPs, nic.Addresses)
+	return nil, nil
+}
+
+func (v *VPS) associateFloatingIP(r *http.Request) (interface{}, error) {
+	srv, nic, err := v.nicIn(r)
+	if err != nil {
+		return nil, err
+	}
+	var req servers.ServerNICAssociateFloatingIPRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+
+	var fip *floatingips.FloatingIP
+	if req.FIPID == "" {
+		// Allocate a new floating IP for the NIC
+		fip = v.newFloatingIP(srv.ProjectID, floatingips.FloatingIPCreateRequest{Name: srv.Name + "-fip"})
+		fip.Status = floatingips.FloatingIPStatusActive
+	} else {
+		existing, ok := v.floatingIPs.get(req.FIPID)
+		if !ok || existing.ProjectID != srv.ProjectID {
+			return nil, badRequest("floating IP %s not found", req.FIPID)
+		}
+		if existing.PortID != "" {
+			return nil, conflict("floating IP %s is already associated", existing.ID)
+		}
+		fip = existing
+	}
+
+	fip.PortID = nic.ID
+	fip.DeviceID = srv.ID
+	fip.DeviceName = srv.Name
+	fip.DeviceType = "server"
+	fip.UpdatedAt = v.st.timestamp()
+	nic.FloatingIP = fip
+	srv.PublicIPs = append(srv.PublicIPs, fip.Address)
+	return fip, nil
+}
+
+// releaseFloatingIP disassociates any floating IP bound to the NIC.
+func (v *VPS) releaseFloatingIP(nicID string) {
+	for _, fip := range v.floatingIPs.list(func(f *floatingips.FloatingIP) bool { return f.PortID == nicID }) {
+		v.disassociate(fip)
+	}
+}
+
+// Server volumes
+
+func (v *VPS) listServerVolumes(r *http.Request) (interface{}, error) {
+	srv, err := v.serverIn(r)
+	if err != nil {
+		return nil, err
+	}
+	disks := []*servers.ServerVolume{}
+	for i, vol := range v.volumes.list(func(vol *volumes.Volume) bool { return attachedTo(vol, srv.ID) }) {
+		disks = append(disks, &servers.ServerVolume{
+			VolumeID: vol.ID,
+			Device:   fmt.Sprintf("/dev/vd%c", 'b'+i),
+			Volume:   vol,
+		})
+	}
+	return servers.ServerVolumesResponse{Disks: disks}, nil
+}
+
+func (v *VPS) attachServerVolume(r *http.Request) (interface{}, error) {
+	srv, err := v.serverIn(r)
+	if err != nil {
+		return nil, err
+	}
+	vol, ok := v.volumes.get(r.PathValue("volume"))
+	if !ok || vol.ProjectID != srv.ProjectID {
+		return nil, notFound("volume", r.PathValue("volume"))
+	}
+	return nil, v.attach(vol, srv)
+}
+
+func (v *VPS) detachServerVolume(r *http.Request) (interface{}, error) {
+	srv, err := v.serverIn(r)
+	if err != nil {
+		return nil, err
+	}
+	vol, ok := v.volumes.get(r.PathValue("volume"))
+	if !ok || !attachedTo(vol, srv.ID) {
+		return nil, notFound("attached volume", r.PathValue("volume"))
+	}
+	return nil, v.detach(vol)
+}
+
+// attachedTo reports whether vol is attached to serverID.
+func attachedTo(vol *volumes.Volume, serverID string) bool {
+	for _, a := range vol.Attachments {
+		if a.ID == serverID {
+			return true
+		}
+	}
+	return false
+}
+
+// removeStrings returns list without any of the values in remove.
+func removeStrings(list, remove []string) []string {
+	out := list[:0]
+	for _, s := range list {
+		if !containsString(remove, s) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
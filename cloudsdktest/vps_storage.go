@@ -0,0 +1,316 @@
+package cloudsdktest
+
+import (
+	"net/http"
+
+	"github.com/Zillaforge/cloud-sdk/models/vps/common"
+	"github.com/Zillaforge/cloud-sdk/models/vps/servers"
+	"github.com/Zillaforge/cloud-sdk/models/vps/snapshots"
+	"github.com/Zillaforge/cloud-sdk/models/vps/volumes"
+)
+
+// defaultVolumeSize is used when a create request omits the size.
+const defaultVolumeSize = 10
+
+// Volumes
+
+// volumeIn returns the volume with the request's {id} in the request's project.
+func (v *VPS) volumeIn(r *http.Request) (*volumes.Volume, error) {
+	vol, ok := v.volumes.get(r.PathValue("id"))
+	if !ok || vol.ProjectID != r.PathValue("project") {
+		return nil, notFound("volume", r.PathValue("id"))
+	}
+	return vol, nil
+}
+
+func (v *VPS) listVolumes(r *http.Request) (interface{}, error) {
+	q := r.URL.Query()
+	list := v.volumes.list(func(vol *volumes.Volume) bool {
+		return vol.ProjectID == r.PathValue("project") &&
+			matchesName(q.Get("name"), vol.Name) &&
+			matches(q.Get("user_id"), vol.UserID) &&
+			matches(q.Get("status"), string(vol.Status)) &&
+			matches(q.Get("type"), vol.Type)
+	})
+	return volumes.VolumeListResponse{Volumes: list}, nil
+}
+
+func (v *VPS) createVolume(r *http.Request) (interface{}, error) {
+	var req volumes.CreateVolumeRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, badRequest("%v", err)
+	}
+	if !containsString(v.volumeTypes, req.Type) {
+		return nil, badRequest("volume type %s not found", req.Type)
+	}
+
+	projectID := r.PathValue("project")
+	size := req.Size
+	if req.SnapshotID != "" {
+		snap, ok := v.snapshots.get(req.SnapshotID)
+		if !ok || snap.ProjectID != projectID {
+			return nil, badRequest("snapshot %s not found", req.SnapshotID)
+		}
+		if size < snap.Size {
+			size = snap.Size
+		}
+	}
+	if size == 0 {
+		size = defaultVolumeSize
+	}
+
+	project, user := owner(projectID)
+	vol := &volumes.Volume{
+		ID:          v.st.newID(),
+		Name:        req.Name,
+		Description: req.Description,
+		Size:        size,
+		Type:        req.Type,
+		Status:      volumes.VolumeStatusCreating,
+		Project:     project,
+		ProjectID:   projectID,
+		User:        user,
+		UserID:      user.ID,
+		Namespace:   DefaultNamespace,
+		CreatedAt:   v.st.timePtr(),
+		UpdatedAt:   v.st.timePtr(),
+	}
+	v.volumes.put(vol.ID, vol)
+	v.settleVolume(vol, volumes.VolumeStatusCreating, volumes.VolumeStatusAvailable)
+	return vol, nil
+}
+
+func (v *VPS) getVolume(r *http.Request) (interface{}, error) {
+	return v.volumeIn(r)
+}
+
+func (v *VPS) updateVolume(r *http.Request) (interface{}, error) {
+	vol, err := v.volumeIn(r)
+	if err != nil {
+		return nil, err
+	}
+	var req volumes.UpdateVolumeRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Name != "" {
+		vol.Name = req.Name
+	}
+	if req.Description != "" {
+		vol.Description = req.Description
+	}
+	vol.UpdatedAt = v.st.timePtr()
+	return vol, nil
+}
+
+// deleteVolume refuses attached volumes, otherwise moves the volume through
+// deleting before removing it.
+func (v *VPS) deleteVolume(r *http.Request) (interface{}, error) {
+	vol, err := v.volumeIn(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(vol.Attachments) > 0 {
+		return nil, conflict("volume %s is attached to a server", vol.ID)
+	}
+	if len(v.snapshots.list(func(s *snapshots.Snapshot) bool { return s.VolumeID == vol.ID })) > 0 {
+		return nil, conflict("volume %s has snapshots", vol.ID)
+	}
+	vol.Status = volumes.VolumeStatusDeleting
+	v.st.after(func() {
+		v.volumes.remove(vol.ID)
+	})
+	return nil, nil
+}
+
+func (v *VPS) volumeAction(r *http.Request) (interface{}, error) {
+	vol, err := v.volumeIn(r)
+	if err != nil {
+		return nil, err
+	}
+	var req volumes.VolumeActionRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, badRequest("%v", err)
+	}
+
+	switch req.Action {
+	case volumes.VolumeActionAttach:
+		srv, ok := v.servers.get(req.ServerID)
+		if !ok || srv.ProjectID != vol.ProjectID {
+			return nil, badRequest("server %s not found", req.ServerID)
+		}
+		return nil, v.attach(vol, srv)
+	case volumes.VolumeActionDetach:
+		if !attachedTo(vol, req.ServerID) {
+			return nil, conflict("volume %s is not attached to server %s", vol.ID, req.ServerID)
+		}
+		return nil, v.detach(vol)
+	case volumes.VolumeActionExtend:
+		if vol.Status != volumes.VolumeStatusAvailable && vol.Status != volumes.VolumeStatusInUse {
+			return nil, conflict("cannot extend volume in status %s", vol.Status)
+		}
+		if req.NewSize <= vol.Size {
+			return nil, badRequest("new_size must exceed the current size %d", vol.Size)
+		}
+		settled := vol.Status
+		vol.Status = volumes.VolumeStatusExtending
+		v.st.after(func() {
+			vol.Size = req.NewSize
+		})
+		v.settleVolume(vol, volumes.VolumeStatusExtending, settled)
+	case volumes.VolumeActionRevert:
+		if vol.Status != volumes.VolumeStatusAvailable {
+			return nil, conflict("cannot revert volume in status %s", vol.Status)
+		}
+		if len(v.snapshots.list(func(s *snapshots.Snapshot) bool { return s.VolumeID == vol.ID })) == 0 {
+			return nil, conflict("volume %s has no snapshot to revert to", vol.ID)
+		}
+	}
+	vol.UpdatedAt = v.st.timePtr()
+	return nil, nil
+}
+
+// attach attaches vol to srv; the volume becomes in-use after the transition delay.
+func (v *VPS) attach(vol *volumes.Volume, srv *servers.Server) error {
+	if vol.Status != volumes.VolumeStatusAvailable {
+		return conflict("cannot attach volume in status %s", vol.Status)
+	}
+	vol.Attachments = []common.IDName{{ID: srv.ID, Name: srv.Name}}
+	v.st.after(func() {
+		if len(vol.Attachments) > 0 && vol.Status == volumes.VolumeStatusAvailable {
+			vol.Status = volumes.VolumeStatusInUse
+			vol.UpdatedAt = v.st.timePtr()
+		}
+	})
+	return nil
+}
+
+// detach moves vol through detaching back to available.
+func (v *VPS) detach(vol *volumes.Volume) error {
+	if vol.Status != volumes.VolumeStatusInUse {
+		return conflict("cannot detach volume in status %s", vol.Status)
+	}
+	vol.Status = volumes.VolumeStatusDetaching
+	v.st.after(func() {
+		vol.Attachments = nil
+	})
+	v.settleVolume(vol, volumes.VolumeStatusDetaching, volumes.VolumeStatusAvailable)
+	return nil
+}
+
+// settleVolume moves vol from a transitional status to settled after the
+// transition delay, unless something else changed its status first.
+func (v *VPS) settleVolume(vol *volumes.Volume, transitional, settled volumes.VolumeStatus) {
+	v.st.after(func() {
+		if vol.Status == transitional {
+			vol.Status = settled
+			vol.UpdatedAt = v.st.timePtr()
+		}
+	})
+}
+
+// Snapshots
+
+// snapshotIn returns the snapshot with the request's {id} in the request's project.
+func (v *VPS) snapshotIn(r *http.Request) (*snapshots.Snapshot, error) {
+	snap, ok := v.snapshots.get(r.PathValue("id"))
+	if !ok || snap.ProjectID != r.PathValue("project") {
+		return nil, notFound("snapshot", r.PathValue("id"))
+	}
+	return snap, nil
+}
+
+func (v *VPS) listSnapshots(r *http.Request) (interface{}, error) {
+	q := r.URL.Query()
+	list := v.snapshots.list(func(s *snapshots.Snapshot) bool {
+		return s.ProjectID == r.PathValue("project") &&
+			matchesName(q.Get("name"), s.Name) &&
+			matches(q.Get("volume_id"), s.VolumeID) &&
+			matches(q.Get("user_id"), s.UserID) &&
+			matches(q.Get("status"), string(s.Status))
+	})
+	return snapshots.SnapshotListResponse{Snapshots: list}, nil
+}
+
+func (v *VPS) createSnapshot(r *http.Request) (interface{}, error) {
+	var req snapshots.CreateSnapshotRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, badRequest("%v", err)
+	}
+	projectID := r.PathValue("project")
+	vol, ok := v.volumes.get(req.VolumeID)
+	if !ok || vol.ProjectID != projectID {
+		return nil, badRequest("volume %s not found", req.VolumeID)
+	}
+	if vol.Status != volumes.VolumeStatusAvailable && vol.Status != volumes.VolumeStatusInUse {
+		return nil, conflict("cannot snapshot volume in status %s", vol.Status)
+	}
+
+	project, user := owner(projectID)
+	snap := &snapshots.Snapshot{
+		ID:        v.st.newID(),
+		Name:      req.Name,
+		VolumeID:  vol.ID,
+		Size:      vol.Size,
+		Status:    snapshots.SnapshotStatusCreating,
+		Project:   project,
+		ProjectID: projectID,
+		User:      user,
+		UserID:    user.ID,
+		Namespace: DefaultNamespace,
+		CreatedAt: v.st.timePtr(),
+		UpdatedAt: v.st.timePtr(),
+	}
+	v.snapshots.put(snap.ID, snap)
+	v.st.after(func() {
+		if snap.Status == snapshots.SnapshotStatusCreating {
+			snap.Status = snapshots.SnapshotStatusAvailable
+			snap.UpdatedAt = v.st.timePtr()
+		}
+	})
+	return snap, nil
+}
+
+func (v *VPS) getSnapshot(r *http.Request) (interface{}, error) {
+	return v.snapshotIn(r)
+}
+
+func (v *VPS) updateSnapshot(r *http.Request) (interface{}, error) {
+	snap, err := v.snapshotIn(r)
+	if err != nil {
+		return nil, err
+	}
+	var req snapshots.UpdateSnapshotRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Name != "" {
+		snap.Name = req.Name
+	}
+	if req.Description != "" {
+		snap.Description = req.Description
+	}
+	snap.UpdatedAt = v.st.timePtr()
+	return snap, nil
+}
+
+func (v *VPS) deleteSnapshot(r *http.Request) (interface{}, error) {
+	snap, err := v.snapshotIn(r)
+	if err != nil {
+		return nil, err
+	}
+	snap.Status = snapshots.SnapshotStatusDeleting
+	v.st.after(func() {
+		v.snapshots.remove(snap.ID)
+	})
+	return nil, nil
+}
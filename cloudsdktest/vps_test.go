@@ -0,0 +1,396 @@
+package cloudsdktest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/internal/types"
+	floatingipsmodel "github.com/Zillaforge/cloud-sdk/models/vps/floatingips"
+	keypairsmodel "github.com/Zillaforge/cloud-sdk/models/vps/keypairs"
+	networksmodel "github.com/Zillaforge/cloud-sdk/models/vps/networks"
+	securitygroupsmodel "github.com/Zillaforge/cloud-sdk/models/vps/securitygroups"
+	serversmodel "github.com/Zillaforge/cloud-sdk/models/vps/servers"
+	snapshotsmodel "github.com/Zillaforge/cloud-sdk/models/vps/snapshots"
+	volumesmodel "github.com/Zillaforge/cloud-sdk/models/vps/volumes"
+	vps "github.com/Zillaforge/cloud-sdk/modules/vps/core"
+	"github.com/Zillaforge/cloud-sdk/waiter"
+)
+
+// newVPSClient starts a fake and returns a VPS client for project p1.
+func newVPSClient(t *testing.T, opts ...Option) (*Server, *vps.Client) {
+	t.Helper()
+	fake := NewServer(opts...)
+	t.Cleanup(fake.Close)
+	return fake, vps.NewClient(fake.URL+"/vps", "token", "p1", fake.Client(), nil)
+}
+
+// fastWait polls often enough for the short transition delays used in tests.
+var fastWait = []waiter.Option{waiter.WithInterval(5 * time.Millisecond), waiter.WithMaxWait(5 * time.Second)}
+
+// createNetwork creates a network in the client's project and returns its ID.
+func createNetwork(t *testing.T, client *vps.Client, cidr string) string {
+	t.Helper()
+	network, err := client.Networks().Create(context.Background(), &networksmodel.NetworkCreateRequest{Name: "net", CIDR: cidr})
+	if err != nil {
+		t.Fatalf("failed to create network: %v", err)
+	}
+	return network.ID
+}
+
+// createServer creates a server on networkID and returns it.
+func createServer(t *testing.T, client *vps.Client, name, networkID string) *serversmodel.Server {
+	t.Helper()
+	server, err := client.Servers().Create(context.Background(), &serversmodel.ServerCreateRequest{
+		Name:     name,
+		FlavorID: FlavorSmall,
+		ImageID:  "image-1",
+		NICs:     []serversmodel.ServerNICCreateRequest{{NetworkID: networkID}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	return server.Server
+}
+
+// statusCode returns the HTTP status of an SDK error, or 0.
+func statusCode(err error) int {
+	var sdkErr *types.SDKError
+	if errors.As(err, &sdkErr) {
+		return sdkErr.StatusCode
+	}
+	return 0
+}
+
+func TestVPS_ServerLifecycle(t *testing.T) {
+	ctx := context.Background()
+	fake, client := newVPSClient(t, WithTransitionDelay(20*time.Millisecond))
+	networkID := createNetwork(t, client, "10.0.0.0/24")
+
+	server := createServer(t, client, "web", networkID)
+	if server.Status != serversmodel.ServerStatusBuild {
+		t.Errorf("expected BUILD, got %s", server.Status)
+	}
+	if len(server.PrivateIPs) != 1 || server.PrivateIPs[0] != "10.0.0.10" {
+		t.Errorf("expected private IP 10.0.0.10, got %v", server.PrivateIPs)
+	}
+
+	if err := vps.WaitForServerActive(ctx, client.Servers(), server.ID, fastWait...); err != nil {
+		t.Fatalf("WaitForServerActive() failed: %v", err)
+	}
+
+	if err := client.Servers().Action(ctx, server.ID, &serversmodel.ServerActionRequest{Action: serversmodel.ServerActionStop}); err != nil {
+		t.Fatalf("stop failed: %v", err)
+	}
+	if err := vps.WaitForServerShutoff(ctx, client.Servers(), server.ID, fastWait...); err != nil {
+		t.Fatalf("WaitForServerShutoff() failed: %v", err)
+	}
+
+	network, err := client.Networks().Get(ctx, networkID)
+	if err != nil {
+		t.Fatalf("Networks().Get() failed: %v", err)
+	}
+	ports, err := network.Ports().List(ctx)
+	if err != nil {
+		t.Fatalf("Ports().List() failed: %v", err)
+	}
+	if len(ports) != 1 || ports[0].Server.ID != server.ID {
+		t.Errorf("expected one port on the server, got %+v", ports)
+	}
+
+	if err := client.Servers().Delete(ctx, server.ID); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if err := vps.WaitForServerDeleted(ctx, client.Servers(), server.ID, fastWait...); err != nil {
+		t.Fatalf("WaitForServerDeleted() failed: %v", err)
+	}
+	if n := fake.VPS.ServerCount("p1"); n != 0 {
+		t.Errorf("expected no servers left, got %d", n)
+	}
+	if err := client.Networks().Delete(ctx, networkID); err != nil {
+		t.Errorf("expected network without ports to be deletable: %v", err)
+	}
+}
+
+func TestVPS_ServerCreateValidation(t *testing.T) {
+	_, client := newVPSClient(t)
+	networkID := createNetwork(t, client, "10.0.0.0/24")
+
+	tests := []struct {
+		name string
+		req  serversmodel.ServerCreateRequest
+	}{
+		{name: "missing name", req: serversmodel.ServerCreateRequest{FlavorID: FlavorSmall, ImageID: "i", NICs: []serversmodel.ServerNICCreateRequest{{NetworkID: networkID}}}},
+		{name: "unknown flavor", req: serversmodel.ServerCreateRequest{Name: "s", FlavorID: "nope", ImageID: "i", NICs: []serversmodel.ServerNICCreateRequest{{NetworkID: networkID}}}},
+		{name: "no NICs", req: serversmodel.ServerCreateRequest{Name: "s", FlavorID: FlavorSmall, ImageID: "i"}},
+		{name: "unknown network", req: serversmodel.ServerCreateRequest{Name: "s", FlavorID: FlavorSmall, ImageID: "i", NICs: []serversmodel.ServerNICCreateRequest{{NetworkID: "nope"}}}},
+		{name: "unknown security group", req: serversmodel.ServerCreateRequest{Name: "s", FlavorID: FlavorSmall, ImageID: "i", SGIDs: []string{"nope"}, NICs: []serversmodel.ServerNICCreateRequest{{NetworkID: networkID}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Servers().Create(context.Background(), &tt.req)
+			if statusCode(err) != http.StatusBadRequest {
+				t.Errorf("expected 400, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVPS_ProjectIsolation(t *testing.T) {
+	ctx := context.Background()
+	fake, client := newVPSClient(t)
+	server := createServer(t, client, "web", createNetwork(t, client, "10.0.0.0/24"))
+
+	other := vps.NewClient(fake.URL+"/vps", "token", "p2", fake.Client(), nil)
+	if _, err := other.Servers().Get(ctx, server.ID); statusCode(err) != http.StatusNotFound {
+		t.Errorf("expected 404 from another project, got %v", err)
+	}
+	list, err := other.Servers().List(ctx, nil)
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	if len(list) != 0 {
+		t.Errorf("expected no servers in p2, got %d", len(list))
+	}
+}
+
+func TestVPS_ManualClock(t *testing.T) {
+	ctx := context.Background()
+	clock := newManualClock()
+	fake, client := newVPSClient(t, WithClock(clock.Now), WithTransitionDelay(time.Minute))
+	server := createServer(t, client, "web", createNetwork(t, client, "10.0.0.0/24"))
+
+	got, err := client.Servers().Get(ctx, server.ID)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if got.Status != serversmodel.ServerStatusBuild {
+		t.Errorf("expected BUILD before the clock moves, got %s", got.Status)
+	}
+
+	clock.Advance(time.Minute)
+	got, err = client.Servers().Get(ctx, server.ID)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if got.Status != serversmodel.ServerStatusActive {
+		t.Errorf("expected ACTIVE after the delay, got %s", got.Status)
+	}
+
+	if !fake.VPS.SetServerStatus(server.ID, serversmodel.ServerStatusError) {
+		t.Fatal("SetServerStatus() did not find the server")
+	}
+	err = vps.WaitForServerActive(ctx, client.Servers(), server.ID, fastWait...)
+	if err == nil {
+		t.Error("expected the waiter to fail on ERROR")
+	}
+}
+
+func TestVPS_VolumesAndSnapshots(t *testing.T) {
+	ctx := context.Background()
+	_, client := newVPSClient(t, WithTransitionDelay(20*time.Millisecond))
+	server := createServer(t, client, "db", createNetwork(t, client, "10.0.0.0/24"))
+
+	volume, err := client.Volumes().Create(ctx, &volumesmodel.CreateVolumeRequest{Name: "data", Type: "SSD", Size: 20})
+	if err != nil {
+		t.Fatalf("Volumes().Create() failed: %v", err)
+	}
+	if volume.Status != volumesmodel.VolumeStatusCreating {
+		t.Errorf("expected creating, got %s", volume.Status)
+	}
+	if err := vps.WaitForVolumeAvailable(ctx, client.Volumes(), volume.ID, fastWait...); err != nil {
+		t.Fatalf("WaitForVolumeAvailable() failed: %v", err)
+	}
+
+	if err := client.Volumes().Action(ctx, volume.ID, &volumesmodel.VolumeActionRequest{Action: volumesmodel.VolumeActionAttach, ServerID: server.ID}); err != nil {
+		t.Fatalf("attach failed: %v", err)
+	}
+	if err := vps.WaitForVolumeInUse(ctx, client.Volumes(), volume.ID, fastWait...); err != nil {
+		t.Fatalf("WaitForVolumeInUse() failed: %v", err)
+	}
+	if err := client.Volumes().Delete(ctx, volume.ID); statusCode(err) != http.StatusConflict {
+		t.Errorf("expected 409 deleting an attached volume, got %v", err)
+	}
+
+	serverResource, err := client.Servers().Get(ctx, server.ID)
+	if err != nil {
+		t.Fatalf("Servers().Get() failed: %v", err)
+	}
+	disks, err := serverResource.Volumes().List(ctx)
+	if err != nil {
+		t.Fatalf("Volumes().List() failed: %v", err)
+	}
+	if len(disks) != 1 || disks[0].VolumeID != volume.ID {
+		t.Errorf("expected the attached volume, got %+v", disks)
+	}
+
+	snapshot, err := client.Snapshots().Create(ctx, &snapshotsmodel.CreateSnapshotRequest{Name: "snap", VolumeID: volume.ID})
+	if err != nil {
+		t.Fatalf("Snapshots().Create() failed: %v", err)
+	}
+	if err := vps.WaitForSnapshotAvailable(ctx, client.Snapshots(), snapshot.ID, fastWait...); err != nil {
+		t.Fatalf("WaitForSnapshotAvailable() failed: %v", err)
+	}
+
+	if err := serverResource.Volumes().Detach(ctx, volume.ID); err != nil {
+		t.Fatalf("Detach() failed: %v", err)
+	}
+	if err := vps.WaitForVolumeAvailable(ctx, client.Volumes(), volume.ID, fastWait...); err != nil {
+		t.Fatalf("WaitForVolumeAvailable() after detach failed: %v", err)
+	}
+
+	if err := client.Volumes().Action(ctx, volume.ID, &volumesmodel.VolumeActionRequest{Action: volumesmodel.VolumeActionExtend, NewSize: 40}); err != nil {
+		t.Fatalf("extend failed: %v", err)
+	}
+	if err := vps.WaitForVolumeAvailable(ctx, client.Volumes(), volume.ID, fastWait...); err != nil {
+		t.Fatalf("WaitForVolumeAvailable() after extend failed: %v", err)
+	}
+	extended, err := client.Volumes().Get(ctx, volume.ID)
+	if err != nil {
+		t.Fatalf("Volumes().Get() failed: %v", err)
+	}
+	if extended.Size != 40 {
+		t.Errorf("expected size 40, got %d", extended.Size)
+	}
+
+	restored, err := client.Volumes().Create(ctx, &volumesmodel.CreateVolumeRequest{Name: "restored", Type: "HDD", SnapshotID: snapshot.ID})
+	if err != nil {
+		t.Fatalf("create from snapshot failed: %v", err)
+	}
+	if restored.Size != 20 {
+		t.Errorf("expected the snapshot size 20, got %d", restored.Size)
+	}
+
+	if _, err := client.Volumes().Create(ctx, &volumesmodel.CreateVolumeRequest{Name: "bad", Type: "NVMe"}); statusCode(err) != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown volume type, got %v", err)
+	}
+}
+
+func TestVPS_FloatingIPs(t *testing.T) {
+	ctx := context.Background()
+	_, client := newVPSClient(t, WithTransitionDelay(20*time.Millisecond))
+	server := createServer(t, client, "web", createNetwork(t, client, "10.0.0.0/24"))
+
+	fip, err := client.FloatingIPs().Create(ctx, &floatingipsmodel.FloatingIPCreateRequest{Name: "public"})
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if fip.Status != floatingipsmodel.FloatingIPStatusPending {
+		t.Errorf("expected PENDING, got %s", fip.Status)
+	}
+	if err := vps.WaitForFloatingIPActive(ctx, client.FloatingIPs(), fip.ID, fastWait...); err != nil {
+		t.Fatalf("WaitForFloatingIPActive() failed: %v", err)
+	}
+
+	serverResource, err := client.Servers().Get(ctx, server.ID)
+	if err != nil {
+		t.Fatalf("Servers().Get() failed: %v", err)
+	}
+	nics, err := serverResource.NICs().List(ctx)
+	if err != nil || len(nics) != 1 {
+		t.Fatalf("expected one NIC, got %v (err %v)", nics, err)
+	}
+	associated, err := serverResource.NICs().AssociateFloatingIP(ctx, nics[0].ID, &serversmodel.ServerNICAssociateFloatingIPRequest{FIPID: fip.ID})
+	if err != nil {
+		t.Fatalf("AssociateFloatingIP() failed: %v", err)
+	}
+	if associated.DeviceID != server.ID || associated.PortID != nics[0].ID {
+		t.Errorf("expected the floating IP bound to the NIC, got %+v", associated)
+	}
+	if err := client.FloatingIPs().Delete(ctx, fip.ID); statusCode(err) != http.StatusConflict {
+		t.Errorf("expected 409 deleting an associated floating IP, got %v", err)
+	}
+
+	if err := client.FloatingIPs().Disassociate(ctx, fip.ID); err != nil {
+		t.Fatalf("Disassociate() failed: %v", err)
+	}
+	if err := client.FloatingIPs().Delete(ctx, fip.ID); err != nil {
+		t.Errorf("Delete() failed: %v", err)
+	}
+
+	pending, err := client.FloatingIPs().Create(ctx, &floatingipsmodel.FloatingIPCreateRequest{Name: "rejected"})
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if err := client.FloatingIPs().Reject(ctx, pending.ID); err != nil {
+		t.Fatalf("Reject() failed: %v", err)
+	}
+	if err := vps.WaitForFloatingIPActive(ctx, client.FloatingIPs(), pending.ID, fastWait...); err == nil {
+		t.Error("expected the waiter to fail on REJECTED")
+	}
+}
+
+func TestVPS_SecurityGroupsAndKeypairs(t *testing.T) {
+	ctx := context.Background()
+	_, client := newVPSClient(t)
+
+	port := 22
+	sg, err := client.SecurityGroups().Create(ctx, securitygroupsmodel.SecurityGroupCreateRequest{
+		Name: "ssh",
+		Rules: []securitygroupsmodel.SecurityGroupRuleCreateRequest{{
+			Direction: securitygroupsmodel.DirectionIngress, Protocol: securitygroupsmodel.ProtocolTCP,
+			PortMin: &port, PortMax: &port, RemoteCIDR: "0.0.0.0/0",
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if len(sg.SecurityGroup.Rules) != 1 || sg.SecurityGroup.Rules[0].ID == "" {
+		t.Fatalf("expected one rule with an ID, got %+v", sg.SecurityGroup.Rules)
+	}
+
+	rule, err := sg.Rules().Create(ctx, securitygroupsmodel.SecurityGroupRuleCreateRequest{
+		Direction: securitygroupsmodel.DirectionEgress, Protocol: securitygroupsmodel.ProtocolAny, RemoteCIDR: "0.0.0.0/0",
+	})
+	if err != nil {
+		t.Fatalf("Rules().Create() failed: %v", err)
+	}
+	if err := sg.Rules().Delete(ctx, rule.ID); err != nil {
+		t.Errorf("Rules().Delete() failed: %v", err)
+	}
+	if _, err := sg.Rules().Create(ctx, securitygroupsmodel.SecurityGroupRuleCreateRequest{Direction: "sideways", Protocol: securitygroupsmodel.ProtocolTCP, RemoteCIDR: "0.0.0.0/0"}); statusCode(err) != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid direction, got %v", err)
+	}
+
+	kp, err := client.Keypairs().Create(ctx, &keypairsmodel.KeypairCreateRequest{Name: "deploy"})
+	if err != nil {
+		t.Fatalf("Keypairs().Create() failed: %v", err)
+	}
+	if kp.PrivateKey == "" || kp.Fingerprint == "" {
+		t.Errorf("expected a generated key pair, got %+v", kp)
+	}
+	listed, err := client.Keypairs().List(ctx, nil)
+	if err != nil {
+		t.Fatalf("Keypairs().List() failed: %v", err)
+	}
+	if len(listed) != 1 || listed[0].PrivateKey != "" {
+		t.Errorf("expected one key pair without its private key, got %+v", listed)
+	}
+	if _, err := client.Keypairs().Create(ctx, &keypairsmodel.KeypairCreateRequest{Name: "deploy"}); statusCode(err) != http.StatusConflict {
+		t.Errorf("expected 409 for a duplicate name, got %v", err)
+	}
+}
+
+func TestVPS_FlavorsAndVolumeTypes(t *testing.T) {
+	ctx := context.Background()
+	fake, client := newVPSClient(t)
+
+	flavors, err := client.Flavors().List(ctx, nil)
+	if err != nil {
+		t.Fatalf("Flavors().List() failed: %v", err)
+	}
+	if len(flavors) != 2 {
+		t.Errorf("expected the two seeded flavors, got %d", len(flavors))
+	}
+
+	fake.VPS.SetVolumeTypes("NVMe")
+	types, err := client.VolumeTypes().List(ctx)
+	if err != nil {
+		t.Fatalf("VolumeTypes().List() failed: %v", err)
+	}
+	if len(types) != 1 || types[0] != "NVMe" {
+		t.Errorf("expected [NVMe], got %v", types)
+	}
+}
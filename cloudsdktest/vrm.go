@@ -0,0 +1,573 @@
+package cloudsdktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/models/vrm/common"
+	"github.com/Zillaforge/cloud-sdk/models/vrm/repositories"
+	"github.com/Zillaforge/cloud-sdk/models/vrm/tags"
+)
+
+// VRM is the state of the fake VRM service. Repositories belong to the project in
+// the request path and to the namespace selected by X-Namespace; tags share their
+// repository's project and namespace.
+type VRM struct {
+	st  *state
+	vps *VPS
+
+	repositories *collection[common.Repository] // stored without Tags
+	tags         *collection[common.Tag]        // stored without Repository
+	downloads    []string
+}
+
+func newVRM(st *state, vps *VPS) *VRM {
+	return &VRM{
+		st:           st,
+		vps:          vps,
+		repositories: newCollection[common.Repository](),
+		tags:         newCollection[common.Tag](),
+	}
+}
+
+// SetTagStatus forces a tag into status, e.g. error. It reports whether the tag exists.
+func (v *VRM) SetTagStatus(tagID string, status common.TagStatus) bool {
+	v.st.mu.Lock()
+	defer v.st.mu.Unlock()
+	tag, ok := v.tags.get(tagID)
+	if ok {
+		tag.Status = status
+	}
+	return ok
+}
+
+// Downloads returns the file paths requested through tag downloads, in order.
+func (v *VRM) Downloads() []string {
+	v.st.mu.Lock()
+	defer v.st.mu.Unlock()
+	return append([]string(nil), v.downloads...)
+}
+
+// register mounts the VRM routes under prefix, which must contain {project}.
+func (v *VRM) register(mux *http.ServeMux, prefix string) {
+	h := v.st.handler
+	route := func(method, path string, status int, fn func(*http.Request) (interface{}, error)) {
+		mux.HandleFunc(method+" "+prefix+path, h(status, fn))
+	}
+
+	route("GET", "/repositories", http.StatusOK, v.listRepositories)
+	route("POST", "/repository", http.StatusCreated, v.createRepository)
+	route("GET", "/repository/{id}", http.StatusOK, v.getRepository)
+	route("PUT", "/repository/{id}", http.StatusOK, v.updateRepository)
+	route("DELETE", "/repository/{id}", http.StatusNoContent, v.deleteRepository)
+	route("GET", "/repository/{id}/tags", http.StatusOK, v.listRepositoryTags)
+	route("POST", "/repository/{id}/tag", http.StatusCreated, v.createTag)
+
+	route("GET", "/tags", http.StatusOK, v.listTags)
+	route("GET", "/tag/{id}", http.StatusOK, v.getTag)
+	route("PUT", "/tag/{id}", http.StatusOK, v.updateTag)
+	route("DELETE", "/tag/{id}", http.StatusNoContent, v.deleteTag)
+	route("POST", "/tag/{id}/download", http.StatusAccepted, v.downloadTag)
+
+	route("POST", "/upload", http.StatusCreated, v.upload)
+	route("POST", "/server/{id}/snapshot", http.StatusCreated, v.snapshotServer)
+}
+
+// Views
+
+// repositoryView returns repo with its tags, as the API returns it.
+func (v *VRM) repositoryView(repo *common.Repository) *common.Repository {
+	view := *repo
+	view.Tags = []*common.Tag{}
+	for _, tag := range v.tags.list(func(t *common.Tag) bool { return t.RepositoryID == repo.ID }) {
+		t := *tag
+		view.Tags = append(view.Tags, &t)
+	}
+	view.Count = len(view.Tags)
+	return &view
+}
+
+// tagView returns tag with its repository, as the API returns it.
+func (v *VRM) tagView(tag *common.Tag) *common.Tag {
+	view := *tag
+	if repo, ok := v.repositories.get(tag.RepositoryID); ok {
+		r := *repo
+		r.Count = len(v.tags.list(func(t *common.Tag) bool { return t.RepositoryID == repo.ID }))
+		view.Repository = &r
+	}
+	return &view
+}
+
+// Lookups
+
+// repositoryIn returns the repository with id if it is visible to the request.
+func (v *VRM) repositoryIn(r *http.Request, id string) (*common.Repository, error) {
+	repo, ok := v.repositories.get(id)
+	if !ok || repo.Project.ID != r.PathValue("project") || !inNamespace(r, repo.Namespace) {
+		return nil, notFound("repository", id)
+	}
+	return repo, nil
+}
+
+// tagIn returns the tag with id if its repository is visible to the request.
+func (v *VRM) tagIn(r *http.Request, id string) (*common.Tag, error) {
+	tag, ok := v.tags.get(id)
+	if !ok {
+		return nil, notFound("tag", id)
+	}
+	if _, err := v.repositoryIn(r, tag.RepositoryID); err != nil {
+		return nil, notFound("tag", id)
+	}
+	return tag, nil
+}
+
+// Repositories
+
+func (v *VRM) listRepositories(r *http.Request) (interface{}, error) {
+	var views []*common.Repository
+	for _, repo := range v.repositories.list(func(repo *common.Repository) bool {
+		return repo.Project.ID == r.PathValue("project") && inNamespace(r, repo.Namespace)
+	}) {
+		views = append(views, v.repositoryView(repo))
+	}
+	filtered, err := where(r, views, repositoryFieldAliases)
+	if err != nil {
+		return nil, err
+	}
+	page, total, err := paginate(r, filtered)
+	if err != nil {
+		return nil, err
+	}
+	return repositories.ListRepositoriesResponse{Repositories: nonNil(page), Total: total}, nil
+}
+
+func (v *VRM) createRepository(r *http.Request) (interface{}, error) {
+	var req repositories.CreateRepositoryRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, badRequest("%v", err)
+	}
+	repo, err := v.newRepository(r, req.Name, req.OperatingSystem, req.Description)
+	if err != nil {
+		return nil, err
+	}
+	return v.repositoryView(repo), nil
+}
+
+// newRepository stores a repository in the request's project and namespace.
+// Names are unique per project and namespace.
+func (v *VRM) newRepository(r *http.Request, name, operatingSystem, description string) (*common.Repository, error) {
+	namespace := requestNamespace(r)
+	projectID := r.PathValue("project")
+	for _, existing := range v.repositories.list(nil) {
+		if existing.Name == name && existing.Project.ID == projectID && existing.Namespace == namespace {
+			return nil, conflict("repository %s already exists", name)
+		}
+	}
+
+	now := v.now()
+	repo := &common.Repository{
+		ID:              v.st.newID(),
+		Name:            name,
+		Namespace:       namespace,
+		OperatingSystem: operatingSystem,
+		Description:     description,
+		Creator:         &common.IDName{ID: DefaultUserID, Name: DefaultUserName, Account: DefaultUserName},
+		Project:         &common.IDName{ID: projectID, Name: projectID},
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	v.repositories.put(repo.ID, repo)
+	return repo, nil
+}
+
+func (v *VRM) getRepository(r *http.Request) (interface{}, error) {
+	repo, err := v.repositoryIn(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+	return v.repositoryView(repo), nil
+}
+
+func (v *VRM) updateRepository(r *http.Request) (interface{}, error) {
+	repo, err := v.repositoryIn(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+	var req repositories.UpdateRepositoryRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	repo.Description = req.Description
+	repo.UpdatedAt = v.now()
+	return v.repositoryView(repo), nil
+}
+
+// deleteRepository removes the repository together with its tags.
+func (v *VRM) deleteRepository(r *http.Request) (interface{}, error) {
+	repo, err := v.repositoryIn(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range v.tags.list(func(t *common.Tag) bool { return t.RepositoryID == repo.ID }) {
+		v.tags.remove(tag.ID)
+	}
+	v.repositories.remove(repo.ID)
+	return nil, nil
+}
+
+// Tags
+
+func (v *VRM) listTags(r *http.Request) (interface{}, error) {
+	return v.tagPage(r, func(t *common.Tag) bool {
+		_, err := v.repositoryIn(r, t.RepositoryID)
+		return err == nil
+	})
+}
+
+func (v *VRM) listRepositoryTags(r *http.Request) (interface{}, error) {
+	repo, err := v.repositoryIn(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+	return v.tagPage(r, func(t *common.Tag) bool { return t.RepositoryID == repo.ID })
+}
+
+// tagPage filters and pages the tags accepted by keep.
+func (v *VRM) tagPage(r *http.Request, keep func(*common.Tag) bool) (interface{}, error) {
+	var views []*common.Tag
+	for _, tag := range v.tags.list(keep) {
+		views = append(views, v.tagView(tag))
+	}
+	filtered, err := where(r, views, tagFieldAliases)
+	if err != nil {
+		return nil, err
+	}
+	page, total, err := paginate(r, filtered)
+	if err != nil {
+		return nil, err
+	}
+	return tags.ListTagsResponse{Tags: nonNil(page), Total: total}, nil
+}
+
+func (v *VRM) createTag(r *http.Request) (interface{}, error) {
+	repo, err := v.repositoryIn(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+	var req tags.CreateTagRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, badRequest("%v", err)
+	}
+	tag, err := v.newTag(repo, req.Name, req.Type, req.DiskFormat, req.ContainerFormat, 0)
+	if err != nil {
+		return nil, err
+	}
+	return v.tagView(tag), nil
+}
+
+// newTag stores a queued tag in repo and schedules queued→saving→active.
+// Tag names are unique per repository.
+func (v *VRM) newTag(repo *common.Repository, name, tagType, diskFormat, containerFormat string, size int64) (*common.Tag, error) {
+	if tagType == "" {
+		tagType = string(common.TagTypeCommon)
+	}
+	if !common.TagType(tagType).IsValid() {
+		return nil, badRequest("invalid tag type %q", tagType)
+	}
+	if len(v.tags.list(func(t *common.Tag) bool { return t.RepositoryID == repo.ID && t.Name == name })) > 0 {
+		return nil, conflict("tag %s already exists in repository %s", name, repo.Name)
+	}
+
+	now := v.now()
+	tag := &common.Tag{
+		ID:           v.st.newID(),
+		Name:         name,
+		RepositoryID: repo.ID,
+		Type:         common.TagType(tagType),
+		Size:         size,
+		Extra:        map[string]interface{}{"diskFormat": diskFormat, "containerFormat": containerFormat},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	v.tags.put(tag.ID, tag)
+	repo.UpdatedAt = now
+	v.progressTag(tag)
+	return tag, nil
+}
+
+// progressTag moves tag through queued and saving to active, one transition
+// delay per step, unless its status is changed in between.
+func (v *VRM) progressTag(tag *common.Tag) {
+	tag.Status = common.TagStatusQueued
+	v.st.after(func() {
+		if tag.Status != common.TagStatusQueued {
+			return
+		}
+		tag.Status = common.TagStatusSaving
+		tag.UpdatedAt = v.now()
+		v.st.after(func() {
+			if tag.Status == common.TagStatusSaving {
+				tag.Status = common.TagStatusActive
+				tag.UpdatedAt = v.now()
+			}
+		})
+	})
+}
+
+func (v *VRM) getTag(r *http.Request) (interface{}, error) {
+	tag, err := v.tagIn(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+	return v.tagView(tag), nil
+}
+
+func (v *VRM) updateTag(r *http.Request) (interface{}, error) {
+	tag, err := v.tagIn(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+	var req tags.UpdateTagRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Name != "" {
+		tag.Name = req.Name
+	}
+	tag.UpdatedAt = v.now()
+	return v.tagView(tag), nil
+}
+
+func (v *VRM) deleteTag(r *http.Request) (interface{}, error) {
+	tag, err := v.tagIn(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+	v.tags.remove(tag.ID)
+	return nil, nil
+}
+
+func (v *VRM) downloadTag(r *http.Request) (interface{}, error) {
+	tag, err := v.tagIn(r, r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+	var req tags.DownloadTagRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, badRequest("%v", err)
+	}
+	if tag.Status != common.TagStatusActive && tag.Status != common.TagStatusAvailable {
+		return nil, conflict("cannot download tag in status %s", tag.Status)
+	}
+	v.downloads = append(v.downloads, req.Filepath)
+	return nil, nil
+}
+
+// Upload and snapshot
+
+// upload imports an image into a new repository, a new tag of an existing
+// repository, or an existing tag, depending on which IDs the request sets.
+func (v *VRM) upload(r *http.Request) (interface{}, error) {
+	var req repositories.UploadImageRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Filepath) == "" {
+		return nil, badRequest("filepath is required")
+	}
+
+	var tag *common.Tag
+	switch {
+	case req.TagID != "":
+		existing, err := v.tagIn(r, req.TagID)
+		if err != nil {
+			return nil, err
+		}
+		tag = existing
+		tag.UpdatedAt = v.now()
+		v.progressTag(tag)
+	case req.RepositoryID != "":
+		repo, err := v.repositoryIn(r, req.RepositoryID)
+		if err != nil {
+			return nil, err
+		}
+		if tag, err = v.newTag(repo, req.Version, req.Type, req.DiskFormat, req.ContainerFormat, 0); err != nil {
+			return nil, err
+		}
+	default:
+		if req.Name == "" || req.Version == "" {
+			return nil, badRequest("name and version are required for a new repository")
+		}
+		repo, err := v.newRepository(r, req.Name, req.OperatingSystem, "")
+		if err != nil {
+			return nil, err
+		}
+		if tag, err = v.newTag(repo, req.Version, req.Type, req.DiskFormat, req.ContainerFormat, 0); err != nil {
+			v.repositories.remove(repo.ID)
+			return nil, err
+		}
+	}
+
+	repo, _ := v.repositories.get(tag.RepositoryID)
+	return repositories.UploadImageResponse{Repository: v.repositoryView(repo), Tag: v.tagView(tag)}, nil
+}
+
+// snapshotServer images a server of the fake VPS into a new or existing repository.
+func (v *VRM) snapshotServer(r *http.Request) (interface{}, error) {
+	srv, ok := v.vps.servers.get(r.PathValue("id"))
+	if !ok || srv.ProjectID != r.PathValue("project") {
+		return nil, notFound("server", r.PathValue("id"))
+	}
+	var req repositories.CreateSnapshotRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Version == "" {
+		return nil, badRequest("version is required")
+	}
+
+	var repo *common.Repository
+	var err error
+	if req.RepositoryID != "" {
+		if repo, err = v.repositoryIn(r, req.RepositoryID); err != nil {
+			return nil, err
+		}
+	} else {
+		if req.Name == "" || req.OperatingSystem == "" {
+			return nil, badRequest("name and operatingSystem are required for a new repository")
+		}
+		if repo, err = v.newRepository(r, req.Name, req.OperatingSystem, req.Description); err != nil {
+			return nil, err
+		}
+	}
+
+	size := int64(srv.RootDiskSize) << 30
+	tag, err := v.newTag(repo, req.Version, string(common.TagTypeCommon), "qcow2", "bare", size)
+	if err != nil {
+		return nil, err
+	}
+	return repositories.CreateSnapshotResponse{Repository: v.repositoryView(repo), Tag: v.tagView(tag)}, nil
+}
+
+// now returns the fake time at the second precision the VRM API serializes.
+func (v *VRM) now() time.Time {
+	return v.st.now().UTC().Truncate(time.Second)
+}
+
+// Where filters
+
+// Short names accepted in where filters, mapped to JSON field paths.
+var (
+	repositoryFieldAliases = map[string]string{
+		"os":         "operatingSystem",
+		"creator":    "creator.id",
+		"project-id": "project.id",
+	}
+	tagFieldAliases = map[string]string{
+		"repository-id": "repositoryID",
+		"namespace":     "repository.namespace",
+		"project-id":    "repository.project.id",
+	}
+)
+
+// where keeps the items matching every "field=value" where query parameter.
+// Fields are JSON field names, optionally dotted into nested objects, or aliases.
+func where[T any](r *http.Request, items []*T, aliases map[string]string) ([]*T, error) {
+	type condition struct{ path, value string }
+	var conditions []condition
+	fields := jsonFieldNames[T]()
+	for _, filter := range r.URL.Query()["where"] {
+		field, value, ok := strings.Cut(filter, "=")
+		if !ok || field == "" {
+			return nil, badRequest("invalid where filter %q, expected field=value", filter)
+		}
+		path := field
+		if alias, ok := aliases[field]; ok {
+			path = alias
+		}
+		if top, _, _ := strings.Cut(path, "."); !fields[top] {
+			return nil, badRequest("unknown where field %q", field)
+		}
+		conditions = append(conditions, condition{path: path, value: value})
+	}
+	if len(conditions) == 0 {
+		return items, nil
+	}
+
+	var out []*T
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		var doc map[string]interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+
+		keep := true
+		for _, c := range conditions {
+			if got, found := lookup(doc, c.path); !found || got != c.value {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
+
+// lookup returns the string form of the value at a dotted path in doc.
+func lookup(doc map[string]interface{}, path string) (string, bool) {
+	var cur interface{} = doc
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := cur.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		if cur, ok = obj[key]; !ok {
+			return "", false
+		}
+	}
+	switch val := cur.(type) {
+	case string:
+		return val, true
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(val), true
+	case nil:
+		return "", true
+	default:
+		return fmt.Sprint(val), true
+	}
+}
+
+// jsonFieldNames returns the JSON names of the fields of struct type T.
+func jsonFieldNames[T any]() map[string]bool {
+	names := make(map[string]bool)
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,203 @@
+package cloudsdktest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	"github.com/Zillaforge/cloud-sdk/models/vrm/common"
+	repositoriesmodel "github.com/Zillaforge/cloud-sdk/models/vrm/repositories"
+	tagsmodel "github.com/Zillaforge/cloud-sdk/models/vrm/tags"
+	"github.com/Zillaforge/cloud-sdk/models/vrm/vrmfilter"
+	vrm "github.com/Zillaforge/cloud-sdk/modules/vrm/core"
+)
+
+// newVRMClient starts a fake and returns a VRM client for the default project,
+// resolved through IAM by its projectSysCode.
+func newVRMClient(t *testing.T, opts ...Option) (*Server, *vrm.Client) {
+	t.Helper()
+	fake, client := newSDKClient(t, opts...)
+	project, err := client.Project(context.Background(), DefaultProjectSysCode)
+	if err != nil {
+		t.Fatalf("Project() failed: %v", err)
+	}
+	return fake, project.VRM()
+}
+
+// repositoryWhere serializes filter for a repository listing.
+func repositoryWhere(t *testing.T, filter vrmfilter.Filter) []string {
+	t.Helper()
+	where, err := filter.Repositories()
+	if err != nil {
+		t.Fatalf("Repositories() failed: %v", err)
+	}
+	return where
+}
+
+func TestVRM_UploadAndWaitForTagActive(t *testing.T) {
+	ctx := context.Background()
+	_, client := newVRMClient(t, WithTransitionDelay(20*time.Millisecond))
+
+	resp, err := client.Repositories().Upload(ctx, &repositoriesmodel.UploadToNewRepositoryRequest{
+		Name: "ubuntu", Version: "22.04", Type: "common", DiskFormat: "qcow2", ContainerFormat: "bare",
+		OperatingSystem: "linux", Filepath: "dss-public://images/ubuntu.qcow2",
+	})
+	if err != nil {
+		t.Fatalf("Upload() failed: %v", err)
+	}
+	if resp.Tag.Status != common.TagStatusQueued {
+		t.Errorf("expected queued, got %s", resp.Tag.Status)
+	}
+
+	if err := vrm.WaitForTagActive(ctx, client.Tags(), resp.Tag.ID, fastWait...); err != nil {
+		t.Fatalf("WaitForTagActive() failed: %v", err)
+	}
+
+	repo, err := client.Repositories().Get(ctx, resp.Repository.ID)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if repo.Count != 1 || len(repo.Repository.Tags) != 1 || repo.Repository.Tags[0].Status != common.TagStatusActive {
+		t.Errorf("expected one active tag in the repository, got %+v", repo.Repository.Tags)
+	}
+
+	if err := client.Tags().Download(ctx, resp.Tag.ID, &tagsmodel.DownloadTagRequest{Filepath: "dss-public://out.qcow2"}); err != nil {
+		t.Errorf("Download() failed: %v", err)
+	}
+}
+
+func TestVRM_TagStatusProgression(t *testing.T) {
+	ctx := context.Background()
+	clock := newManualClock()
+	_, client := newVRMClient(t, WithClock(clock.Now), WithTransitionDelay(time.Minute))
+
+	repo, err := client.Repositories().Create(ctx, &repositoriesmodel.CreateRepositoryRequest{Name: "centos", OperatingSystem: "linux"})
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	tag, err := repo.Tags().Create(ctx, &tagsmodel.CreateTagRequest{Name: "v1", Type: "common", DiskFormat: "qcow2", ContainerFormat: "bare"})
+	if err != nil {
+		t.Fatalf("Tags().Create() failed: %v", err)
+	}
+
+	for _, want := range []common.TagStatus{common.TagStatusQueued, common.TagStatusSaving, common.TagStatusActive} {
+		got, err := client.Tags().Get(ctx, tag.ID)
+		if err != nil {
+			t.Fatalf("Get() failed: %v", err)
+		}
+		if got.Status != want {
+			t.Fatalf("expected %s, got %s", want, got.Status)
+		}
+		if got.Repository == nil || got.Repository.ID != repo.ID {
+			t.Errorf("expected the tag to reference its repository, got %+v", got.Repository)
+		}
+		clock.Advance(time.Minute)
+	}
+}
+
+func TestVRM_ListFilters(t *testing.T) {
+	ctx := context.Background()
+	fake, client := newVRMClient(t)
+
+	for _, req := range []repositoriesmodel.CreateRepositoryRequest{
+		{Name: "ubuntu", OperatingSystem: "linux"},
+		{Name: "debian", OperatingSystem: "linux"},
+		{Name: "windows", OperatingSystem: "windows"},
+	} {
+		req := req
+		repo, err := client.Repositories().Create(ctx, &req)
+		if err != nil {
+			t.Fatalf("Create() failed: %v", err)
+		}
+		if _, err := repo.Tags().Create(ctx, &tagsmodel.CreateTagRequest{Name: "v1", Type: "common", DiskFormat: "qcow2", ContainerFormat: "bare"}); err != nil {
+			t.Fatalf("Tags().Create() failed: %v", err)
+		}
+	}
+	if _, err := client.Repositories().Create(ctx, &repositoriesmodel.CreateRepositoryRequest{Name: "private", OperatingSystem: "linux"}, cloudsdk.WithNamespace("private")); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		opts      repositoriesmodel.ListRepositoriesOptions
+		wantNames []string
+	}{
+		{name: "default namespace", opts: repositoriesmodel.ListRepositoriesOptions{}, wantNames: []string{"ubuntu", "debian", "windows"}},
+		{name: "other namespace", opts: repositoriesmodel.ListRepositoriesOptions{Namespace: "private"}, wantNames: []string{"private"}},
+		{name: "where", opts: repositoriesmodel.ListRepositoriesOptions{Where: []string{"operatingSystem=linux"}}, wantNames: []string{"ubuntu", "debian"}},
+		{name: "where alias", opts: repositoriesmodel.ListRepositoriesOptions{Where: []string{"os=windows"}}, wantNames: []string{"windows"}},
+		{name: "where builder", opts: repositoriesmodel.ListRepositoriesOptions{Where: repositoryWhere(t, vrmfilter.Field("os").Eq("linux").And(vrmfilter.Field("name").Eq("debian")))}, wantNames: []string{"debian"}},
+		{name: "limit and offset", opts: repositoriesmodel.ListRepositoriesOptions{Limit: 1, Offset: 1}, wantNames: []string{"debian"}},
+		{name: "limit all", opts: repositoriesmodel.ListRepositoriesOptions{Limit: -1}, wantNames: []string{"ubuntu", "debian", "windows"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos, err := client.Repositories().List(ctx, &tt.opts)
+			if err != nil {
+				t.Fatalf("List() failed: %v", err)
+			}
+			var names []string
+			for _, repo := range repos {
+				names = append(names, repo.Name)
+			}
+			if len(names) != len(tt.wantNames) {
+				t.Fatalf("expected %v, got %v", tt.wantNames, names)
+			}
+			for i := range names {
+				if names[i] != tt.wantNames[i] {
+					t.Errorf("expected %v, got %v", tt.wantNames, names)
+					break
+				}
+			}
+		})
+	}
+
+	if _, err := client.Repositories().List(ctx, &repositoriesmodel.ListRepositoriesOptions{Where: []string{"bogus=1"}}); err == nil {
+		t.Error("expected an error for an unknown where field")
+	}
+
+	tagList, err := client.Tags().List(ctx, &tagsmodel.ListTagsOptions{Where: []string{"status=queued", "repository.name=debian"}})
+	if err != nil {
+		t.Fatalf("Tags().List() failed: %v", err)
+	}
+	if len(tagList) != 1 || tagList[0].Repository.Name != "debian" {
+		t.Errorf("expected the debian tag, got %+v", tagList)
+	}
+
+	fake.VRM.SetTagStatus(tagList[0].ID, common.TagStatusError)
+	if err := vrm.WaitForTagActive(ctx, client.Tags(), tagList[0].ID, fastWait...); err == nil {
+		t.Error("expected the waiter to fail on error status")
+	}
+}
+
+func TestVRM_SnapshotServer(t *testing.T) {
+	ctx := context.Background()
+	_, client := newSDKClient(t, WithTransitionDelay(10*time.Millisecond))
+	project, err := client.Project(ctx, DefaultProjectID)
+	if err != nil {
+		t.Fatalf("Project() failed: %v", err)
+	}
+
+	vpsClient := project.VPS()
+	server := createServer(t, vpsClient, "web", createNetwork(t, vpsClient, "10.1.0.0/24"))
+
+	resp, err := project.VRM().Repositories().Snapshot(ctx, server.ID, &repositoriesmodel.CreateSnapshotFromNewRepositoryRequest{
+		Name: "web-image", OperatingSystem: "linux", Version: "v1",
+	})
+	if err != nil {
+		t.Fatalf("Snapshot() failed: %v", err)
+	}
+	if resp.Tag.Size != int64(server.RootDiskSize)<<30 {
+		t.Errorf("expected the tag size to match the root disk, got %d", resp.Tag.Size)
+	}
+	if err := vrm.WaitForTagActive(ctx, project.VRM().Tags(), resp.Tag.ID, fastWait...); err != nil {
+		t.Fatalf("WaitForTagActive() failed: %v", err)
+	}
+
+	if _, err := project.VRM().Repositories().Snapshot(ctx, "missing", &repositoriesmodel.CreateSnapshotFromExistingRepositoryRequest{
+		RepositoryID: resp.Repository.ID, Version: "v2",
+	}); err == nil {
+		t.Error("expected an error snapshotting an unknown server")
+	}
+}
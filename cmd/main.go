@@ -63,17 +63,13 @@ func main() {
 	volumeName := "default"
 	repositoryName := "default"
 
-	baseURL := os.Getenv("API_HOST")
-	protocol := os.Getenv("API_PROTOCOL")
-	token := os.Getenv("API_TOKEN")
-	projectCode := os.Getenv("PROJECT_SYS_CODE")
 	passwordEnvVar := os.Getenv("VM_PASSWORD")
 	imageURL := fmt.Sprintf("dss-public://%s/%s", os.Getenv("CS_BUCKET"), os.Getenv("SRC_IMAGE"))
 	downloadFilepath := fmt.Sprintf("dss-public://%s/%s-%s.img", os.Getenv("CS_BUCKET"), "download", time.Now().Format("20060102-150405"))
 	auto := os.Getenv("AUTO_EXECUTE") != "false" // default to true
 
 	// 1. 初始化客戶端
-	vpsClient, vrmClient, err := initClient(protocol, baseURL, token, projectCode)
+	vpsClient, vrmClient, err := initClient()
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -135,20 +131,17 @@ func main() {
 	}
 }
 
-func initClient(protocol, baseURL, token, projectCode string) (*vps.Client, *vrm.Client, error) {
-
-	if protocol == "" || baseURL == "" || token == "" || projectCode == "" {
-		return nil, nil, errors.New("missing required environment variables: API_PROTOCOL, API_HOST, API_TOKEN, PROJECT_SYS_CODE")
-	}
-
+func initClient() (*vps.Client, *vrm.Client, error) {
 	ctx := context.Background()
 
-	client, err := cloudsdk.New(protocol+"://"+baseURL, token)
+	// Resolve base URL, token and project from the environment (API_PROTOCOL, API_HOST,
+	// API_TOKEN, PROJECT_SYS_CODE), the profile file or a credential helper
+	client, err := cloudsdk.NewFromEnvironment(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	projectClient, err := client.Project(ctx, projectCode)
+	projectClient, err := client.DefaultProject(ctx)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -335,7 +328,6 @@ func (a *App) createServer(serverName, passwordEnvVar string, auto bool) error {
 
 	// Encode password to base64
 	encodedPassword := base64.StdEncoding.EncodeToString([]byte(passwordEnvVar))
-	log.Printf("Encoded password: %s", encodedPassword)
 
 	// Step 6: Create Server using information from setup
 	req := &servers.ServerCreateRequest{
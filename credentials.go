@@ -0,0 +1,332 @@
+package cloudsdk
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Environment variables read by EnvProvider and the default provider chain.
+const (
+	EnvBaseURL           = "ZILLAFORGE_BASE_URL"
+	EnvToken             = "ZILLAFORGE_TOKEN"
+	EnvProject           = "ZILLAFORGE_PROJECT"
+	EnvProfile           = "ZILLAFORGE_PROFILE"
+	EnvConfigFile        = "ZILLAFORGE_CONFIG_FILE"
+	EnvCredentialProcess = "ZILLAFORGE_CREDENTIAL_PROCESS"
+
+	// Legacy variables used by earlier tooling (see cmd/main.go).
+	envLegacyProtocol = "API_PROTOCOL"
+	envLegacyHost     = "API_HOST"
+	envLegacyToken    = "API_TOKEN"
+	envLegacyProject  = "PROJECT_SYS_CODE"
+)
+
+// DefaultProfile is the profile name used when ZILLAFORGE_PROFILE is not set.
+const DefaultProfile = "default"
+
+// ErrNoCredentials is returned by a CredentialProvider that found no credentials.
+// ChainProvider moves on to the next provider when it sees this error.
+var ErrNoCredentials = errors.New("no credentials found")
+
+// Credentials holds the settings needed to construct a Client.
+type Credentials struct {
+	// BaseURL is the API base URL including scheme
+	BaseURL string
+
+	// Token is a static bearer token (empty when TokenSource is set)
+	Token string
+
+	// TokenSource supplies tokens dynamically, e.g. from a credential helper
+	TokenSource TokenSource
+
+	// Project is the default project ID or projectSysCode (optional)
+	Project string
+
+	// Source names the provider that produced these credentials
+	Source string
+}
+
+// CredentialProvider retrieves credentials from a single source.
+type CredentialProvider interface {
+	Retrieve(ctx context.Context) (*Credentials, error)
+}
+
+// EnvProvider reads credentials from environment variables.
+// It reads ZILLAFORGE_BASE_URL, ZILLAFORGE_TOKEN and ZILLAFORGE_PROJECT, falling back to
+// API_PROTOCOL + API_HOST, API_TOKEN and PROJECT_SYS_CODE.
+type EnvProvider struct{}
+
+// Retrieve implements CredentialProvider.
+func (EnvProvider) Retrieve(_ context.Context) (*Credentials, error) {
+	baseURL := os.Getenv(EnvBaseURL)
+	if baseURL == "" {
+		if host := os.Getenv(envLegacyHost); host != "" {
+			protocol := os.Getenv(envLegacyProtocol)
+			if protocol == "" {
+				protocol = "https"
+			}
+			baseURL = protocol + "://" + host
+		}
+	}
+
+	token := firstNonEmpty(os.Getenv(EnvToken), os.Getenv(envLegacyToken))
+	if baseURL == "" || token == "" {
+		return nil, fmt.Errorf("environment: %w", ErrNoCredentials)
+	}
+
+	return &Credentials{
+		BaseURL: baseURL,
+		Token:   token,
+		Project: firstNonEmpty(os.Getenv(EnvProject), os.Getenv(envLegacyProject)),
+		Source:  "environment",
+	}, nil
+}
+
+// FileProvider reads credentials from a profile in an INI-style config file.
+//
+//	[default]
+//	base_url = https://api.example.com
+//	token = my-token
+//	project = my-project-code
+//
+//	[staging]
+//	base_url = https://staging.example.com
+//	credential_process = /usr/local/bin/zf-token --profile staging
+//
+// A profile must set base_url and either token or credential_process.
+type FileProvider struct {
+	// Path is the config file path (default: ZILLAFORGE_CONFIG_FILE or ~/.zillaforge/config)
+	Path string
+
+	// Profile is the profile name (default: ZILLAFORGE_PROFILE or "default")
+	Profile string
+}
+
+// Retrieve implements CredentialProvider.
+func (p FileProvider) Retrieve(_ context.Context) (*Credentials, error) {
+	path := p.Path
+	if path == "" {
+		path = os.Getenv(EnvConfigFile)
+	}
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("config file: %w", ErrNoCredentials)
+		}
+		path = filepath.Join(home, ".zillaforge", "config")
+	}
+
+	profile := firstNonEmpty(p.Profile, os.Getenv(EnvProfile), DefaultProfile)
+
+	data, err := os.ReadFile(path) //nolint:gosec // Path is chosen by the caller or the user's environment
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("config file %s: %w", path, ErrNoCredentials)
+		}
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	profiles, err := parseProfiles(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	values, ok := profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("profile %q in %s: %w", profile, path, ErrNoCredentials)
+	}
+
+	creds := &Credentials{
+		BaseURL: values["base_url"],
+		Token:   values["token"],
+		Project: values["project"],
+		Source:  "file:" + profile,
+	}
+	if creds.BaseURL == "" {
+		return nil, fmt.Errorf("profile %q in %s is missing base_url", profile, path)
+	}
+	if creds.Token == "" {
+		command := values["credential_process"]
+		if command == "" {
+			return nil, fmt.Errorf("profile %q in %s must set token or credential_process", profile, path)
+		}
+		creds.TokenSource = NewExecTokenSource(command)
+	}
+
+	return creds, nil
+}
+
+// parseProfiles parses INI-style "[profile]" sections of "key = value" pairs.
+// Blank lines and lines starting with '#' or ';' are ignored.
+func parseProfiles(data []byte) (map[string]map[string]string, error) {
+	profiles := make(map[string]map[string]string)
+	var current map[string]string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(strings.TrimPrefix(line[1:len(line)-1], "profile "))
+			if name == "" {
+				return nil, fmt.Errorf("line %d: empty profile name", lineNo)
+			}
+			current = make(map[string]string)
+			profiles[name] = current
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNo)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("line %d: key outside of a profile section", lineNo)
+		}
+		current[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return profiles, scanner.Err()
+}
+
+// ExecProvider obtains a token from an external credential helper.
+// The command must print a JSON object to stdout:
+//
+//	{"token": "...", "expiresAt": "2026-01-02T15:04:05Z"}
+//
+// expiresAt is optional; without it the token is reused until the API rejects it.
+type ExecProvider struct {
+	// Command is the helper command line (default: ZILLAFORGE_CREDENTIAL_PROCESS)
+	Command string
+
+	// BaseURL is the API base URL (default: ZILLAFORGE_BASE_URL)
+	BaseURL string
+
+	// Project is the default project (default: ZILLAFORGE_PROJECT)
+	Project string
+}
+
+// Retrieve implements CredentialProvider.
+func (p ExecProvider) Retrieve(_ context.Context) (*Credentials, error) {
+	command := firstNonEmpty(p.Command, os.Getenv(EnvCredentialProcess))
+	baseURL := firstNonEmpty(p.BaseURL, os.Getenv(EnvBaseURL))
+	if command == "" || baseURL == "" {
+		return nil, fmt.Errorf("credential process: %w", ErrNoCredentials)
+	}
+
+	return &Credentials{
+		BaseURL:     baseURL,
+		TokenSource: NewExecTokenSource(command),
+		Project:     firstNonEmpty(p.Project, os.Getenv(EnvProject)),
+		Source:      "exec",
+	}, nil
+}
+
+// execTokenOutput is the JSON document printed by a credential helper.
+type execTokenOutput struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+}
+
+// NewExecTokenSource returns a TokenSource that runs command to obtain tokens.
+// The command line is split on whitespace; tokens are cached until they expire
+// or are invalidated after a 401.
+func NewExecTokenSource(command string) *CachedTokenSource {
+	args := strings.Fields(command)
+	return NewCachedTokenSource(func(ctx context.Context) (string, time.Time, error) {
+		if len(args) == 0 {
+			return "", time.Time{}, fmt.Errorf("credential process command is empty")
+		}
+
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...) //nolint:gosec // Command comes from the user's own configuration
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return "", time.Time{}, fmt.Errorf("credential process failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+
+		var out execTokenOutput
+		if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to parse credential process output: %w", err)
+		}
+		if out.Token == "" {
+			return "", time.Time{}, fmt.Errorf("credential process returned an empty token")
+		}
+
+		return out.Token, out.ExpiresAt, nil
+	})
+}
+
+// ChainProvider tries each provider in order and returns the first credentials found.
+// Providers reporting ErrNoCredentials are skipped; any other error stops the chain.
+type ChainProvider []CredentialProvider
+
+// Retrieve implements CredentialProvider.
+func (c ChainProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	for _, provider := range c {
+		creds, err := provider.Retrieve(ctx)
+		if err == nil {
+			return creds, nil
+		}
+		if !errors.Is(err, ErrNoCredentials) {
+			return nil, err
+		}
+	}
+	return nil, ErrNoCredentials
+}
+
+// DefaultCredentialChain returns the provider chain used by NewFromEnvironment:
+// environment variables, then the profile file, then the credential helper.
+func DefaultCredentialChain() ChainProvider {
+	return ChainProvider{EnvProvider{}, FileProvider{}, ExecProvider{}}
+}
+
+// NewFromEnvironment creates a client from the default credential chain.
+// Any default project found is available via Client.DefaultProject.
+func NewFromEnvironment(ctx context.Context, opts ...ClientOption) (*Client, error) {
+	return NewFromCredentials(ctx, DefaultCredentialChain(), opts...)
+}
+
+// NewFromCredentials creates a client from the credentials returned by provider.
+// Options are applied after the credentials, so they can override them.
+func NewFromCredentials(ctx context.Context, provider CredentialProvider, opts ...ClientOption) (*Client, error) {
+	creds, err := provider.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve credentials: %w", err)
+	}
+
+	var credOpts []ClientOption
+	if creds.TokenSource != nil {
+		credOpts = append(credOpts, WithTokenSource(creds.TokenSource))
+	}
+	if creds.Project != "" {
+		credOpts = append(credOpts, WithDefaultProject(creds.Project))
+	}
+
+	return New(creds.BaseURL, creds.Token, append(credOpts, opts...)...)
+}
+
+// firstNonEmpty returns the first non-empty string.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
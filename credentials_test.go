@@ -0,0 +1,206 @@
+package cloudsdk
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// clearCredentialEnv unsets every environment variable read by the credential providers
+func clearCredentialEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{
+		EnvBaseURL, EnvToken, EnvProject, EnvProfile, EnvConfigFile, EnvCredentialProcess,
+		envLegacyProtocol, envLegacyHost, envLegacyToken, envLegacyProject,
+	} {
+		t.Setenv(key, "")
+	}
+	t.Setenv("HOME", t.TempDir())
+}
+
+// TestEnvProvider tests environment variable credentials
+func TestEnvProvider(t *testing.T) {
+	t.Run("primary variables", func(t *testing.T) {
+		clearCredentialEnv(t)
+		t.Setenv(EnvBaseURL, "https://api.example.com")
+		t.Setenv(EnvToken, "env-token")
+		t.Setenv(EnvProject, "proj-1")
+
+		creds, err := EnvProvider{}.Retrieve(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if creds.BaseURL != "https://api.example.com" || creds.Token != "env-token" || creds.Project != "proj-1" {
+			t.Errorf("unexpected credentials: %+v", creds)
+		}
+	})
+
+	t.Run("legacy variables", func(t *testing.T) {
+		clearCredentialEnv(t)
+		t.Setenv(envLegacyProtocol, "http")
+		t.Setenv(envLegacyHost, "localhost:8080")
+		t.Setenv(envLegacyToken, "legacy-token")
+		t.Setenv(envLegacyProject, "SYS-CODE")
+
+		creds, err := EnvProvider{}.Retrieve(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if creds.BaseURL != "http://localhost:8080" || creds.Token != "legacy-token" || creds.Project != "SYS-CODE" {
+			t.Errorf("unexpected credentials: %+v", creds)
+		}
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		clearCredentialEnv(t)
+		t.Setenv(EnvBaseURL, "https://api.example.com")
+
+		_, err := EnvProvider{}.Retrieve(context.Background())
+		if !errors.Is(err, ErrNoCredentials) {
+			t.Errorf("expected ErrNoCredentials, got %v", err)
+		}
+	})
+}
+
+// TestFileProvider tests profile file credentials
+func TestFileProvider(t *testing.T) {
+	clearCredentialEnv(t)
+
+	path := filepath.Join(t.TempDir(), "config")
+	content := `# comment
+[default]
+base_url = https://api.example.com
+token = file-token
+project = proj-default
+
+[profile staging]
+base_url = https://staging.example.com
+credential_process = /bin/true
+
+[broken]
+token = missing-url
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	t.Run("default profile", func(t *testing.T) {
+		creds, err := FileProvider{Path: path}.Retrieve(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if creds.BaseURL != "https://api.example.com" || creds.Token != "file-token" || creds.Project != "proj-default" {
+			t.Errorf("unexpected credentials: %+v", creds)
+		}
+	})
+
+	t.Run("profile from environment uses credential process", func(t *testing.T) {
+		t.Setenv(EnvProfile, "staging")
+		creds, err := FileProvider{Path: path}.Retrieve(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if creds.BaseURL != "https://staging.example.com" || creds.TokenSource == nil || creds.Token != "" {
+			t.Errorf("unexpected credentials: %+v", creds)
+		}
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		_, err := FileProvider{Path: path, Profile: "missing"}.Retrieve(context.Background())
+		if !errors.Is(err, ErrNoCredentials) {
+			t.Errorf("expected ErrNoCredentials, got %v", err)
+		}
+	})
+
+	t.Run("invalid profile", func(t *testing.T) {
+		_, err := FileProvider{Path: path, Profile: "broken"}.Retrieve(context.Background())
+		if err == nil || errors.Is(err, ErrNoCredentials) {
+			t.Errorf("expected configuration error, got %v", err)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := FileProvider{Path: filepath.Join(t.TempDir(), "nope")}.Retrieve(context.Background())
+		if !errors.Is(err, ErrNoCredentials) {
+			t.Errorf("expected ErrNoCredentials, got %v", err)
+		}
+	})
+}
+
+// TestExecTokenSource tests the credential helper token source
+func TestExecTokenSource(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script helper requires a POSIX shell")
+	}
+
+	script := filepath.Join(t.TempDir(), "helper.sh")
+	body := "#!/bin/sh\necho '{\"token\": \"exec-token\", \"expiresAt\": \"2999-01-01T00:00:00Z\"}'\n"
+	if err := os.WriteFile(script, []byte(body), 0o700); err != nil { //nolint:gosec // Test helper must be executable
+		t.Fatalf("failed to write helper: %v", err)
+	}
+
+	token, err := NewExecTokenSource(script).Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token != "exec-token" {
+		t.Errorf("expected exec-token, got %q", token)
+	}
+
+	if _, err := NewExecTokenSource("/bin/false").Token(context.Background()); err == nil {
+		t.Error("expected error from failing helper")
+	}
+}
+
+// TestChainProvider tests provider ordering and error handling
+func TestChainProvider(t *testing.T) {
+	clearCredentialEnv(t)
+
+	t.Run("falls through to later providers", func(t *testing.T) {
+		t.Setenv(EnvBaseURL, "https://api.example.com")
+		t.Setenv(EnvCredentialProcess, "/bin/true")
+
+		creds, err := DefaultCredentialChain().Retrieve(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if creds.Source != "exec" {
+			t.Errorf("expected exec provider, got %q", creds.Source)
+		}
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		clearCredentialEnv(t)
+		_, err := DefaultCredentialChain().Retrieve(context.Background())
+		if !errors.Is(err, ErrNoCredentials) {
+			t.Errorf("expected ErrNoCredentials, got %v", err)
+		}
+	})
+}
+
+// TestNewFromEnvironment tests client construction from the default chain
+func TestNewFromEnvironment(t *testing.T) {
+	clearCredentialEnv(t)
+	t.Setenv(EnvBaseURL, "https://api.example.com")
+	t.Setenv(EnvToken, "env-token")
+	t.Setenv(EnvProject, "proj-1")
+
+	client, err := NewFromEnvironment(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.BaseURL() != "https://api.example.com" {
+		t.Errorf("unexpected base URL %q", client.BaseURL())
+	}
+	if client.defaultProject != "proj-1" {
+		t.Errorf("expected default project proj-1, got %q", client.defaultProject)
+	}
+
+	clearCredentialEnv(t)
+	if _, err := NewFromEnvironment(context.Background()); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("expected ErrNoCredentials, got %v", err)
+	}
+}
@@ -1,7 +1,11 @@
 package cloudsdk
 
 import (
+	"context"
+	"errors"
+
 	"github.com/Zillaforge/cloud-sdk/internal/types"
+	"github.com/Zillaforge/cloud-sdk/waiter"
 )
 
 // SDKError is re-exported from internal/types for public API.
@@ -31,3 +35,79 @@ func NewCanceledError(cause error) *SDKError {
 func NewHTTPError(statusCode int, rawBody string) *SDKError {
 	return types.NewHTTPError(statusCode, rawBody)
 }
+
+// Sentinel errors classifying failed calls. An SDKError matches them under
+// errors.Is through its platform error code (see ErrorForCode), its HTTP status
+// or, for client-side failures, its category:
+//
+//	if errors.Is(err, cloudsdk.ErrNotFound) { ... }
+var (
+	ErrBadRequest       = types.ErrBadRequest
+	ErrUnauthorized     = types.ErrUnauthorized
+	ErrPermissionDenied = types.ErrPermissionDenied
+	ErrNotFound         = types.ErrNotFound
+	ErrConflict         = types.ErrConflict
+	ErrQuotaExceeded    = types.ErrQuotaExceeded
+	ErrThrottled        = types.ErrThrottled
+	ErrTimeout          = types.ErrTimeout
+	ErrInternal         = types.ErrInternal
+)
+
+// Platform error codes returned in the errorCode field of error responses.
+const (
+	ErrorCodeBadRequest = types.ErrorCodeBadRequest
+	ErrorCodeForbidden  = types.ErrorCodeForbidden
+	ErrorCodeNotFound   = types.ErrorCodeNotFound
+	ErrorCodeInternal   = types.ErrorCodeInternal
+)
+
+// ErrorForCode returns the sentinel error a platform error code maps to.
+func ErrorForCode(code int) (error, bool) {
+	return types.ErrorForCode(code)
+}
+
+// RegisterErrorCode maps a platform error code to a sentinel error, so that
+// errors.Is(err, sentinel) holds for SDKErrors carrying that code. It extends
+// the built-in catalog and is meant to be called during program initialization.
+func RegisterErrorCode(code int, sentinel error) {
+	types.RegisterErrorCode(code, sentinel)
+}
+
+// IsNotFound reports whether err, or any error it wraps, is a not-found error.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConflict reports whether err is a 409 Conflict response.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsQuotaExceeded reports whether err reports an exhausted quota or limit.
+func IsQuotaExceeded(err error) bool {
+	return errors.Is(err, ErrQuotaExceeded)
+}
+
+// IsThrottled reports whether err is a 429 Too Many Requests response that
+// outlasted the retry policy.
+func IsThrottled(err error) bool {
+	return errors.Is(err, ErrThrottled)
+}
+
+// IsTimeout reports whether err is a request timeout, a timeout status
+// (408 or 504), an expired context deadline or an exceeded waiter deadline.
+func IsTimeout(err error) bool {
+	return errors.Is(err, ErrTimeout) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, waiter.ErrWaitTimeout)
+}
+
+// IsPermissionDenied reports whether err is a 403 Forbidden response.
+func IsPermissionDenied(err error) bool {
+	return errors.Is(err, ErrPermissionDenied)
+}
+
+// IsUnauthorized reports whether err is a 401 Unauthorized response or carries
+// ErrorCodeForbidden, which IAM returns with a 403 for an invalid or expired
+// token.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
@@ -1,8 +1,12 @@
 package cloudsdk
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
+
+	"github.com/Zillaforge/cloud-sdk/waiter"
 )
 
 func TestSDKError_Error(t *testing.T) {
@@ -210,3 +214,60 @@ func TestSDKError_ErrorsAs(t *testing.T) {
 		t.Errorf("expected StatusCode 404, got %d", sdkErr.StatusCode)
 	}
 }
+
+func TestErrorPredicates(t *testing.T) {
+	wrap := func(err error) error {
+		return fmt.Errorf("failed to create server: %w", fmt.Errorf("failed to do request: %w", err))
+	}
+	tests := []struct {
+		name      string
+		err       error
+		predicate func(error) bool
+		want      bool
+	}{
+		{name: "not found status", err: wrap(NewHTTPError(404, "")), predicate: IsNotFound, want: true},
+		{name: "not found code", err: wrap(NewSDKError(400, ErrorCodeNotFound, "project not found", nil, nil)), predicate: IsNotFound, want: true},
+		{name: "not found on other error", err: wrap(NewHTTPError(500, "")), predicate: IsNotFound, want: false},
+		{name: "conflict", err: wrap(NewHTTPError(409, "")), predicate: IsConflict, want: true},
+		{name: "quota", err: wrap(NewSDKError(403, 0, "quota of 10 servers exceeded", nil, nil)), predicate: IsQuotaExceeded, want: true},
+		{name: "quota bad request", err: wrap(NewSDKError(400, 0, "Quota exceeded", nil, nil)), predicate: IsQuotaExceeded, want: true},
+		{name: "throttled", err: wrap(NewHTTPError(429, "")), predicate: IsThrottled, want: true},
+		{name: "client timeout", err: wrap(NewTimeoutError(context.DeadlineExceeded)), predicate: IsTimeout, want: true},
+		{name: "context deadline", err: wrap(context.DeadlineExceeded), predicate: IsTimeout, want: true},
+		{name: "waiter timeout", err: wrap(waiter.ErrWaitTimeout), predicate: IsTimeout, want: true},
+		{name: "canceled is not a timeout", err: wrap(NewCanceledError(context.Canceled)), predicate: IsTimeout, want: false},
+		{name: "permission denied", err: wrap(NewHTTPError(403, "")), predicate: IsPermissionDenied, want: true},
+		{name: "expired token", err: wrap(NewSDKError(403, ErrorCodeForbidden, "Forbidden", nil, nil)), predicate: IsUnauthorized, want: true},
+		{name: "unauthorized status", err: wrap(NewHTTPError(401, "")), predicate: IsUnauthorized, want: true},
+		{name: "nil", err: nil, predicate: IsNotFound, want: false},
+		{name: "plain error", err: errors.New("not found"), predicate: IsNotFound, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.predicate(tt.err); got != tt.want {
+				t.Errorf("predicate(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorForCode(t *testing.T) {
+	tests := []struct {
+		code int
+		want error
+	}{
+		{code: ErrorCodeBadRequest, want: ErrBadRequest},
+		{code: ErrorCodeForbidden, want: ErrUnauthorized},
+		{code: ErrorCodeNotFound, want: ErrNotFound},
+		{code: ErrorCodeInternal, want: ErrInternal},
+	}
+	for _, tt := range tests {
+		got, ok := ErrorForCode(tt.code)
+		if !ok || got != tt.want {
+			t.Errorf("ErrorForCode(%d) = %v, %v, want %v", tt.code, got, ok, tt.want)
+		}
+	}
+	if _, ok := ErrorForCode(0); ok {
+		t.Error("expected code 0 to be unmapped")
+	}
+}
@@ -0,0 +1,373 @@
+// Package faultinject provides an http.RoundTripper that injects failures into SDK
+// traffic, so retry logic and recovery paths can be exercised in tests.
+//
+//	ft := faultinject.New([]faultinject.Rule{
+//		{Method: http.MethodGet, Path: "/vps/**/servers/*", Probability: 0.3, Fault: faultinject.Status(http.StatusServiceUnavailable)},
+//		{Path: "/vrm/**", Probability: 0.1, Fault: faultinject.ConnectionReset()},
+//	}, faultinject.WithSeed(42), faultinject.WithTransport(fake.Client().Transport))
+//
+//	client, err := cloudsdk.New(baseURL, token, cloudsdk.WithHTTPClient(ft.Client()))
+//
+// The same seed produces the same faults for the same sequence of requests.
+package faultinject
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// DefaultSeed seeds the random source when WithSeed is not used.
+const DefaultSeed = 1
+
+// Fault produces the outcome of a faulted request. next is the wrapped transport;
+// faults that only disturb the response call it and modify what it returns.
+type Fault func(req *http.Request, next http.RoundTripper) (*http.Response, error)
+
+// Rule injects a fault into matching requests.
+type Rule struct {
+	// Name identifies the rule in Injections (default: its index)
+	Name string
+
+	// Method restricts the rule to one HTTP method; empty matches any method
+	Method string
+
+	// Path is a glob matched against the request path; empty matches any path.
+	// "*" matches within one path segment and "**" matches across segments.
+	Path string
+
+	// Probability is the chance that a matching request triggers the rule. Values
+	// in (0, 1) trigger at random; zero, the default, and values of 1 or more
+	// trigger on every matching request.
+	Probability float64
+
+	// Burst is the number of consecutive matching requests faulted each time the
+	// rule triggers (default 1), e.g. 3 to simulate a short outage
+	Burst int
+
+	// Times caps the total number of requests the rule faults; zero means no cap
+	Times int
+
+	// Fault is the failure to inject
+	Fault Fault
+}
+
+// Injection records a fault applied to a request.
+type Injection struct {
+	Rule   string
+	Method string
+	Path   string
+}
+
+// Option configures a Transport.
+type Option func(*Transport)
+
+// WithTransport sets the transport that non-faulted requests are sent through
+// (default: http.DefaultTransport). Use the fake backend's transport to combine
+// faults with cloudsdktest.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(t *Transport) {
+		t.next = rt
+	}
+}
+
+// WithSeed seeds the random source deciding which requests are faulted.
+func WithSeed(seed int64) Option {
+	return func(t *Transport) {
+		t.seed = seed
+	}
+}
+
+// Transport is an http.RoundTripper that injects faults according to its rules.
+// Rules are evaluated in order and the first one that triggers is applied.
+// It is safe for concurrent use; concurrent requests draw from the random
+// source in arrival order, so only sequential traffic is fully reproducible.
+type Transport struct {
+	next  http.RoundTripper
+	seed  int64
+	rules []Rule
+
+	mu         sync.Mutex
+	rng        *rand.Rand
+	burst      []int
+	injected   []int
+	injections []Injection
+}
+
+// New creates a Transport applying rules.
+func New(rules []Rule, opts ...Option) *Transport {
+	t := &Transport{
+		next:  http.DefaultTransport,
+		seed:  DefaultSeed,
+		rules: append([]Rule(nil), rules...),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	t.rng = rand.New(rand.NewSource(t.seed)) //nolint:gosec // Reproducible test faults, not security
+	t.burst = make([]int, len(t.rules))
+	t.injected = make([]int, len(t.rules))
+	for i := range t.rules {
+		if t.rules[i].Name == "" {
+			t.rules[i].Name = strconv.Itoa(i)
+		}
+	}
+	return t
+}
+
+// Client returns an HTTP client that uses the transport.
+func (t *Transport) Client() *http.Client {
+	return &http.Client{Transport: t, Timeout: 30 * time.Second}
+}
+
+// Injections returns a copy of the faults applied so far, in order.
+func (t *Transport) Injections() []Injection {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Injection(nil), t.injections...)
+}
+
+// Reset clears the injection log and burst state and reseeds the random source,
+// so the transport replays the same faults for the same requests.
+func (t *Transport) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rng = rand.New(rand.NewSource(t.seed)) //nolint:gosec // Reproducible test faults, not security
+	t.burst = make([]int, len(t.rules))
+	t.injected = make([]int, len(t.rules))
+	t.injections = nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if fault := t.pick(req); fault != nil {
+		return fault(req, t.next)
+	}
+	return t.next.RoundTrip(req)
+}
+
+// pick returns the fault of the first rule that triggers for req, or nil.
+func (t *Transport) pick(req *http.Request) Fault {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i := range t.rules {
+		rule := &t.rules[i]
+		if !rule.matches(req) || rule.Fault == nil {
+			continue
+		}
+		if rule.Times > 0 && t.injected[i] >= rule.Times {
+			continue
+		}
+
+		if t.burst[i] == 0 {
+			if rule.Probability > 0 && rule.Probability < 1 && t.rng.Float64() >= rule.Probability {
+				continue
+			}
+			t.burst[i] = rule.Burst
+			if t.burst[i] < 1 {
+				t.burst[i] = 1
+			}
+		}
+
+		t.burst[i]--
+		t.injected[i]++
+		t.injections = append(t.injections, Injection{Rule: rule.Name, Method: req.Method, Path: req.URL.Path})
+		return rule.Fault
+	}
+	return nil
+}
+
+// matches reports whether the rule applies to req.
+func (r *Rule) matches(req *http.Request) bool {
+	if r.Method != "" && !strings.EqualFold(r.Method, req.Method) {
+		return false
+	}
+	return r.Path == "" || matchPath(r.Path, req.URL.Path)
+}
+
+// matchPath matches path against a glob where "*" matches any run of characters
+// other than '/' and "**" matches any run of characters.
+func matchPath(pattern, path string) bool {
+	for len(pattern) > 0 {
+		if !strings.HasPrefix(pattern, "*") {
+			if len(path) == 0 || pattern[0] != path[0] {
+				return false
+			}
+			pattern, path = pattern[1:], path[1:]
+			continue
+		}
+
+		crossSegments := strings.HasPrefix(pattern, "**")
+		pattern = strings.TrimLeft(pattern, "*")
+		for i := 0; i <= len(path); i++ {
+			if matchPath(pattern, path[i:]) {
+				return true
+			}
+			if i < len(path) && path[i] == '/' && !crossSegments {
+				return false
+			}
+		}
+		return false
+	}
+	return len(path) == 0
+}
+
+// Latency delays the request by d before sending it. The delay ends early,
+// failing the request, if the request context is done.
+func Latency(d time.Duration) Fault {
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			return next.RoundTrip(req)
+		case <-req.Context().Done():
+			closeBody(req)
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// ConnectionReset fails the request without sending it, with the error a reset
+// TCP connection produces (errors.Is(err, syscall.ECONNRESET) holds).
+func ConnectionReset() Fault {
+	return func(req *http.Request, _ http.RoundTripper) (*http.Response, error) {
+		closeBody(req)
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+	}
+}
+
+// Error fails the request without sending it, returning err.
+func Error(err error) Fault {
+	return func(req *http.Request, _ http.RoundTripper) (*http.Response, error) {
+		closeBody(req)
+		return nil, err
+	}
+}
+
+// Status answers the request with statusCode and a platform-style error body
+// without sending it, e.g. http.StatusServiceUnavailable for a 5xx burst.
+func Status(statusCode int) Fault {
+	return func(req *http.Request, _ http.RoundTripper) (*http.Response, error) {
+		return errorResponse(req, statusCode), nil
+	}
+}
+
+// Throttle answers the request with 429 Too Many Requests and a Retry-After
+// header of retryAfter, rounded up to whole seconds. A zero retryAfter omits the header.
+func Throttle(retryAfter time.Duration) Fault {
+	return func(req *http.Request, _ http.RoundTripper) (*http.Response, error) {
+		resp := errorResponse(req, http.StatusTooManyRequests)
+		if retryAfter > 0 {
+			seconds := (retryAfter + time.Second - 1) / time.Second
+			resp.Header.Set("Retry-After", strconv.Itoa(int(seconds)))
+		}
+		return resp, nil
+	}
+}
+
+// TruncatedBody sends the request and cuts the response body in half; reading
+// past the cut fails with io.ErrUnexpectedEOF, as when a connection drops mid-body.
+func TruncatedBody() Fault {
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		resp, body, err := roundTripBody(req, next)
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = &truncatedReader{r: bytes.NewReader(body[:len(body)/2])}
+		// The original length no longer matches the body, so it is unknown
+		resp.ContentLength = -1
+		resp.Header.Del("Content-Length")
+		return resp, nil
+	}
+}
+
+// MalformedJSON sends the request and replaces the response body with invalid
+// JSON, keeping the status code and headers.
+func MalformedJSON() Fault {
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		resp, body, err := roundTripBody(req, next)
+		if err != nil {
+			return nil, err
+		}
+		malformed := append(body[:len(body)/2:len(body)/2], `{"malformed":`...)
+		resp.Body = io.NopCloser(bytes.NewReader(malformed))
+		resp.ContentLength = int64(len(malformed))
+		resp.Header.Del("Content-Length")
+		return resp, nil
+	}
+}
+
+// roundTripBody sends req and reads the whole response body.
+func roundTripBody(req *http.Request, next http.RoundTripper) (*http.Response, []byte, error) {
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return resp, body, nil
+}
+
+// closeBody closes the body of a request that is answered without being sent,
+// as the http.RoundTripper contract requires.
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
+
+// errorResponse builds a synthetic error response for req, closing its body since
+// the request is never sent.
+func errorResponse(req *http.Request, statusCode int) *http.Response {
+	closeBody(req)
+	body, _ := json.Marshal(map[string]interface{}{
+		"errorCode": 0,
+		"message":   fmt.Sprintf("injected fault: %d %s", statusCode, http.StatusText(statusCode)),
+	})
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// truncatedReader returns io.ErrUnexpectedEOF instead of io.EOF.
+type truncatedReader struct {
+	r *bytes.Reader
+}
+
+func (t *truncatedReader) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (t *truncatedReader) Close() error {
+	return nil
+}
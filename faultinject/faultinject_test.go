@@ -0,0 +1,340 @@
+package faultinject
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	"github.com/Zillaforge/cloud-sdk/cloudsdktest"
+)
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "/vps/api/v1/flavors", path: "/vps/api/v1/flavors", want: true},
+		{pattern: "/vps/api/v1/flavors", path: "/vps/api/v1/flavors/1", want: false},
+		{pattern: "/servers/*", path: "/servers/abc", want: true},
+		{pattern: "/servers/*", path: "/servers/abc/nics", want: false},
+		{pattern: "/vps/**/servers", path: "/vps/api/v1/project/p1/servers", want: true},
+		{pattern: "/vps/**", path: "/vrm/api/v1", want: false},
+		{pattern: "**/tag/*", path: "/vrm/api/v1/project/p1/tag/t1", want: true},
+		{pattern: "/a/*/c", path: "/a//c", want: true},
+	}
+	for _, tt := range tests {
+		if got := matchPath(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("matchPath(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestTransport_Faults(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"userId":"user-1","account":"alice"}`))
+	}))
+	defer backend.Close()
+
+	injected := errors.New("boom")
+	tests := []struct {
+		name  string
+		fault Fault
+		check func(t *testing.T, resp *http.Response, err error)
+	}{
+		{
+			name:  "connection reset",
+			fault: ConnectionReset(),
+			check: func(t *testing.T, _ *http.Response, err error) {
+				if !errors.Is(err, syscall.ECONNRESET) {
+					t.Errorf("expected ECONNRESET, got %v", err)
+				}
+			},
+		},
+		{
+			name:  "error",
+			fault: Error(injected),
+			check: func(t *testing.T, _ *http.Response, err error) {
+				if !errors.Is(err, injected) {
+					t.Errorf("expected the injected error, got %v", err)
+				}
+			},
+		},
+		{
+			name:  "status",
+			fault: Status(http.StatusBadGateway),
+			check: func(t *testing.T, resp *http.Response, err error) {
+				if err != nil || resp.StatusCode != http.StatusBadGateway {
+					t.Errorf("expected 502, got %v, %v", resp, err)
+				}
+			},
+		},
+		{
+			name:  "throttle",
+			fault: Throttle(1500 * time.Millisecond),
+			check: func(t *testing.T, resp *http.Response, err error) {
+				if err != nil || resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") != "2" {
+					t.Errorf("expected 429 with Retry-After 2, got %v, %v", resp, err)
+				}
+			},
+		},
+		{
+			name:  "truncated body",
+			fault: TruncatedBody(),
+			check: func(t *testing.T, resp *http.Response, err error) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if _, err := io.ReadAll(resp.Body); !errors.Is(err, io.ErrUnexpectedEOF) {
+					t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+				}
+			},
+		},
+		{
+			name:  "malformed JSON",
+			fault: MalformedJSON(),
+			check: func(t *testing.T, resp *http.Response, err error) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				var v interface{}
+				if err := json.NewDecoder(resp.Body).Decode(&v); err == nil {
+					t.Error("expected the body to be invalid JSON")
+				}
+				if resp.StatusCode != http.StatusOK {
+					t.Errorf("expected the status to be kept, got %d", resp.StatusCode)
+				}
+			},
+		},
+		{
+			name:  "latency",
+			fault: Latency(30 * time.Millisecond),
+			check: func(t *testing.T, resp *http.Response, err error) {
+				if err != nil || resp.StatusCode != http.StatusOK {
+					t.Errorf("expected the request to succeed after the delay, got %v, %v", resp, err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft := New([]Rule{{Path: "/user", Fault: tt.fault}}, WithTransport(backend.Client().Transport))
+
+			start := time.Now()
+			resp, err := ft.Client().Get(backend.URL + "/user")
+			if resp != nil {
+				defer resp.Body.Close()
+			}
+			tt.check(t, resp, err)
+			if tt.name == "latency" && time.Since(start) < 30*time.Millisecond {
+				t.Error("expected the request to be delayed")
+			}
+
+			// Unmatched paths pass through untouched
+			resp, err = ft.Client().Get(backend.URL + "/other")
+			if err != nil || resp.StatusCode != http.StatusOK {
+				t.Fatalf("expected unmatched request to pass through, got %v, %v", resp, err)
+			}
+			resp.Body.Close()
+			if got := len(ft.Injections()); got != 1 {
+				t.Errorf("expected 1 injection, got %d", got)
+			}
+		})
+	}
+}
+
+// trackedBody records whether it was closed.
+type trackedBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackedBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestTransport_ClosesUnsentBodies(t *testing.T) {
+	faults := map[string]Fault{
+		"connection reset": ConnectionReset(),
+		"error":            Error(errors.New("boom")),
+		"status":           Status(http.StatusServiceUnavailable),
+		"throttle":         Throttle(time.Second),
+	}
+	for name, fault := range faults {
+		t.Run(name, func(t *testing.T) {
+			ft := New([]Rule{{Fault: fault}}, WithTransport(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+				t.Fatal("request should not be sent")
+				return nil, nil
+			})))
+			body := &trackedBody{Reader: strings.NewReader(`{"name":"web"}`)}
+			req, _ := http.NewRequest(http.MethodPost, "http://example.invalid/servers", body)
+			if resp, _ := ft.RoundTrip(req); resp != nil {
+				resp.Body.Close()
+			}
+			if !body.closed {
+				t.Error("expected the request body to be closed")
+			}
+		})
+	}
+}
+
+func TestTransport_LatencyHonorsContext(t *testing.T) {
+	ft := New([]Rule{{Fault: Latency(time.Minute)}}, WithTransport(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		t.Fatal("request should not be sent")
+		return nil, nil
+	})))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.invalid/", nil)
+	if _, err := ft.RoundTrip(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestTransport_DeterministicUnderSeed(t *testing.T) {
+	ok := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+	})
+	rules := []Rule{
+		{Name: "reset", Method: http.MethodGet, Probability: 0.2, Fault: ConnectionReset()},
+		{Name: "burst", Probability: 0.1, Burst: 3, Fault: Status(http.StatusServiceUnavailable)},
+	}
+
+	run := func(ft *Transport) []Injection {
+		for i := 0; i < 100; i++ {
+			method := http.MethodGet
+			if i%3 == 0 {
+				method = http.MethodPost
+			}
+			req, _ := http.NewRequest(method, "http://example.invalid/servers", nil)
+			if resp, err := ft.RoundTrip(req); err == nil {
+				resp.Body.Close()
+			}
+		}
+		return ft.Injections()
+	}
+
+	first := run(New(rules, WithSeed(7), WithTransport(ok)))
+	second := New(rules, WithSeed(7), WithTransport(ok))
+	if got := run(second); !reflect.DeepEqual(first, got) {
+		t.Error("expected the same seed to inject the same faults")
+	}
+	if len(first) == 0 || len(first) == 100 {
+		t.Errorf("expected some but not all requests to be faulted, got %d", len(first))
+	}
+
+	second.Reset()
+	if got := run(second); !reflect.DeepEqual(first, got) {
+		t.Error("expected Reset to replay the same faults")
+	}
+	if got := run(New(rules, WithSeed(8), WithTransport(ok))); reflect.DeepEqual(first, got) {
+		t.Error("expected a different seed to inject different faults")
+	}
+}
+
+func TestTransport_BodyFaultsThroughClient(t *testing.T) {
+	const payload = `{"userId":"user-1","account":"alice"}`
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", strconv.Itoa(len(payload)))
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer backend.Close()
+
+	tests := []struct {
+		name     string
+		fault    Fault
+		wantBody string
+		wantErr  error
+	}{
+		{name: "truncated body", fault: TruncatedBody(), wantBody: payload[:len(payload)/2], wantErr: io.ErrUnexpectedEOF},
+		{name: "malformed JSON", fault: MalformedJSON(), wantBody: payload[:len(payload)/2] + `{"malformed":`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft := New([]Rule{{Fault: tt.fault}})
+			resp, err := ft.Client().Get(backend.URL)
+			if err != nil {
+				t.Fatalf("Get() failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if got := resp.Header.Get("Content-Length"); got != "" {
+				t.Errorf("expected the stale Content-Length header to be dropped, got %q", got)
+			}
+			if resp.ContentLength != -1 && resp.ContentLength != int64(len(tt.wantBody)) {
+				t.Errorf("expected ContentLength to match the new body, got %d", resp.ContentLength)
+			}
+			body, err := io.ReadAll(resp.Body)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected read error %v, got %v", tt.wantErr, err)
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("expected body %q, got %q", tt.wantBody, body)
+			}
+		})
+	}
+}
+
+func TestTransport_RetriesWithFakeBackend(t *testing.T) {
+	ctx := context.Background()
+	fake := cloudsdktest.NewServer()
+	defer fake.Close()
+
+	newClient := func(ft *Transport) *cloudsdk.Client {
+		client, err := cloudsdk.New(fake.URL, "token",
+			cloudsdk.WithHTTPClient(ft.Client()),
+			cloudsdk.WithRetryPolicy(&cloudsdk.RetryPolicy{MaxAttempts: 4, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond}),
+		)
+		if err != nil {
+			t.Fatalf("cloudsdk.New() failed: %v", err)
+		}
+		return client
+	}
+
+	// A burst shorter than the retry budget is absorbed by the client
+	ft := New([]Rule{{Method: http.MethodGet, Path: "/iam/**/user", Burst: 3, Times: 3, Fault: Status(http.StatusServiceUnavailable)}},
+		WithTransport(fake.Client().Transport))
+	if _, err := newClient(ft).IAM().Users().Get(ctx); err != nil {
+		t.Fatalf("expected the burst to be retried away, got %v", err)
+	}
+	if got := len(ft.Injections()); got != 3 {
+		t.Errorf("expected 3 injections, got %d", got)
+	}
+
+	// A longer burst exhausts the retries and surfaces the status
+	ft = New([]Rule{{Path: "/iam/**", Fault: Throttle(0)}}, WithTransport(fake.Client().Transport))
+	_, err := newClient(ft).IAM().Users().Get(ctx)
+	var sdkErr *cloudsdk.SDKError
+	if !errors.As(err, &sdkErr) || sdkErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected a 429 SDKError, got %v", err)
+	}
+	if got := len(ft.Injections()); got != 4 {
+		t.Errorf("expected every attempt to be faulted, got %d", got)
+	}
+
+	// Malformed bodies surface as parse errors
+	ft = New([]Rule{{Path: "/iam/**", Fault: MalformedJSON()}}, WithTransport(fake.Client().Transport))
+	if _, err := newClient(ft).IAM().Users().Get(ctx); err == nil {
+		t.Error("expected a parse error for a malformed body")
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,521 @@
+// Command mockgen writes test doubles for the exported interfaces of a package.
+//
+// It is run through go:generate from a service package and writes
+// <package>mock/mock.go next to it:
+//
+//	//go:generate go run github.com/Zillaforge/cloud-sdk/hack/mockgen
+//
+// Each interface Foo becomes a struct Foo with one function field per method,
+// named after the method with a Func suffix. Calls are recorded, and methods
+// whose function is unset return zero values and, if the method returns an
+// error, ErrNotImplemented.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "package directory")
+	flag.Parse()
+
+	if err := run(*dir); err != nil {
+		log.Fatalf("mockgen: %v", err)
+	}
+}
+
+// run generates the mock package for the package in dir.
+func run(dir string) error {
+	importPath, err := importPathOf(dir)
+	if err != nil {
+		return err
+	}
+
+	src, err := generate(dir, importPath)
+	if err != nil {
+		return err
+	}
+
+	pkg, err := parsePackage(dir)
+	if err != nil {
+		return err
+	}
+	outDir := filepath.Join(dir, pkg.Name+"mock")
+	if err := os.MkdirAll(outDir, 0o750); err != nil {
+		return fmt.Errorf("failed to create %s: %w", outDir, err)
+	}
+	return os.WriteFile(filepath.Join(outDir, "mock.go"), src, 0o600)
+}
+
+// importPathOf derives the import path of dir from the enclosing go.mod.
+func importPathOf(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for root := abs; ; root = filepath.Dir(root) {
+		data, err := os.ReadFile(filepath.Join(root, "go.mod")) //nolint:gosec // Walks up from the package being generated
+		if err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				if module, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+					rel, err := filepath.Rel(root, abs)
+					if err != nil {
+						return "", err
+					}
+					return path.Join(strings.TrimSpace(module), filepath.ToSlash(rel)), nil
+				}
+			}
+			return "", fmt.Errorf("no module line in %s", filepath.Join(root, "go.mod"))
+		}
+		if filepath.Dir(root) == root {
+			return "", errors.New("go.mod not found")
+		}
+	}
+}
+
+// parsePackage parses the non-test Go files in dir.
+func parsePackage(dir string) (*ast.Package, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", dir, err)
+	}
+	for name, pkg := range pkgs {
+		if name != "main" {
+			return pkg, nil
+		}
+	}
+	return nil, fmt.Errorf("no package in %s", dir)
+}
+
+// iface is an exported interface and the file it was declared in.
+type iface struct {
+	name string
+	typ  *ast.InterfaceType
+	file *ast.File
+}
+
+// generate returns the formatted source of the mock package for the package in dir.
+func generate(dir, importPath string) ([]byte, error) {
+	pkg, err := parsePackage(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ifaces []iface
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if it, ok := ts.Type.(*ast.InterfaceType); ok && ts.Name.IsExported() {
+					ifaces = append(ifaces, iface{name: ts.Name.Name, typ: it, file: file})
+				}
+			}
+		}
+	}
+	if len(ifaces) == 0 {
+		return nil, fmt.Errorf("no exported interfaces in %s", dir)
+	}
+	sort.Slice(ifaces, func(i, j int) bool { return ifaces[i].name < ifaces[j].name })
+
+	g := &generator{
+		pkgName:    pkg.Name,
+		importPath: importPath,
+		imports:    map[string]string{},
+		aliases:    map[string]string{},
+	}
+	g.use(importPath, pkg.Name)
+
+	var body bytes.Buffer
+	for _, it := range ifaces {
+		if err := g.writeMock(&body, it); err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by hack/mockgen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "// Package %smock provides test doubles for the interfaces in package %s.\n", pkg.Name, pkg.Name)
+	fmt.Fprintf(&out, "package %smock\n\n", pkg.Name)
+	g.writeImports(&out)
+	out.WriteString(preamble)
+	out.Write(body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w\n%s", err, out.Bytes())
+	}
+	return src, nil
+}
+
+// preamble is shared by every generated mock package.
+const preamble = `
+// ErrNotImplemented is returned by mock methods whose function field is unset.
+var ErrNotImplemented = errors.New("mock method not implemented")
+
+// Call records one invocation of a mock method.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// recorder keeps the calls made to a mock. It is safe for concurrent use.
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns the calls made so far, in order.
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// CallCount returns how many times method was called.
+func (r *recorder) CallCount(method string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, c := range r.calls {
+		if c.Method == method {
+			n++
+		}
+	}
+	return n
+}
+
+func notImplemented(method string) error {
+	return fmt.Errorf("%s: %w", method, ErrNotImplemented)
+}
+`
+
+// generator accumulates the imports needed by the generated code.
+type generator struct {
+	pkgName    string
+	importPath string
+
+	// imports maps import paths to their alias in the generated file
+	imports map[string]string
+
+	// aliases maps aliases in the generated file to import paths
+	aliases map[string]string
+}
+
+// use registers importPath under a unique alias derived from name and returns it.
+func (g *generator) use(importPath, name string) string {
+	if alias, ok := g.imports[importPath]; ok {
+		return alias
+	}
+	alias := name
+	if _, taken := g.aliases[alias]; taken && strings.Contains(importPath, "/models/") {
+		alias = name + "model"
+	}
+	for i := 2; ; i++ {
+		if _, taken := g.aliases[alias]; !taken {
+			break
+		}
+		alias = name + strconv.Itoa(i)
+	}
+	g.imports[importPath] = alias
+	g.aliases[alias] = importPath
+	return alias
+}
+
+// writeImports writes the import block, standard library first.
+func (g *generator) writeImports(out *bytes.Buffer) {
+	paths := []string{"errors", "fmt", "sync"}
+	for p := range g.imports {
+		if p != "errors" && p != "fmt" && p != "sync" {
+			paths = append(paths, p)
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		si, sj := !strings.Contains(paths[i], "."), !strings.Contains(paths[j], ".")
+		if si != sj {
+			return si
+		}
+		return paths[i] < paths[j]
+	})
+
+	out.WriteString("import (\n")
+	std := true
+	for _, p := range paths {
+		if std && strings.Contains(p, ".") {
+			out.WriteString("\n")
+			std = false
+		}
+		if alias, ok := g.imports[p]; ok && alias != path.Base(p) {
+			fmt.Fprintf(out, "\t%s %q\n", alias, p)
+		} else {
+			fmt.Fprintf(out, "\t%q\n", p)
+		}
+	}
+	out.WriteString(")\n")
+}
+
+// param is a method parameter or result in the generated code.
+type param struct {
+	name     string
+	typ      string
+	variadic bool
+}
+
+// writeMock writes the mock struct and methods for one interface.
+func (g *generator) writeMock(out *bytes.Buffer, it iface) error {
+	type method struct {
+		name    string
+		params  []param
+		results []param
+	}
+
+	var methods []method
+	for _, field := range it.typ.Methods.List {
+		fn, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) == 0 {
+			return fmt.Errorf("%s: embedded interfaces are not supported", it.name)
+		}
+		params, err := g.fields(fn.Params, it.file, "arg")
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", it.name, field.Names[0].Name, err)
+		}
+		results, err := g.fields(fn.Results, it.file, "")
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", it.name, field.Names[0].Name, err)
+		}
+		for _, name := range field.Names {
+			methods = append(methods, method{name: name.Name, params: params, results: results})
+		}
+	}
+
+	fmt.Fprintf(out, "\n// %s is a mock of %s.%s.\n", it.name, g.pkgName, it.name)
+	fmt.Fprintf(out, "type %s struct {\n", it.name)
+	for _, m := range methods {
+		fmt.Fprintf(out, "\t%sFunc func(%s) %s\n", m.name, signature(m.params, true), resultList(m.results))
+	}
+	out.WriteString("\n\trecorder\n}\n")
+	fmt.Fprintf(out, "\nvar _ %s.%s = (*%s)(nil)\n", g.imports[g.importPath], it.name, it.name)
+
+	for _, m := range methods {
+		var args, callArgs []string
+		for _, p := range m.params {
+			args = append(args, p.name)
+			if p.variadic {
+				callArgs = append(callArgs, p.name+"...")
+			} else {
+				callArgs = append(callArgs, p.name)
+			}
+		}
+
+		fmt.Fprintf(out, "\n// %s calls %sFunc.\n", m.name, m.name)
+		fmt.Fprintf(out, "func (m *%s) %s(%s) %s {\n", it.name, m.name, signature(m.params, true), resultList(m.results))
+		fmt.Fprintf(out, "\tm.record(%s)\n", strings.Join(append([]string{strconv.Quote(m.name)}, args...), ", "))
+		fmt.Fprintf(out, "\tif m.%sFunc == nil {\n", m.name)
+		if len(m.results) > 0 {
+			var zeros []string
+			for i, r := range m.results {
+				if r.typ == "error" && i == len(m.results)-1 {
+					zeros = append(zeros, fmt.Sprintf("notImplemented(%q)", it.name+"."+m.name))
+					continue
+				}
+				fmt.Fprintf(out, "\t\tvar r%d %s\n", i, r.typ)
+				zeros = append(zeros, fmt.Sprintf("r%d", i))
+			}
+			fmt.Fprintf(out, "\t\treturn %s\n", strings.Join(zeros, ", "))
+		} else {
+			out.WriteString("\t\treturn\n")
+		}
+		out.WriteString("\t}\n")
+		call := fmt.Sprintf("m.%sFunc(%s)", m.name, strings.Join(callArgs, ", "))
+		if len(m.results) > 0 {
+			fmt.Fprintf(out, "\treturn %s\n}\n", call)
+		} else {
+			fmt.Fprintf(out, "\t%s\n}\n", call)
+		}
+	}
+	return nil
+}
+
+// fields converts a parameter or result list, naming unnamed entries with prefix.
+// An empty prefix leaves results unnamed.
+func (g *generator) fields(list *ast.FieldList, file *ast.File, prefix string) ([]param, error) {
+	if list == nil {
+		return nil, nil
+	}
+	var params []param
+	for _, field := range list.List {
+		typ, variadic := field.Type, false
+		if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+			typ, variadic = ellipsis.Elt, true
+		}
+		s, err := g.typeString(typ, file)
+		if err != nil {
+			return nil, err
+		}
+
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{{}}
+		}
+		for _, name := range names {
+			p := param{name: name.Name, typ: s, variadic: variadic}
+			if prefix == "" {
+				p.name = ""
+			} else if p.name == "" || p.name == "_" {
+				p.name = prefix + strconv.Itoa(len(params))
+			}
+			params = append(params, p)
+		}
+	}
+	return params, nil
+}
+
+// typeString renders a type expression from file, qualifying identifiers
+// declared in the mocked package and renaming imports to their generated aliases.
+func (g *generator) typeString(expr ast.Expr, file *ast.File) (string, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if types.Universe.Lookup(t.Name) != nil {
+			return t.Name, nil
+		}
+		return g.imports[g.importPath] + "." + t.Name, nil
+	case *ast.SelectorExpr:
+		pkgIdent, ok := t.X.(*ast.Ident)
+		if !ok {
+			return "", fmt.Errorf("unsupported selector %T", t.X)
+		}
+		importPath, err := resolveImport(file, pkgIdent.Name)
+		if err != nil {
+			return "", err
+		}
+		return g.use(importPath, path.Base(importPath)) + "." + t.Sel.Name, nil
+	case *ast.StarExpr:
+		s, err := g.typeString(t.X, file)
+		return "*" + s, err
+	case *ast.IndexExpr:
+		return g.instanceString(t.X, []ast.Expr{t.Index}, file)
+	case *ast.IndexListExpr:
+		return g.instanceString(t.X, t.Indices, file)
+	case *ast.ArrayType:
+		s, err := g.typeString(t.Elt, file)
+		if err != nil || t.Len == nil {
+			return "[]" + s, err
+		}
+		lit, ok := t.Len.(*ast.BasicLit)
+		if !ok {
+			return "", errors.New("unsupported array length")
+		}
+		return "[" + lit.Value + "]" + s, nil
+	case *ast.MapType:
+		k, err := g.typeString(t.Key, file)
+		if err != nil {
+			return "", err
+		}
+		v, err := g.typeString(t.Value, file)
+		return "map[" + k + "]" + v, err
+	case *ast.InterfaceType:
+		if len(t.Methods.List) > 0 {
+			return "", errors.New("unsupported inline interface")
+		}
+		return "interface{}", nil
+	case *ast.FuncType:
+		params, err := g.fields(t.Params, file, "")
+		if err != nil {
+			return "", err
+		}
+		results, err := g.fields(t.Results, file, "")
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace("func(" + signature(params, false) + ") " + resultList(results)), nil
+	default:
+		return "", fmt.Errorf("unsupported type %T", expr)
+	}
+}
+
+// instanceString renders an instantiation of the generic type generic with args.
+func (g *generator) instanceString(generic ast.Expr, args []ast.Expr, file *ast.File) (string, error) {
+	s, err := g.typeString(generic, file)
+	if err != nil {
+		return "", err
+	}
+	rendered := make([]string, len(args))
+	for i, arg := range args {
+		if rendered[i], err = g.typeString(arg, file); err != nil {
+			return "", err
+		}
+	}
+	return s + "[" + strings.Join(rendered, ", ") + "]", nil
+}
+
+// resolveImport returns the import path bound to name in file.
+func resolveImport(file *ast.File, name string) (string, error) {
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return "", err
+		}
+		if spec.Name != nil && spec.Name.Name == name {
+			return importPath, nil
+		}
+		if spec.Name == nil && path.Base(importPath) == name {
+			return importPath, nil
+		}
+	}
+	return "", fmt.Errorf("unknown package %q", name)
+}
+
+// signature renders a parameter list, with names when named is true.
+func signature(params []param, named bool) string {
+	parts := make([]string, len(params))
+	for i, p := range params {
+		typ := p.typ
+		if p.variadic {
+			typ = "..." + typ
+		}
+		if named && p.name != "" {
+			parts[i] = p.name + " " + typ
+		} else {
+			parts[i] = typ
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// resultList renders a result list.
+func resultList(results []param) string {
+	switch len(results) {
+	case 0:
+		return ""
+	case 1:
+		return results[0].typ
+	}
+	return "(" + signature(results, false) + ")"
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGeneratedMocksUpToDate fails when an interface changed without re-running
+// go generate.
+func TestGeneratedMocksUpToDate(t *testing.T) {
+	apis, err := filepath.Glob("../../modules/*/*/api.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apis) == 0 {
+		t.Fatal("no service packages found")
+	}
+
+	for _, api := range apis {
+		dir := filepath.Dir(api)
+		t.Run(dir, func(t *testing.T) {
+			importPath, err := importPathOf(dir)
+			if err != nil {
+				t.Fatalf("importPathOf() failed: %v", err)
+			}
+			want, err := generate(dir, importPath)
+			if err != nil {
+				t.Fatalf("generate() failed: %v", err)
+			}
+
+			pkg, err := parsePackage(dir)
+			if err != nil {
+				t.Fatalf("parsePackage() failed: %v", err)
+			}
+			got, err := os.ReadFile(filepath.Join(dir, pkg.Name+"mock", "mock.go"))
+			if err != nil {
+				t.Fatalf("mock not generated: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s is stale; run go generate ./modules/...", filepath.Join(dir, pkg.Name+"mock", "mock.go"))
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := generate("testdata/sample", "example.com/sample")
+	if err != nil {
+		t.Fatalf("generate() failed: %v", err)
+	}
+	out := string(src)
+
+	for _, want := range []string{
+		"package samplemock",
+		`"example.com/sample"`,
+		"var _ sample.WidgetsAPI = (*WidgetsAPI)(nil)",
+		"func(ctx context.Context, id string, opts ...sample.Option) (*sample.Widget, error)",
+		"return m.GetFunc(ctx, id, opts...)",
+		"func (m *WidgetsAPI) List(arg0 context.Context, arg1 map[string][]string) ([]*sample.Widget, int, error)",
+		`return r0, r1, notImplemented("WidgetsAPI.List")`,
+		"func (m *WidgetsAPI) Name() string",
+		"func (m *WidgetsAPI) Pairs(ctx context.Context) []sample.Pair[string, *sample.Widget]",
+		"\t\treturn\n\t}\n\tm.ResetFunc()",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated code missing %q\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package sample
+
+import (
+	"context"
+	"net/http"
+)
+
+// Option configures a call.
+type Option func(*http.Request)
+
+// Widget is returned by WidgetsAPI.
+type Widget struct {
+	ID string
+}
+
+// Pair is a generic type used in method signatures.
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// WidgetsAPI exercises the parameter shapes the generator supports.
+type WidgetsAPI interface {
+	Get(ctx context.Context, id string, opts ...Option) (*Widget, error)
+	List(context.Context, map[string][]string) ([]*Widget, int, error)
+	Name() string
+	Pairs(ctx context.Context) []Pair[string, *Widget]
+	Reset()
+}
@@ -0,0 +1,43 @@
+package cloudsdk
+
+import (
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+)
+
+// IdempotencyKeyHeader is the header carrying the idempotency key of a mutating request.
+const IdempotencyKeyHeader = internalhttp.IdempotencyKeyHeader
+
+// NewIdempotencyKey returns a random key suitable for WithCallIdempotencyKey.
+func NewIdempotencyKey() string {
+	return internalhttp.NewIdempotencyKey()
+}
+
+// WithIdempotencyKeys makes the transport attach a generated Idempotency-Key header to
+// every POST, PUT, PATCH and DELETE request that does not already carry one.
+// Mutations carrying a key are retried under the retry policy like GET requests,
+// and the same key is reused for every retry.
+func WithIdempotencyKeys() ClientOption {
+	return func(c *Client) {
+		c.idempotencyKeys = true
+	}
+}
+
+// WithCallIdempotencyKey sets the idempotency key for a single mutating call.
+// Reusing the key when re-issuing the same logical operation lets the platform
+// deduplicate it.
+func WithCallIdempotencyKey(key string) CallOption {
+	return internalhttp.WithCallIdempotencyKey(key)
+}
+
+// WithCallReconcile enables reconciliation for a single create call: when the create
+// fails ambiguously (network error, timeout or 5xx), the SDK looks the resource up by
+// name among those created since the call started, and returns it if exactly one
+// matches. Otherwise the original error is returned. Supported by servers and
+// floating IPs.
+//
+// The lookup runs even when the call's context has expired, bounded by its own
+// 10 second timeout. "Since the call started" allows the server clock to run up
+// to 30 seconds behind the client's.
+func WithCallReconcile() CallOption {
+	return internalhttp.WithCallReconcile()
+}
@@ -0,0 +1,43 @@
+package cloudsdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	serversmodels "github.com/Zillaforge/cloud-sdk/models/vps/servers"
+)
+
+// TestWithIdempotencyKeys tests that project clients send and retry with idempotency keys
+func TestWithIdempotencyKeys(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL, "test-token",
+		WithIdempotencyKeys(),
+		WithRetryPolicy(&RetryPolicy{MaxAttempts: 2, InitialInterval: time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	pc := &ProjectClient{client: client, projectID: "proj-123"}
+	ctx := WithCallOptions(context.Background(), WithCallIdempotencyKey("create-web-1"))
+	if _, err := pc.VPS().Servers().Create(ctx, &serversmodels.ServerCreateRequest{Name: "web"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if len(keys) != 2 || keys[0] != "create-web-1" || keys[1] != "create-web-1" {
+		t.Errorf("expected two attempts with caller key, got %v", keys)
+	}
+}
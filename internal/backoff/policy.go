@@ -0,0 +1,96 @@
+package backoff
+
+import (
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls whether and how failed requests are retried.
+// Zero-valued interval and multiplier fields fall back to DefaultStrategy values.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	// A value of 1 or less disables retries.
+	MaxAttempts int
+
+	// InitialInterval is the wait time before the first retry
+	InitialInterval time.Duration
+
+	// MaxInterval caps the wait time between retries
+	MaxInterval time.Duration
+
+	// Multiplier is the factor by which the interval increases each retry
+	Multiplier float64
+
+	// Jitter adds ±25% randomization to each interval
+	Jitter bool
+
+	// RetryableStatusCodes lists the HTTP status codes that are retried.
+	// When empty, 429, 502, 503 and 504 are retried.
+	RetryableStatusCodes []int
+
+	// ShouldRetry, when set, replaces the status code and method checks.
+	// req is the attempted request, resp is nil when no response was received,
+	// and err is the error the attempt produced.
+	ShouldRetry func(req *http.Request, resp *http.Response, err error) bool
+}
+
+// DefaultRetryPolicy returns the policy used when none is configured:
+// up to 4 attempts (3 retries), 100ms to 5s exponential backoff with jitter,
+// retrying 429/502/503/504 for GET and HEAD requests.
+func DefaultRetryPolicy() *RetryPolicy {
+	s := DefaultStrategy()
+	return &RetryPolicy{
+		MaxAttempts:     s.MaxRetries + 1,
+		InitialInterval: s.InitialInterval,
+		MaxInterval:     s.MaxInterval,
+		Multiplier:      s.Multiplier,
+		Jitter:          s.Jitter,
+	}
+}
+
+// NoRetryPolicy returns a policy that never retries.
+func NoRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 1}
+}
+
+// CanAttempt reports whether another attempt is allowed after attempt
+// (zero-indexed) has failed.
+func (p *RetryPolicy) CanAttempt(attempt int) bool {
+	return attempt+1 < p.MaxAttempts
+}
+
+// IsRetryableStatusCode reports whether statusCode is retried by this policy.
+func (p *RetryPolicy) IsRetryableStatusCode(statusCode int) bool {
+	if len(p.RetryableStatusCodes) == 0 {
+		return IsRetryableStatusCode(statusCode)
+	}
+	for _, code := range p.RetryableStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// Duration calculates the backoff duration before retry number attempt (zero-indexed).
+func (p *RetryPolicy) Duration(attempt int) time.Duration {
+	return p.strategy().Duration(attempt)
+}
+
+// strategy converts the policy's interval settings into a Strategy,
+// filling unset fields from DefaultStrategy.
+func (p *RetryPolicy) strategy() *Strategy {
+	s := DefaultStrategy()
+	if p.InitialInterval > 0 {
+		s.InitialInterval = p.InitialInterval
+	}
+	if p.MaxInterval > 0 {
+		s.MaxInterval = p.MaxInterval
+	}
+	if p.Multiplier > 0 {
+		s.Multiplier = p.Multiplier
+	}
+	s.Jitter = p.Jitter
+	s.MaxRetries = p.MaxAttempts - 1
+	return s
+}
@@ -0,0 +1,65 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	if policy.MaxAttempts != 4 {
+		t.Errorf("expected MaxAttempts 4, got %d", policy.MaxAttempts)
+	}
+	if !policy.CanAttempt(2) {
+		t.Error("expected a 4th attempt to be allowed after the 3rd failed")
+	}
+	if policy.CanAttempt(3) {
+		t.Error("expected no 5th attempt")
+	}
+}
+
+func TestNoRetryPolicy(t *testing.T) {
+	if NoRetryPolicy().CanAttempt(0) {
+		t.Error("expected no retry")
+	}
+}
+
+func TestRetryPolicy_IsRetryableStatusCode(t *testing.T) {
+	defaults := &RetryPolicy{}
+	if !defaults.IsRetryableStatusCode(503) || defaults.IsRetryableStatusCode(500) {
+		t.Error("expected default status codes when none configured")
+	}
+
+	custom := &RetryPolicy{RetryableStatusCodes: []int{500}}
+	if !custom.IsRetryableStatusCode(500) {
+		t.Error("expected 500 to be retryable")
+	}
+	if custom.IsRetryableStatusCode(503) {
+		t.Error("expected 503 not to be retryable with custom set")
+	}
+}
+
+func TestRetryPolicy_Duration(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts:     5,
+		InitialInterval: 10 * time.Millisecond,
+		MaxInterval:     25 * time.Millisecond,
+		Multiplier:      2,
+	}
+
+	if d := policy.Duration(0); d != 10*time.Millisecond {
+		t.Errorf("expected 10ms, got %v", d)
+	}
+	if d := policy.Duration(1); d != 20*time.Millisecond {
+		t.Errorf("expected 20ms, got %v", d)
+	}
+	if d := policy.Duration(5); d != 25*time.Millisecond {
+		t.Errorf("expected capped 25ms, got %v", d)
+	}
+
+	// Zero fields fall back to defaults
+	if d := (&RetryPolicy{}).Duration(0); d != 100*time.Millisecond {
+		t.Errorf("expected default 100ms, got %v", d)
+	}
+}
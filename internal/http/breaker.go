@@ -0,0 +1,149 @@
+package http
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/internal/types"
+)
+
+// Circuit breaker states.
+const (
+	breakerClosed = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// Breaker is a circuit breaker shared by every client that talks to one service.
+// After Threshold consecutive network, timeout or 5xx failures it opens and
+// rejects requests without sending them. Once Cooldown has elapsed a single probe
+// request is let through: success closes the circuit, failure re-opens it.
+type Breaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    int
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewBreaker creates a circuit breaker for the named service.
+// A threshold below 1 is treated as 1.
+func NewBreaker(name string, threshold int, cooldown time.Duration) *Breaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &Breaker{
+		name:      name,
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// WithBreaker attaches a circuit breaker that every request attempt must pass.
+func WithBreaker(b *Breaker) Option {
+	return func(c *Client) {
+		c.breaker = b
+	}
+}
+
+// Allow reports whether a request may be sent. A true result obliges the caller
+// to report the outcome with Record.
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		b.probing = true
+		return true
+	case breakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Record reports the outcome of a request admitted by Allow.
+// Network errors, timeouts and 5xx responses count as failures; any other response,
+// including 4xx, shows the service is reachable and counts as success. Outcomes that
+// say nothing about the service, such as cancellation, only release a probe slot.
+func (b *Breaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasProbe := b.state == breakerHalfOpen
+	b.probing = false
+
+	switch {
+	case err == nil || isServiceResponse(err):
+		b.state = breakerClosed
+		b.failures = 0
+	case isServiceFailure(err):
+		b.failures++
+		if wasProbe || b.failures >= b.threshold {
+			b.state = breakerOpen
+			b.openedAt = b.now()
+		}
+	}
+}
+
+// Cancel reports that a request admitted by Allow was never sent.
+func (b *Breaker) Cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// Name returns the service name the breaker guards.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State returns the current state: "closed", "open" or "half-open".
+func (b *Breaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// isServiceFailure reports whether err shows the service to be down or unhealthy.
+func isServiceFailure(err error) bool {
+	var sdkErr *types.SDKError
+	if !errors.As(err, &sdkErr) {
+		return false
+	}
+	if sdkErr.StatusCode >= 500 {
+		return true
+	}
+	category, _ := sdkErr.Meta["category"].(string)
+	return sdkErr.StatusCode == 0 && (category == "network" || category == "timeout")
+}
+
+// isServiceResponse reports whether err carries a non-5xx HTTP response.
+func isServiceResponse(err error) bool {
+	var sdkErr *types.SDKError
+	return errors.As(err, &sdkErr) && sdkErr.StatusCode > 0 && sdkErr.StatusCode < 500
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/internal/backoff"
+	"github.com/Zillaforge/cloud-sdk/internal/types"
+)
+
+func TestBreaker_Transitions(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	b := NewBreaker("vrm", 2, 10*time.Second)
+	b.now = func() time.Time { return now }
+
+	serverErr := types.NewHTTPError(http.StatusServiceUnavailable, "")
+
+	// One failure keeps the circuit closed
+	if !b.Allow() {
+		t.Fatal("expected closed breaker to allow")
+	}
+	b.Record(serverErr)
+	if b.State() != "closed" {
+		t.Fatalf("expected closed after 1 failure, got %s", b.State())
+	}
+
+	// Second consecutive failure opens it
+	b.Allow()
+	b.Record(types.NewNetworkError("connection refused", nil))
+	if b.State() != "open" || b.Allow() {
+		t.Fatalf("expected open breaker to reject, state %s", b.State())
+	}
+
+	// After the cool-down a single probe is admitted
+	now = now.Add(10 * time.Second)
+	if !b.Allow() {
+		t.Fatal("expected probe after cool-down")
+	}
+	if b.State() != "half-open" || b.Allow() {
+		t.Fatal("expected only one concurrent probe")
+	}
+
+	// A failed probe re-opens the circuit immediately
+	b.Record(serverErr)
+	if b.State() != "open" {
+		t.Fatalf("expected re-open after failed probe, got %s", b.State())
+	}
+
+	// A successful probe closes it
+	now = now.Add(10 * time.Second)
+	b.Allow()
+	b.Record(nil)
+	if b.State() != "closed" {
+		t.Fatalf("expected closed after successful probe, got %s", b.State())
+	}
+}
+
+func TestBreaker_RecordClassification(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantOpen bool
+	}{
+		{name: "5xx", err: types.NewHTTPError(http.StatusInternalServerError, ""), wantOpen: true},
+		{name: "network", err: types.NewNetworkError("reset", nil), wantOpen: true},
+		{name: "timeout", err: types.NewTimeoutError(context.DeadlineExceeded), wantOpen: true},
+		{name: "4xx", err: types.NewHTTPError(http.StatusNotFound, ""), wantOpen: false},
+		{name: "canceled", err: types.NewCanceledError(context.Canceled), wantOpen: false},
+		{name: "success", err: nil, wantOpen: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBreaker("vps", 1, time.Minute)
+			b.Allow()
+			b.Record(tt.err)
+			if got := b.State() == "open"; got != tt.wantOpen {
+				t.Errorf("open = %v, want %v", got, tt.wantOpen)
+			}
+		})
+	}
+}
+
+func TestBreaker_CanceledProbeReleasesSlot(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	b := NewBreaker("iam", 1, time.Second)
+	b.now = func() time.Time { return now }
+
+	b.Allow()
+	b.Record(types.NewNetworkError("reset", nil))
+	now = now.Add(time.Second)
+
+	b.Allow()
+	b.Cancel()
+	if !b.Allow() {
+		t.Error("expected a new probe after the previous one was canceled")
+	}
+}
+
+func TestClient_Do_CircuitOpen(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	breaker := NewBreaker("vrm", 3, time.Minute)
+	policy := &backoff.RetryPolicy{MaxAttempts: 5, InitialInterval: time.Millisecond}
+	client := NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil,
+		WithBreaker(breaker), WithRetryPolicy(policy))
+
+	// Retries stop as soon as the circuit opens
+	err := client.Do(context.Background(), &Request{Method: "GET", Path: "/test"}, nil)
+	var sdkErr *types.SDKError
+	if !errors.As(err, &sdkErr) || sdkErr.Meta["category"] != "circuit_open" {
+		t.Fatalf("expected circuit_open error, got %v", err)
+	}
+	if !errors.As(sdkErr.Cause, new(*types.SDKError)) {
+		t.Errorf("expected last failure as cause, got %v", sdkErr.Cause)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("expected 3 requests before opening, got %d", got)
+	}
+
+	// Subsequent calls fail fast without reaching the server
+	err = client.Do(context.Background(), &Request{Method: "GET", Path: "/test"}, nil)
+	if !errors.As(err, &sdkErr) || sdkErr.Meta["category"] != "circuit_open" {
+		t.Fatalf("expected circuit_open error, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("expected no further requests, got %d", got)
+	}
+}
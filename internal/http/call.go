@@ -0,0 +1,146 @@
+package http
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/internal/backoff"
+)
+
+// NamespaceHeader selects the namespace a request operates in.
+const NamespaceHeader = "X-Namespace"
+
+// CallOptions holds per-call overrides for a single SDK operation.
+type CallOptions struct {
+	// RetryPolicy overrides the client's retry policy when set
+	RetryPolicy *backoff.RetryPolicy
+
+	// IdempotencyKey is sent as the Idempotency-Key header on mutating requests
+	IdempotencyKey string
+
+	// Reconcile enables looking up a created resource by name after an ambiguous failure
+	Reconcile bool
+
+	// ResponseMetadata, when set, receives the metadata of the call's last attempt
+	ResponseMetadata *ResponseMetadata
+
+	// Headers are set on every request of the call, overriding headers set by the
+	// SDK method. The map is never modified in place.
+	Headers map[string]string
+
+	// Timeout bounds each request of the call, retries included, when positive
+	Timeout time.Duration
+
+	// DryRun, when set, receives the mutating requests of the call instead of
+	// the server (see DryRun)
+	DryRun *DryRun
+}
+
+// CallOption is a functional option that customizes a single SDK operation.
+type CallOption func(*CallOptions)
+
+// WithCallRetryPolicy overrides the retry policy for a single call.
+func WithCallRetryPolicy(policy *backoff.RetryPolicy) CallOption {
+	return func(o *CallOptions) {
+		o.RetryPolicy = policy
+	}
+}
+
+// WithCallIdempotencyKey sets the idempotency key for a single mutating call.
+func WithCallIdempotencyKey(key string) CallOption {
+	return func(o *CallOptions) {
+		o.IdempotencyKey = key
+	}
+}
+
+// WithCallReconcile enables name-based reconciliation for a single create call.
+func WithCallReconcile() CallOption {
+	return func(o *CallOptions) {
+		o.Reconcile = true
+	}
+}
+
+// ReconcileTimeout bounds the lookup that reconciles an ambiguous create.
+const ReconcileTimeout = 10 * time.Second
+
+// ReconcileContext returns the context for the lookup that reconciles an
+// ambiguous create made with ctx. A create whose deadline expired is worth
+// reconciling, so the lookup is detached from ctx's cancellation and bounded by
+// ReconcileTimeout instead. It keeps ctx's call options except the response
+// metadata, which stays that of the create.
+func ReconcileContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ReconcileTimeout)
+	return WithCallOptions(ctx, WithCallResponseMetadata(nil)), cancel
+}
+
+// WithCallResponseMetadata captures the response metadata of a single call in md.
+// md is filled whether the call succeeds or fails.
+func WithCallResponseMetadata(md *ResponseMetadata) CallOption {
+	return func(o *CallOptions) {
+		o.ResponseMetadata = md
+	}
+}
+
+// WithCallHeader sets a request header for a single call.
+func WithCallHeader(name, value string) CallOption {
+	return func(o *CallOptions) {
+		headers := make(map[string]string, len(o.Headers)+1)
+		for k, v := range o.Headers {
+			headers[k] = v
+		}
+		headers[name] = value
+		o.Headers = headers
+	}
+}
+
+// WithCallNamespace sends the call in namespace through the X-Namespace header.
+// An empty namespace leaves the header unset, selecting the default namespace.
+func WithCallNamespace(namespace string) CallOption {
+	if namespace == "" {
+		return func(*CallOptions) {}
+	}
+	return WithCallHeader(NamespaceHeader, namespace)
+}
+
+// WithCallTimeout bounds each request of a single call, retries included.
+func WithCallTimeout(d time.Duration) CallOption {
+	return func(o *CallOptions) {
+		o.Timeout = d
+	}
+}
+
+// WithCallDryRun records the mutating requests of a single call in dr instead of
+// sending them.
+func WithCallDryRun(dr *DryRun) CallOption {
+	return func(o *CallOptions) {
+		o.DryRun = dr
+	}
+}
+
+type callOptionsKey struct{}
+
+// WithCallOptions returns a context carrying opts for every request made with it.
+// Options are applied on top of any options already present in ctx.
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+	merged := *callOptionsFrom(ctx)
+	for _, opt := range opts {
+		opt(&merged)
+	}
+	return context.WithValue(ctx, callOptionsKey{}, &merged)
+}
+
+// CallOptionsFromContext returns a copy of the call options carried by ctx.
+func CallOptionsFromContext(ctx context.Context) CallOptions {
+	return *callOptionsFrom(ctx)
+}
+
+// callOptionsFrom returns the call options carried by ctx (never nil).
+func callOptionsFrom(ctx context.Context) *CallOptions {
+	if opts, ok := ctx.Value(callOptionsKey{}).(*CallOptions); ok {
+		return opts
+	}
+	return &CallOptions{}
+}
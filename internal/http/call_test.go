@@ -0,0 +1,100 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_Do_CallHeaders(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token", &http.Client{Timeout: 5 * time.Second}, nil)
+	req := &Request{Method: "GET", Path: "/repositories", Headers: map[string]string{"X-Namespace": "public", "X-Method": "set"}}
+	err := client.Do(context.Background(), req, nil,
+		WithCallNamespace("private"),
+		WithCallHeader("X-Extra", "1"),
+		WithCallNamespace(""),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Get(NamespaceHeader) != "private" {
+		t.Errorf("expected the call namespace to override the method header, got %q", got.Get(NamespaceHeader))
+	}
+	if got.Get("X-Extra") != "1" || got.Get("X-Method") != "set" {
+		t.Errorf("expected call and method headers to be sent, got %v", got)
+	}
+}
+
+func TestClient_Do_CallTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token", &http.Client{Timeout: 5 * time.Second}, nil)
+	start := time.Now()
+	err := client.Do(context.Background(), &Request{Method: "GET", Path: "/slow"}, nil, WithCallTimeout(20*time.Millisecond))
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the call timeout to apply, took %v", elapsed)
+	}
+}
+
+func TestClient_Do_DryRun(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token", &http.Client{Timeout: 5 * time.Second}, nil)
+	var dr DryRun
+	ctx := WithCallOptions(context.Background(), WithCallDryRun(&dr), WithCallNamespace("private"))
+
+	if err := client.Do(ctx, &Request{Method: "GET", Path: "/servers"}, nil); err != nil {
+		t.Fatalf("expected GET to be sent, got %v", err)
+	}
+	body := map[string]string{"name": "web", "password": "s3cret"}
+	err := client.Do(ctx, &Request{Method: "POST", Path: "/servers", Operation: "servers.Create", Body: body}, nil)
+	if !errors.Is(err, ErrDryRun) {
+		t.Fatalf("expected ErrDryRun, got %v", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected only the GET to reach the server, got %d requests", got)
+	}
+	requests := dr.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 recorded request, got %d", len(requests))
+	}
+	rec := requests[0]
+	if rec.Method != "POST" || rec.Path != "/servers" || rec.Operation != "servers.Create" {
+		t.Errorf("unexpected recorded request: %+v", rec)
+	}
+	if rec.Header.Get(NamespaceHeader) != "private" || rec.Header.Get("Authorization") != "" {
+		t.Errorf("expected call headers without Authorization, got %v", rec.Header)
+	}
+	if strings.Contains(string(rec.Body), "s3cret") || !strings.Contains(string(rec.Body), `"name":"web"`) {
+		t.Errorf("expected a redacted body, got %s", rec.Body)
+	}
+}
@@ -6,32 +6,82 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/Zillaforge/cloud-sdk/internal/backoff"
 	"github.com/Zillaforge/cloud-sdk/internal/types"
+	"github.com/Zillaforge/cloud-sdk/metrics"
+	"github.com/Zillaforge/cloud-sdk/redact"
+	"github.com/Zillaforge/cloud-sdk/tracing"
 )
 
 // Client wraps the standard HTTP client with retry, timeout, and error handling.
 type Client struct {
-	baseURL       string
-	token         string
-	httpClient    *http.Client
-	logger        types.Logger
-	retryStrategy *backoff.Strategy
+	baseURL     string
+	tokenSource types.TokenSource
+	httpClient  *http.Client
+	logger      types.Logger
+	retryPolicy *backoff.RetryPolicy
+
+	autoIdempotencyKeys bool
+	rateLimitCallback   RateLimitCallback
+	limiter             *Limiter
+	breaker             *Breaker
+	middleware          []Middleware
+	tracer              tracing.Tracer
+	metrics             metrics.Recorder
+	service             string
+	errorHeaders        []string
+	requestLogging      *RequestLogging
+}
+
+// Option is a functional option for configuring the internal HTTP client.
+type Option func(*Client)
+
+// WithTokenSource sets the token source used to authenticate requests.
+// It takes precedence over the static token passed to NewClient.
+func WithTokenSource(ts types.TokenSource) Option {
+	return func(c *Client) {
+		if ts != nil {
+			c.tokenSource = ts
+		}
+	}
+}
+
+// WithRetryPolicy sets the default retry policy for all requests made by the client.
+func WithRetryPolicy(policy *backoff.RetryPolicy) Option {
+	return func(c *Client) {
+		if policy != nil {
+			c.retryPolicy = policy
+		}
+	}
+}
+
+// WithIdempotencyKeys enables generating an Idempotency-Key for every mutating request
+// that does not already carry one, which makes those requests eligible for retry.
+func WithIdempotencyKeys(enabled bool) Option {
+	return func(c *Client) {
+		c.autoIdempotencyKeys = enabled
+	}
 }
 
 // NewClient creates a new internal HTTP client.
-func NewClient(baseURL, token string, httpClient *http.Client, logger types.Logger) *Client {
-	return &Client{
-		baseURL:       baseURL,
-		token:         token,
-		httpClient:    httpClient,
-		logger:        logger,
-		retryStrategy: backoff.DefaultStrategy(),
+func NewClient(baseURL, token string, httpClient *http.Client, logger types.Logger, opts ...Option) *Client {
+	c := &Client{
+		baseURL:     baseURL,
+		tokenSource: types.StaticTokenSource(token),
+		httpClient:  httpClient,
+		logger:      logger,
+		retryPolicy: backoff.DefaultRetryPolicy(),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 // Request represents an HTTP request to be executed.
@@ -40,24 +90,142 @@ type Request struct {
 	Path    string
 	Body    interface{}
 	Headers map[string]string
+
+	// Operation names the SDK method making the request, e.g. "vps.servers.Create"
+	Operation string
+
+	// Attempt is the 1-based attempt number, set by Client.Do for each attempt
+	Attempt int
+}
+
+// header returns the value of the named request header, matching case-insensitively.
+func (r *Request) header(name string) string {
+	for key, value := range r.Headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+// WithHeader returns a shallow copy of r with the header set, leaving r unchanged.
+// An existing header of the same name, in any case, is replaced.
+func (r *Request) WithHeader(name, value string) *Request {
+	clone := *r
+	clone.Headers = make(map[string]string, len(r.Headers)+1)
+	for key, v := range r.Headers {
+		if !strings.EqualFold(key, name) {
+			clone.Headers[key] = v
+		}
+	}
+	clone.Headers[name] = value
+	return &clone
 }
 
 // Do executes an HTTP request with retry logic and error handling.
-// The context can override the default timeout.
-func (c *Client) Do(ctx context.Context, req *Request, result interface{}) error {
-	// Apply default timeout if context doesn't have a deadline
-	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+// opts are applied on top of the call options carried by ctx. A per-call timeout
+// bounds the request; otherwise the context can override the default timeout.
+func (c *Client) Do(ctx context.Context, req *Request, result interface{}, opts ...CallOption) error {
+	if len(opts) > 0 {
+		ctx = WithCallOptions(ctx, opts...)
+	}
+
+	if timeout := callOptionsFrom(ctx).Timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	} else if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		// Apply default timeout if context doesn't have a deadline
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, c.httpClient.Timeout)
 		defer cancel()
 	}
 
+	// Trace the whole operation, retries included
+	if c.tracer != nil {
+		var span tracing.Span
+		ctx, span = c.startOperation(ctx, req)
+		err := c.do(ctx, req, result)
+		endOperation(span, err)
+		return err
+	}
+
+	return c.do(ctx, req, result)
+}
+
+// do runs the retry loop for Do.
+func (c *Client) do(ctx context.Context, req *Request, result interface{}) (err error) {
+	// Per-call policy overrides the client default
+	callOpts := callOptionsFrom(ctx)
+	policy := c.retryPolicy
+	if callOpts.RetryPolicy != nil {
+		policy = callOpts.RetryPolicy
+	}
+
+	// Report the last attempt to the caller and on any returned error
+	md := ResponseMetadata{Method: req.Method, Path: req.Path}
+	start := time.Now()
+	defer func() { err = c.finish(&md, callOpts, start, err) }()
+
+	// Per-call headers override the ones set by the SDK method
+	for name, value := range callOpts.Headers {
+		req = req.WithHeader(name, value)
+	}
+
+	// Attach the idempotency key once so every retry reuses it
+	req = c.withIdempotencyKey(req, callOpts)
+
+	// A dry run records mutating requests instead of sending them
+	if callOpts.DryRun != nil && isMutatingMethod(req.Method) {
+		if err := callOpts.DryRun.record(req); err != nil {
+			return types.NewSDKError(0, 0, "failed to marshal request body", nil, err)
+		}
+		return ErrDryRun
+	}
+
 	attempt := 0
+	sent := 0
+	tokenRefreshed := false
+	var lastErr error
 
 	for {
-		err := c.doOnce(ctx, req, result)
+		// Fail fast without consuming a rate-limit token while the service's circuit is open
+		if c.breaker != nil && !c.breaker.Allow() {
+			return types.NewCircuitOpenError(c.breaker.Name(), lastErr)
+		}
+
+		// Respect the client-side rate limit before every attempt
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				if c.breaker != nil {
+					c.breaker.Cancel()
+				}
+				if errors.Is(err, context.DeadlineExceeded) {
+					return types.NewTimeoutError(err)
+				}
+				return types.NewCanceledError(err)
+			}
+		}
+
+		sent++
+		httpReq, resp, err := c.attempt(ctx, req, sent)
+		md.observe(sent, resp)
+		if c.breaker != nil {
+			c.breaker.Record(err)
+		}
+		lastErr = err
+
+		var httpResp *http.Response
+		if resp != nil {
+			httpResp = &http.Response{StatusCode: resp.StatusCode, Header: resp.Header, Request: httpReq}
+			if c.rateLimitCallback != nil {
+				if info, ok := parseRateLimit(req, httpResp, time.Now()); ok {
+					c.rateLimitCallback(info)
+				}
+			}
+		}
 		if err == nil {
-			return nil
+			return decodeResult(resp, result)
 		}
 
 		// Check if we should retry
@@ -66,21 +234,50 @@ func (c *Client) Do(ctx context.Context, req *Request, result interface{}) error
 			return err // Not an SDKError, don't retry
 		}
 
-		// Only retry for specific status codes and safe methods
-		if !backoff.IsRetryableStatusCode(sdkErr.StatusCode) || !backoff.IsRetryableMethod(req.Method) {
+		// Replay once with a fresh token if the current one was rejected
+		if errors.Is(sdkErr, types.ErrUnauthorized) && !tokenRefreshed {
+			if invalidator, ok := c.tokenSource.(types.TokenInvalidator); ok {
+				tokenRefreshed = true
+				invalidator.Invalidate(bearerToken(httpReq))
+				if c.logger != nil {
+					c.logger.Debug("token rejected, refreshing", "method", req.Method, "path", req.Path)
+				}
+				continue
+			}
+		}
+
+		// Ask the policy whether this failure is retryable
+		if !c.shouldRetry(policy, req, httpReq, httpResp, sdkErr) {
 			return err
 		}
 
 		// Check if we can retry
-		if !c.retryStrategy.ShouldRetry(attempt) {
+		if !policy.CanAttempt(attempt) {
 			if c.logger != nil {
 				c.logger.Debug("max retries reached", "method", req.Method, "path", req.Path, "attempts", attempt+1)
 			}
 			return err
 		}
 
-		// Calculate backoff duration
-		duration := c.retryStrategy.Duration(attempt)
+		// Calculate backoff duration, waiting at least as long as the server asks
+		duration := policy.Duration(attempt)
+		wait := "backoff"
+		if httpResp != nil {
+			if retryAfter, ok := backoff.ParseRetryAfter(httpResp.Header.Get("Retry-After"), time.Now()); ok && retryAfter > duration {
+				duration = retryAfter
+				wait = "Retry-After"
+			}
+		}
+
+		// Don't sleep past the deadline; the retry could never complete. The error
+		// reports the deadline alongside the last failure, unlike exhausted retries.
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < duration {
+			if c.logger != nil {
+				c.logger.Debug("retry wait exceeds deadline", "method", req.Method, "path", req.Path, "backoff", duration)
+			}
+			return fmt.Errorf("skipped retry as %s of %v exceeds the context deadline: %w: %w", wait, duration, context.DeadlineExceeded, err)
+		}
+		traceRetry(ctx, attempt+1, duration, err)
 		if c.logger != nil {
 			c.logger.Debug("retrying request", "method", req.Method, "path", req.Path, "attempt", attempt+1, "backoff", duration)
 		}
@@ -95,8 +292,59 @@ func (c *Client) Do(ctx context.Context, req *Request, result interface{}) error
 	}
 }
 
-// doOnce executes a single HTTP request without retry.
-func (c *Client) doOnce(ctx context.Context, req *Request, result interface{}) error {
+// shouldRetry reports whether a failed attempt may be retried under policy.
+// Without a custom predicate, only retryable status codes are retried, and only for
+// safe methods or mutations carrying an idempotency key.
+func (c *Client) shouldRetry(policy *backoff.RetryPolicy, req *Request, httpReq *http.Request, httpResp *http.Response, sdkErr *types.SDKError) bool {
+	if policy.ShouldRetry != nil {
+		return policy.ShouldRetry(httpReq, httpResp, sdkErr)
+	}
+	if !backoff.IsRetryableMethod(req.Method) && req.header(IdempotencyKeyHeader) == "" {
+		return false
+	}
+	return policy.IsRetryableStatusCode(sdkErr.StatusCode)
+}
+
+// attempt runs a single request attempt through the middleware chain.
+// number is the 1-based count of attempts made so far, including token replays.
+// It returns the request sent on the wire, which is nil when a middleware
+// answered without calling the transport.
+func (c *Client) attempt(ctx context.Context, req *Request, number int) (*http.Request, *Response, error) {
+	var httpReq *http.Request
+	var handler Handler = func(ctx context.Context, req *Request) (*Response, error) {
+		var resp *Response
+		var err error
+		httpReq, resp, err = c.send(ctx, req)
+		return resp, err
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		handler = c.middleware[i](handler)
+	}
+	if c.tracer != nil {
+		handler = c.traceAttempt(handler)
+	}
+
+	attemptReq := *req
+	attemptReq.Attempt = number
+	logging := c.logger != nil && c.requestLogging != nil
+	if logging {
+		c.logRequest(&attemptReq)
+	}
+	start := time.Now()
+	resp, err := handler(ctx, &attemptReq)
+	duration := time.Since(start)
+	if c.metrics != nil {
+		c.recordAttempt(req, number, resp, err, duration)
+	}
+	if logging {
+		c.logResponse(&attemptReq, resp, err, duration)
+	}
+	return httpReq, resp, err
+}
+
+// send executes a single HTTP request on the wire.
+// Error responses are returned together with their parsed SDKError.
+func (c *Client) send(ctx context.Context, req *Request) (*http.Request, *Response, error) {
 	// Build full URL
 	url := c.baseURL + req.Path
 
@@ -105,7 +353,7 @@ func (c *Client) doOnce(ctx context.Context, req *Request, result interface{}) e
 	if req.Body != nil {
 		bodyBytes, err := json.Marshal(req.Body)
 		if err != nil {
-			return types.NewSDKError(0, 0, "failed to marshal request body", nil, err)
+			return nil, nil, types.NewSDKError(0, 0, "failed to marshal request body", nil, err)
 		}
 		bodyReader = bytes.NewReader(bodyBytes)
 	}
@@ -113,11 +361,17 @@ func (c *Client) doOnce(ctx context.Context, req *Request, result interface{}) e
 	// Create HTTP request
 	httpReq, err := http.NewRequestWithContext(ctx, req.Method, url, bodyReader)
 	if err != nil {
-		return types.NewSDKError(0, 0, "failed to create request", nil, err)
+		return nil, nil, types.NewSDKError(0, 0, "failed to create request", nil, err)
+	}
+
+	// Resolve the bearer token for this attempt
+	token, err := c.tokenSource.Token(ctx)
+	if err != nil {
+		return httpReq, nil, types.NewAuthError(err)
 	}
 
 	// Set headers
-	httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	httpReq.Header.Set("Authorization", "Bearer "+token)
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("Accept", "application/json")
 	for key, value := range req.Headers {
@@ -125,44 +379,59 @@ func (c *Client) doOnce(ctx context.Context, req *Request, result interface{}) e
 	}
 
 	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
+	httpResp, err := c.httpClient.Do(httpReq)
 	if err != nil {
 		// Check for context errors
 		if ctx.Err() == context.DeadlineExceeded {
-			return types.NewTimeoutError(err)
+			return httpReq, nil, types.NewTimeoutError(err)
 		}
 		if ctx.Err() == context.Canceled {
-			return types.NewCanceledError(err)
+			return httpReq, nil, types.NewCanceledError(err)
 		}
-		return types.NewNetworkError(err.Error(), err)
+		return httpReq, nil, types.NewNetworkError(redact.String(err.Error(), token), err)
 	}
-	defer resp.Body.Close()
+	defer httpResp.Body.Close()
 
 	// Read response body
-	bodyBytes, err := io.ReadAll(resp.Body)
+	bodyBytes, err := io.ReadAll(httpResp.Body)
+	resp := &Response{StatusCode: httpResp.StatusCode, Header: httpResp.Header, Body: bodyBytes}
 	if err != nil {
-		return types.NewSDKError(resp.StatusCode, 0, "failed to read response body", nil, err)
+		return httpReq, resp, types.NewSDKError(httpResp.StatusCode, 0, "failed to read response body", nil, err)
 	}
 
 	// Handle error responses
-	if resp.StatusCode >= 400 {
-		return c.parseErrorResponse(resp.StatusCode, bodyBytes)
+	if httpResp.StatusCode >= 400 {
+		return httpReq, resp, c.parseErrorResponse(httpResp.StatusCode, bodyBytes)
 	}
 
-	// Parse success response
-	if result != nil && len(bodyBytes) > 0 {
-		if err := json.Unmarshal(bodyBytes, result); err != nil {
-			return types.NewSDKError(resp.StatusCode, 0, "failed to parse response", nil, err)
-		}
-	}
+	return httpReq, resp, nil
+}
 
+// decodeResult unmarshals a successful response body into result.
+func decodeResult(resp *Response, result interface{}) error {
+	if result == nil || resp == nil || len(resp.Body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Body, result); err != nil {
+		return types.NewSDKError(resp.StatusCode, 0, "failed to parse response", nil, err)
+	}
 	return nil
 }
 
-// ErrorResponse represents the standard error response format.
+// bearerToken returns the token httpReq was authenticated with.
+func bearerToken(httpReq *http.Request) string {
+	if httpReq == nil {
+		return ""
+	}
+	return strings.TrimPrefix(httpReq.Header.Get("Authorization"), "Bearer ")
+}
+
+// ErrorResponse represents the standard error response format. Some services
+// send only {"error": "..."}, which stands in for the message.
 type ErrorResponse struct {
 	ErrorCode int                    `json:"errorCode"`
 	Message   string                 `json:"message"`
+	Error     string                 `json:"error,omitempty"`
 	Meta      map[string]interface{} `json:"meta,omitempty"`
 }
 
@@ -173,6 +442,9 @@ func (c *Client) parseErrorResponse(statusCode int, body []byte) error {
 		// Failed to parse as structured error, return raw body
 		return types.NewHTTPError(statusCode, string(body))
 	}
+	if errResp.Message == "" {
+		errResp.Message = errResp.Error
+	}
 
-	return types.NewSDKError(statusCode, errResp.ErrorCode, errResp.Message, errResp.Meta, nil)
+	return types.NewSDKError(statusCode, errResp.ErrorCode, errResp.Message, redact.Map(errResp.Meta), nil)
 }
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Zillaforge/cloud-sdk/internal/backoff"
 	"github.com/Zillaforge/cloud-sdk/internal/types"
 )
 
@@ -129,6 +130,12 @@ func TestClient_Do_HTTPError(t *testing.T) {
 			expectErrorCode: 1001,
 			expectMessage:   "Invalid request",
 		},
+		{
+			name:          "error field response",
+			statusCode:    400,
+			responseBody:  ErrorResponse{Error: "Quota exceeded"},
+			expectMessage: "Quota exceeded",
+		},
 		{
 			name:            "unstructured error response",
 			statusCode:      500,
@@ -246,3 +253,280 @@ func TestClient_Do_NoRetryForPost(t *testing.T) {
 		t.Errorf("expected 1 attempt (no retry for POST), got %d", attemptCount)
 	}
 }
+
+func TestClient_Do_EmptyStaticToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not be sent without a token")
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "", &http.Client{Timeout: 5 * time.Second}, nil)
+	err := client.Do(context.Background(), &Request{Method: "GET", Path: "/test"}, nil)
+	var sdkErr *types.SDKError
+	if !errors.As(err, &sdkErr) || sdkErr.Meta["category"] != "auth" {
+		t.Errorf("expected an auth error for an empty token, got %v", err)
+	}
+}
+
+// refreshingTokenSource hands out a new token after each invalidation.
+type refreshingTokenSource struct {
+	generation  int
+	invalidated int
+	rejected    string
+}
+
+func (s *refreshingTokenSource) Token(_ context.Context) (string, error) {
+	if s.generation == 0 {
+		return "stale-token", nil
+	}
+	return "fresh-token", nil
+}
+
+func (s *refreshingTokenSource) Invalidate(rejected string) {
+	s.rejected = rejected
+	s.invalidated++
+	s.generation++
+}
+
+func TestClient_Do_TokenSourceRefreshOn401(t *testing.T) {
+	attemptCount := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attemptCount++
+		if r.Header.Get("Authorization") != "Bearer fresh-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	ts := &refreshingTokenSource{}
+	client := NewClient(server.URL, "", &http.Client{Timeout: 5 * time.Second}, nil, WithTokenSource(ts))
+
+	var result map[string]string
+	err := client.Do(context.Background(), &Request{
+		Method: "POST",
+		Path:   "/test",
+	}, &result)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attemptCount != 2 {
+		t.Errorf("expected 2 attempts, got %d", attemptCount)
+	}
+	if ts.invalidated != 1 || ts.rejected != "stale-token" {
+		t.Errorf("expected stale-token to be invalidated once, got %d invalidations of %q", ts.invalidated, ts.rejected)
+	}
+}
+
+func TestClient_Do_TokenSourceRefreshOnExpiredTokenCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") != "Bearer fresh-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(ErrorResponse{ErrorCode: types.ErrorCodeForbidden, Message: "Forbidden"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+
+	ts := &refreshingTokenSource{}
+	client := NewClient(server.URL, "", &http.Client{Timeout: 5 * time.Second}, nil, WithTokenSource(ts))
+
+	if err := client.Do(context.Background(), &Request{Method: "GET", Path: "/test"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ts.invalidated != 1 {
+		t.Errorf("expected token to be invalidated once, got %d", ts.invalidated)
+	}
+}
+
+func TestClient_Do_PermissionDeniedNoReplay(t *testing.T) {
+	attemptCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attemptCount++
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	ts := &refreshingTokenSource{}
+	client := NewClient(server.URL, "", &http.Client{Timeout: 5 * time.Second}, nil, WithTokenSource(ts))
+
+	if err := client.Do(context.Background(), &Request{Method: "GET", Path: "/test"}, nil); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if attemptCount != 1 || ts.invalidated != 0 {
+		t.Errorf("expected a plain 403 to fail without refresh, got %d attempts and %d invalidations", attemptCount, ts.invalidated)
+	}
+}
+
+func TestClient_Do_TokenSourceReplaysOnlyOnce(t *testing.T) {
+	attemptCount := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attemptCount++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	ts := &refreshingTokenSource{}
+	client := NewClient(server.URL, "", &http.Client{Timeout: 5 * time.Second}, nil, WithTokenSource(ts))
+
+	err := client.Do(context.Background(), &Request{
+		Method: "GET",
+		Path:   "/test",
+	}, nil)
+
+	var sdkErr *types.SDKError
+	if !errors.As(err, &sdkErr) || sdkErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 SDKError, got %v", err)
+	}
+	if attemptCount != 2 {
+		t.Errorf("expected 2 attempts, got %d", attemptCount)
+	}
+}
+
+func TestClient_Do_StaticTokenNoReplay(t *testing.T) {
+	attemptCount := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attemptCount++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil)
+
+	err := client.Do(context.Background(), &Request{
+		Method: "GET",
+		Path:   "/test",
+	}, nil)
+
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if attemptCount != 1 {
+		t.Errorf("expected 1 attempt for static token, got %d", attemptCount)
+	}
+}
+
+func TestClient_Do_TokenSourceError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("request should not be sent when token cannot be obtained")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ts := tokenSourceFunc(func(_ context.Context) (string, error) {
+		return "", errors.New("identity provider unavailable")
+	})
+	client := NewClient(server.URL, "", &http.Client{Timeout: 5 * time.Second}, nil, WithTokenSource(ts))
+
+	err := client.Do(context.Background(), &Request{
+		Method: "GET",
+		Path:   "/test",
+	}, nil)
+
+	var sdkErr *types.SDKError
+	if !errors.As(err, &sdkErr) {
+		t.Fatalf("expected SDKError, got %T", err)
+	}
+	if sdkErr.Meta["category"] != "auth" {
+		t.Errorf("expected auth error category, got %v", sdkErr.Meta["category"])
+	}
+}
+
+type tokenSourceFunc func(ctx context.Context) (string, error)
+
+func (f tokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+func TestClient_Do_RetryPolicy(t *testing.T) {
+	fastPolicy := func(maxAttempts int) *backoff.RetryPolicy {
+		return &backoff.RetryPolicy{
+			MaxAttempts:     maxAttempts,
+			InitialInterval: time.Millisecond,
+			MaxInterval:     time.Millisecond,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		clientPolicy   *backoff.RetryPolicy
+		callPolicy     *backoff.RetryPolicy
+		method         string
+		status         int
+		expectAttempts int
+	}{
+		{
+			name:           "client policy limits attempts",
+			clientPolicy:   fastPolicy(2),
+			method:         "GET",
+			status:         http.StatusServiceUnavailable,
+			expectAttempts: 2,
+		},
+		{
+			name:           "per-call policy overrides client policy",
+			clientPolicy:   fastPolicy(5),
+			callPolicy:     backoff.NoRetryPolicy(),
+			method:         "GET",
+			status:         http.StatusServiceUnavailable,
+			expectAttempts: 1,
+		},
+		{
+			name: "custom retryable status codes",
+			clientPolicy: &backoff.RetryPolicy{
+				MaxAttempts:          3,
+				InitialInterval:      time.Millisecond,
+				RetryableStatusCodes: []int{http.StatusInternalServerError},
+			},
+			method:         "GET",
+			status:         http.StatusInternalServerError,
+			expectAttempts: 3,
+		},
+		{
+			name: "custom predicate allows POST",
+			clientPolicy: &backoff.RetryPolicy{
+				MaxAttempts:     3,
+				InitialInterval: time.Millisecond,
+				ShouldRetry: func(req *http.Request, resp *http.Response, _ error) bool {
+					return req != nil && resp != nil && resp.StatusCode == http.StatusConflict
+				},
+			},
+			method:         "POST",
+			status:         http.StatusConflict,
+			expectAttempts: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attemptCount := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				attemptCount++
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil, WithRetryPolicy(tt.clientPolicy))
+
+			ctx := context.Background()
+			if tt.callPolicy != nil {
+				ctx = WithCallOptions(ctx, WithCallRetryPolicy(tt.callPolicy))
+			}
+
+			err := client.Do(ctx, &Request{Method: tt.method, Path: "/test"}, nil)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if attemptCount != tt.expectAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.expectAttempts, attemptCount)
+			}
+		})
+	}
+}
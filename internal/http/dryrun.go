@@ -0,0 +1,59 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/Zillaforge/cloud-sdk/redact"
+)
+
+// ErrDryRun is returned by calls whose mutating request was recorded by a dry run
+// instead of being sent.
+var ErrDryRun = errors.New("dry run: request not sent")
+
+// DryRun collects the requests a call would have sent. Mutating requests (POST,
+// PUT, PATCH and DELETE) are recorded and fail with ErrDryRun; read-only requests
+// are still sent, so lookups made by the call behave normally.
+// It is safe for concurrent use.
+type DryRun struct {
+	mu       sync.Mutex
+	requests []DryRunRequest
+}
+
+// DryRunRequest is a request recorded by a dry run. Secrets in the body are
+// masked and the Authorization header is not included.
+type DryRunRequest struct {
+	Method    string
+	Path      string
+	Operation string
+	Header    http.Header
+	Body      []byte
+}
+
+// Requests returns a copy of the recorded requests, in order.
+func (d *DryRun) Requests() []DryRunRequest {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]DryRunRequest(nil), d.requests...)
+}
+
+// record stores req, reporting an encoding failure of its body.
+func (d *DryRun) record(req *Request) error {
+	recorded := DryRunRequest{Method: req.Method, Path: req.Path, Operation: req.Operation, Header: make(http.Header)}
+	for key, value := range req.Headers {
+		recorded.Header.Set(key, value)
+	}
+	if req.Body != nil {
+		body, err := redact.Marshal(req.Body)
+		if err != nil {
+			return err
+		}
+		recorded.Body = body
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.requests = append(d.requests, recorded)
+	return nil
+}
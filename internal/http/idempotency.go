@@ -0,0 +1,50 @@
+package http
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// IdempotencyKeyHeader is the header carrying the idempotency key of a mutating request.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// NewIdempotencyKey returns a random UUIDv4 suitable for the Idempotency-Key header.
+func NewIdempotencyKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand does not fail on supported platforms
+		panic(fmt.Sprintf("failed to generate idempotency key: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// isMutatingMethod reports whether method changes server state.
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// withIdempotencyKey attaches an idempotency key to mutating requests.
+// A key already present on the request wins, then one supplied through call options;
+// otherwise a key is generated when the client has automatic keys enabled.
+// The same key is reused for every retry of the request.
+func (c *Client) withIdempotencyKey(req *Request, callOpts *CallOptions) *Request {
+	if !isMutatingMethod(req.Method) || req.header(IdempotencyKeyHeader) != "" {
+		return req
+	}
+
+	key := callOpts.IdempotencyKey
+	if key == "" && c.autoIdempotencyKeys {
+		key = NewIdempotencyKey()
+	}
+	if key == "" {
+		return req
+	}
+	return req.WithHeader(IdempotencyKeyHeader, key)
+}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/internal/backoff"
+)
+
+func TestNewIdempotencyKey(t *testing.T) {
+	uuidPattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	a, b := NewIdempotencyKey(), NewIdempotencyKey()
+	if !uuidPattern.MatchString(a) {
+		t.Errorf("expected UUIDv4, got %q", a)
+	}
+	if a == b {
+		t.Error("expected unique keys")
+	}
+}
+
+func TestClient_Do_IdempotencyKey(t *testing.T) {
+	fastPolicy := &backoff.RetryPolicy{MaxAttempts: 3, InitialInterval: time.Millisecond}
+
+	tests := []struct {
+		name           string
+		auto           bool
+		callKey        string
+		method         string
+		expectKey      string
+		expectAnyKey   bool
+		expectAttempts int
+	}{
+		{
+			name:           "no key by default, POST not retried",
+			method:         "POST",
+			expectAttempts: 1,
+		},
+		{
+			name:           "generated key makes POST retryable",
+			auto:           true,
+			method:         "POST",
+			expectAnyKey:   true,
+			expectAttempts: 3,
+		},
+		{
+			name:           "caller key wins over generated key",
+			auto:           true,
+			callKey:        "caller-key",
+			method:         "DELETE",
+			expectKey:      "caller-key",
+			expectAnyKey:   true,
+			expectAttempts: 3,
+		},
+		{
+			name:           "GET never carries a key",
+			auto:           true,
+			method:         "GET",
+			expectAttempts: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var keys []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil,
+				WithRetryPolicy(fastPolicy), WithIdempotencyKeys(tt.auto))
+
+			ctx := context.Background()
+			if tt.callKey != "" {
+				ctx = WithCallOptions(ctx, WithCallIdempotencyKey(tt.callKey))
+			}
+
+			if err := client.Do(ctx, &Request{Method: tt.method, Path: "/test"}, nil); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if len(keys) != tt.expectAttempts {
+				t.Fatalf("expected %d attempts, got %d", tt.expectAttempts, len(keys))
+			}
+			for _, key := range keys {
+				if key != keys[0] {
+					t.Errorf("expected the same key on every retry, got %v", keys)
+				}
+			}
+			if tt.expectAnyKey && keys[0] == "" {
+				t.Error("expected an idempotency key")
+			}
+			if !tt.expectAnyKey && keys[0] != "" {
+				t.Errorf("expected no idempotency key, got %q", keys[0])
+			}
+			if tt.expectKey != "" && keys[0] != tt.expectKey {
+				t.Errorf("expected key %q, got %q", tt.expectKey, keys[0])
+			}
+		})
+	}
+}
+
+func TestClient_Do_IdempotencyKeyDoesNotMutateRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil, WithIdempotencyKeys(true))
+
+	req := &Request{Method: "POST", Path: "/test", Headers: map[string]string{"X-Namespace": "public"}}
+	if err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := req.Headers[IdempotencyKeyHeader]; ok {
+		t.Error("expected caller's request headers to be left unchanged")
+	}
+}
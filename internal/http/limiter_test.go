@@ -0,0 +1,105 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/internal/types"
+)
+
+func TestLimiter_Reserve(t *testing.T) {
+	l := NewLimiter(10, 2)
+	now := l.last
+
+	if wait := l.reserve(now); wait != 0 {
+		t.Errorf("expected first burst token immediately, got %v", wait)
+	}
+	if wait := l.reserve(now); wait != 0 {
+		t.Errorf("expected second burst token immediately, got %v", wait)
+	}
+	if wait := l.reserve(now); wait != 100*time.Millisecond {
+		t.Errorf("expected 100ms wait once burst is spent, got %v", wait)
+	}
+
+	// Refill after one second caps at burst
+	later := now.Add(time.Second)
+	l.reserve(later)
+	l.reserve(later)
+	if wait := l.reserve(later); wait <= 0 {
+		t.Errorf("expected refill to be capped at burst, got wait %v", wait)
+	}
+}
+
+func TestLimiter_WaitCanceled(t *testing.T) {
+	l := NewLimiter(1, 1)
+	_ = l.Wait(context.Background()) // Drain the bucket
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if l.tokens < -0.01 {
+		t.Errorf("expected abandoned reservation to be returned, tokens = %v", l.tokens)
+	}
+}
+
+func TestLimiter_Unlimited(t *testing.T) {
+	l := NewLimiter(0, 1)
+	for i := 0; i < 100; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestClient_Do_Limiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	limiter := NewLimiter(20, 1)
+	a := NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil, WithLimiter(limiter))
+	b := NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil, WithLimiter(limiter))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := a.Do(context.Background(), &Request{Method: "GET", Path: "/a"}, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := b.Do(context.Background(), &Request{Method: "GET", Path: "/b"}, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// 6 requests at 20 rps with burst 1 take at least 5 intervals of 50ms
+	if elapsed := time.Since(start); elapsed < 240*time.Millisecond {
+		t.Errorf("expected shared limiter to pace requests, took %v", elapsed)
+	}
+}
+
+func TestClient_Do_LimiterDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	limiter := NewLimiter(0.1, 1)
+	client := NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil, WithLimiter(limiter))
+	_ = client.Do(context.Background(), &Request{Method: "GET", Path: "/test"}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := client.Do(ctx, &Request{Method: "GET", Path: "/test"}, nil)
+	var sdkErr *types.SDKError
+	if !errors.As(err, &sdkErr) || sdkErr.Meta["category"] != "timeout" {
+		t.Errorf("expected timeout SDKError, got %v", err)
+	}
+}
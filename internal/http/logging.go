@@ -0,0 +1,94 @@
+package http
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/redact"
+)
+
+// LogLevel selects the logger method used for request logs.
+type LogLevel int
+
+const (
+	// LogLevelDebug logs through Logger.Debug.
+	LogLevelDebug LogLevel = iota
+
+	// LogLevelInfo logs through Logger.Info.
+	LogLevelInfo
+
+	// LogLevelError logs through Logger.Error.
+	LogLevelError
+)
+
+// RequestLogging configures the structured log lines written for every attempt.
+type RequestLogging struct {
+	// Level is the level both the request and the response are logged at
+	Level LogLevel
+
+	// MaxBodyBytes caps the request and response bodies included in the logs.
+	// Zero omits bodies; longer bodies are truncated.
+	MaxBodyBytes int
+}
+
+// WithRequestLogging logs every attempt's request and response through the
+// client's logger. It has no effect without a logger.
+func WithRequestLogging(cfg RequestLogging) Option {
+	return func(c *Client) {
+		c.requestLogging = &cfg
+	}
+}
+
+// log writes msg and keysAndValues at the configured level.
+func (l *RequestLogging) log(c *Client, msg string, keysAndValues ...interface{}) {
+	switch l.Level {
+	case LogLevelInfo:
+		c.logger.Info(msg, keysAndValues...)
+	case LogLevelError:
+		c.logger.Error(msg, keysAndValues...)
+	default:
+		c.logger.Debug(msg, keysAndValues...)
+	}
+}
+
+// logRequest logs an attempt before it is handed to the middleware chain.
+// Secrets in the body are masked before it is logged.
+func (c *Client) logRequest(req *Request) {
+	kv := []interface{}{"method", req.Method, "path", req.Path, "attempt", req.Attempt}
+	if req.Operation != "" {
+		kv = append(kv, "operation", req.Operation)
+	}
+	if c.requestLogging.MaxBodyBytes > 0 && req.Body != nil {
+		if body, err := redact.Marshal(req.Body); err == nil {
+			kv = append(kv, "body", truncateBody(body, c.requestLogging.MaxBodyBytes))
+		}
+	}
+	c.requestLogging.log(c, "sending request", kv...)
+}
+
+// logResponse logs the outcome of an attempt.
+func (c *Client) logResponse(req *Request, resp *Response, err error, duration time.Duration) {
+	kv := []interface{}{"method", req.Method, "path", req.Path, "attempt", req.Attempt, "duration", duration}
+	if resp != nil {
+		kv = append(kv, "status", resp.StatusCode)
+		if id := requestID(resp.Header); id != "" {
+			kv = append(kv, "request_id", id)
+		}
+		if c.requestLogging.MaxBodyBytes > 0 && len(resp.Body) > 0 {
+			kv = append(kv, "body", truncateBody(redact.JSON(resp.Body), c.requestLogging.MaxBodyBytes))
+		}
+	}
+	if err != nil {
+		kv = append(kv, "error", err.Error())
+	}
+	c.requestLogging.log(c, "received response", kv...)
+}
+
+// truncateBody returns body as a string of at most max bytes, noting the
+// original size when it was cut.
+func truncateBody(body []byte, max int) string {
+	if len(body) <= max {
+		return string(body)
+	}
+	return fmt.Sprintf("%s...(truncated, %d bytes)", body[:max], len(body))
+}
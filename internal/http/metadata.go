@@ -0,0 +1,113 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/internal/types"
+)
+
+// ResponseMetadata describes the HTTP exchange behind an SDK call.
+type ResponseMetadata struct {
+	// Method and Path identify the request, with Path relative to the service base URL
+	Method string
+	Path   string
+
+	// StatusCode is the HTTP status of the last attempt (0 if no response was received)
+	StatusCode int
+
+	// RequestID is the server-assigned request ID of the last attempt, if any
+	RequestID string
+
+	// Attempts is the number of attempts made, including retries
+	Attempts int
+
+	// Header holds the response headers of the last attempt
+	Header http.Header
+
+	// Duration is the time spent on the call, including retry waits
+	Duration time.Duration
+}
+
+// requestIDHeaders are the response headers checked, in order, for the request ID.
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "Request-Id"}
+
+// DefaultErrorHeaders are the response headers copied onto SDKError.Header.
+var DefaultErrorHeaders = []string{
+	"X-Request-Id", "X-Correlation-Id", "Request-Id", "Date", "Retry-After",
+	"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset",
+}
+
+// WithErrorHeaders adds response headers to copy onto SDKError.Header,
+// in addition to DefaultErrorHeaders.
+func WithErrorHeaders(names ...string) Option {
+	return func(c *Client) {
+		c.errorHeaders = append(c.errorHeaders, names...)
+	}
+}
+
+// requestID returns the first request ID header present in h.
+func requestID(h http.Header) string {
+	for _, name := range requestIDHeaders {
+		if v := h.Get(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// observe updates md with the outcome of an attempt. A nil resp means no
+// response was received, which clears the response fields.
+func (md *ResponseMetadata) observe(attempts int, resp *Response) {
+	md.Attempts = attempts
+	md.StatusCode, md.RequestID, md.Header = 0, "", nil
+	if resp != nil {
+		md.StatusCode = resp.StatusCode
+		md.RequestID = requestID(resp.Header)
+		md.Header = resp.Header
+	}
+}
+
+// finish publishes md to the caller's ResponseMetadata, if requested, and
+// returns err with the request details attached. An SDKError returned by an
+// attempt may be shared, for instance by a middleware or the circuit breaker, so
+// it is copied rather than annotated in place. Errors wrapping an SDKError are
+// returned unchanged.
+func (c *Client) finish(md *ResponseMetadata, opts *CallOptions, start time.Time, err error) error {
+	md.Duration = time.Since(start)
+	if opts.ResponseMetadata != nil {
+		*opts.ResponseMetadata = *md
+		opts.ResponseMetadata.Header = md.Header.Clone()
+	}
+
+	sdkErr, ok := err.(*types.SDKError)
+	if !ok || sdkErr == nil {
+		return err
+	}
+	annotated := *sdkErr
+	annotated.Method = md.Method
+	annotated.Path = md.Path
+	annotated.Attempts = md.Attempts
+	annotated.RequestID = md.RequestID
+	annotated.Header = c.selectErrorHeaders(md.Header)
+	return &annotated
+}
+
+// selectErrorHeaders returns the headers of h that are copied onto errors.
+func (c *Client) selectErrorHeaders(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	selected := make(http.Header)
+	for _, names := range [][]string{DefaultErrorHeaders, c.errorHeaders} {
+		for _, name := range names {
+			if values := h.Values(name); len(values) > 0 {
+				selected[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+	return selected
+}
@@ -0,0 +1,182 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/internal/backoff"
+	"github.com/Zillaforge/cloud-sdk/internal/types"
+)
+
+func TestClient_Do_ResponseMetadata(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		w.Header().Set("X-Request-Id", fmt.Sprintf("req-%d", n))
+		w.Header().Set("X-Custom", "value")
+		if n < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token", &http.Client{Timeout: 5 * time.Second}, nil,
+		WithRetryPolicy(&backoff.RetryPolicy{MaxAttempts: 3, InitialInterval: time.Millisecond}))
+
+	var md ResponseMetadata
+	ctx := WithCallOptions(context.Background(), WithCallResponseMetadata(&md))
+	if err := client.Do(ctx, &Request{Method: "GET", Path: "/servers?detail=true"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if md.Method != "GET" || md.Path != "/servers?detail=true" {
+		t.Errorf("unexpected method/path: %s %s", md.Method, md.Path)
+	}
+	if md.StatusCode != http.StatusOK || md.Attempts != 3 || md.RequestID != "req-3" {
+		t.Errorf("expected the last attempt (200, 3 attempts, req-3), got %+v", md)
+	}
+	if md.Header.Get("X-Custom") != "value" {
+		t.Errorf("expected all response headers, got %v", md.Header)
+	}
+	if md.Duration <= 0 {
+		t.Error("expected a positive duration")
+	}
+}
+
+func TestClient_Do_ErrorMetadata(t *testing.T) {
+	tests := []struct {
+		name          string
+		handler       http.HandlerFunc
+		opts          []Option
+		wantStatus    int
+		wantRequestID string
+		wantAttempts  int
+		wantHeaders   []string
+		wantNoHeaders []string
+	}{
+		{
+			name: "API error carries request ID and selected headers",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("X-Request-Id", "abc-123")
+				w.Header().Set("Set-Cookie", "session=secret")
+				w.Header().Set("X-Trace", "t-1")
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"errorCode":1004,"message":"not found"}`))
+			},
+			wantStatus:    http.StatusNotFound,
+			wantRequestID: "abc-123",
+			wantAttempts:  1,
+			wantHeaders:   []string{"X-Request-Id"},
+			wantNoHeaders: []string{"Set-Cookie", "X-Trace"},
+		},
+		{
+			name: "extra error headers",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("X-Correlation-Id", "corr-9")
+				w.Header().Set("X-Trace", "t-1")
+				w.WriteHeader(http.StatusBadRequest)
+			},
+			opts:          []Option{WithErrorHeaders("X-Trace")},
+			wantStatus:    http.StatusBadRequest,
+			wantRequestID: "corr-9",
+			wantAttempts:  1,
+			wantHeaders:   []string{"X-Correlation-Id", "X-Trace"},
+		},
+		{
+			name: "retried errors count every attempt",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			opts:         []Option{WithRetryPolicy(&backoff.RetryPolicy{MaxAttempts: 2, InitialInterval: time.Millisecond})},
+			wantStatus:   http.StatusTooManyRequests,
+			wantAttempts: 2,
+			wantHeaders:  []string{"Retry-After"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			client := NewClient(server.URL, "token", &http.Client{Timeout: 5 * time.Second}, nil, tt.opts...)
+			err := client.Do(context.Background(), &Request{Method: "GET", Path: "/servers/svr-1"}, nil)
+
+			var sdkErr *types.SDKError
+			if !errors.As(fmt.Errorf("failed to get server: %w", err), &sdkErr) {
+				t.Fatalf("expected SDKError, got %v", err)
+			}
+			if sdkErr.StatusCode != tt.wantStatus || sdkErr.Attempts != tt.wantAttempts || sdkErr.RequestID != tt.wantRequestID {
+				t.Errorf("unexpected error details: status %d, attempts %d, request ID %q", sdkErr.StatusCode, sdkErr.Attempts, sdkErr.RequestID)
+			}
+			if sdkErr.Method != "GET" || sdkErr.Path != "/servers/svr-1" {
+				t.Errorf("unexpected method/path: %s %s", sdkErr.Method, sdkErr.Path)
+			}
+			for _, name := range tt.wantHeaders {
+				if sdkErr.Header.Get(name) == "" {
+					t.Errorf("expected header %s on the error, got %v", name, sdkErr.Header)
+				}
+			}
+			for _, name := range tt.wantNoHeaders {
+				if sdkErr.Header.Get(name) != "" {
+					t.Errorf("expected header %s to be left off the error", name)
+				}
+			}
+			if tt.wantRequestID != "" && !strings.Contains(sdkErr.Error(), tt.wantRequestID) {
+				t.Errorf("expected the request ID in the message, got %q", sdkErr.Error())
+			}
+		})
+	}
+}
+
+func TestClient_Do_NetworkErrorMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	server.Close()
+
+	client := NewClient(server.URL, "token", &http.Client{Timeout: 5 * time.Second}, nil)
+	var md ResponseMetadata
+	ctx := WithCallOptions(context.Background(), WithCallResponseMetadata(&md))
+	err := client.Do(ctx, &Request{Method: "DELETE", Path: "/servers/svr-1"}, nil)
+
+	var sdkErr *types.SDKError
+	if !errors.As(err, &sdkErr) {
+		t.Fatalf("expected SDKError, got %v", err)
+	}
+	if sdkErr.Attempts != 1 || sdkErr.Method != "DELETE" || sdkErr.RequestID != "" || sdkErr.Header != nil {
+		t.Errorf("unexpected error details: %+v", sdkErr)
+	}
+	if md.StatusCode != 0 || md.Attempts != 1 {
+		t.Errorf("expected metadata for the failed call, got %+v", md)
+	}
+}
+
+func TestClient_Do_SharedErrorNotAnnotated(t *testing.T) {
+	shared := types.NewSDKError(http.StatusBadRequest, 0, "rejected by policy", nil, nil)
+	reject := func(Handler) Handler {
+		return func(context.Context, *Request) (*Response, error) {
+			return nil, shared
+		}
+	}
+	client := NewClient("http://example.invalid", "token", &http.Client{}, nil, WithMiddleware(reject))
+
+	for _, path := range []string{"/servers", "/volumes"} {
+		err := client.Do(context.Background(), &Request{Method: "GET", Path: path}, nil)
+		var sdkErr *types.SDKError
+		if !errors.As(err, &sdkErr) || sdkErr.Path != path || sdkErr.Message != "rejected by policy" {
+			t.Errorf("expected an error annotated with %s, got %+v", path, err)
+		}
+	}
+	if shared.Path != "" || shared.Attempts != 0 {
+		t.Errorf("expected the shared error to be left untouched, got %+v", shared)
+	}
+}
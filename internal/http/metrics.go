@@ -0,0 +1,63 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/internal/types"
+	"github.com/Zillaforge/cloud-sdk/metrics"
+)
+
+// WithMetrics reports every attempt to recorder, labelled with service.
+func WithMetrics(recorder metrics.Recorder, service string) Option {
+	return func(c *Client) {
+		c.metrics = recorder
+		c.service = service
+	}
+}
+
+// recordAttempt reports a finished attempt to the metrics recorder.
+func (c *Client) recordAttempt(req *Request, attempt int, resp *Response, err error, duration time.Duration) {
+	o := metrics.Observation{
+		Service:   c.service,
+		Operation: req.Operation,
+		Method:    req.Method,
+		Attempt:   attempt,
+		Duration:  duration,
+	}
+	if resp != nil {
+		o.StatusCode = resp.StatusCode
+	}
+	if err != nil {
+		o.ErrorCategory = errorCategory(err)
+		if o.StatusCode == 0 {
+			var sdkErr *types.SDKError
+			if errors.As(err, &sdkErr) {
+				o.StatusCode = sdkErr.StatusCode
+			}
+		}
+	}
+	c.metrics.RecordAttempt(o)
+}
+
+// errorCategory classifies err for metrics.
+func errorCategory(err error) string {
+	var sdkErr *types.SDKError
+	if !errors.As(err, &sdkErr) {
+		return "unknown"
+	}
+	if category, ok := sdkErr.Meta["category"].(string); ok {
+		return category
+	}
+	switch {
+	case sdkErr.StatusCode == http.StatusTooManyRequests:
+		return metrics.CategoryThrottled
+	case sdkErr.StatusCode >= 500:
+		return metrics.CategoryServerError
+	case sdkErr.StatusCode >= 400:
+		return metrics.CategoryClientError
+	default:
+		return "unknown"
+	}
+}
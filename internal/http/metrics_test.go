@@ -0,0 +1,81 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/internal/backoff"
+	"github.com/Zillaforge/cloud-sdk/internal/types"
+	"github.com/Zillaforge/cloud-sdk/metrics"
+)
+
+func TestClient_Do_Metrics(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var observed []metrics.Observation
+	recorder := metrics.RecorderFunc(func(o metrics.Observation) {
+		mu.Lock()
+		defer mu.Unlock()
+		observed = append(observed, o)
+	})
+
+	policy := &backoff.RetryPolicy{MaxAttempts: 2, InitialInterval: time.Millisecond}
+	client := NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil,
+		WithMetrics(recorder, "vps"), WithRetryPolicy(policy))
+
+	req := &Request{Method: "GET", Path: "/servers", Operation: "vps.servers.List"}
+	if err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(observed) != 2 {
+		t.Fatalf("expected 2 observations, got %d", len(observed))
+	}
+	first, second := observed[0], observed[1]
+	if first.Service != "vps" || first.Operation != "vps.servers.List" || first.Method != "GET" {
+		t.Errorf("unexpected labels: %+v", first)
+	}
+	if first.StatusCode != http.StatusTooManyRequests || first.ErrorCategory != metrics.CategoryThrottled || first.Attempt != 1 {
+		t.Errorf("unexpected first attempt: %+v", first)
+	}
+	if second.StatusCode != http.StatusOK || second.ErrorCategory != "" || second.Attempt != 2 {
+		t.Errorf("unexpected second attempt: %+v", second)
+	}
+}
+
+func TestErrorCategory(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "throttled", err: types.NewHTTPError(http.StatusTooManyRequests, ""), want: metrics.CategoryThrottled},
+		{name: "server error", err: types.NewHTTPError(http.StatusBadGateway, ""), want: metrics.CategoryServerError},
+		{name: "client error", err: types.NewSDKError(http.StatusNotFound, 1004, "not found", nil, nil), want: metrics.CategoryClientError},
+		{name: "network", err: types.NewNetworkError("reset", nil), want: "network"},
+		{name: "timeout", err: types.NewTimeoutError(context.DeadlineExceeded), want: "timeout"},
+		{name: "not an SDKError", err: context.Canceled, want: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorCategory(tt.err); got != tt.want {
+				t.Errorf("errorCategory() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"context"
+	"net/http"
+)
+
+// Response is the outcome of a single request attempt as seen by middleware.
+// Body holds the complete response body; it is decoded into the caller's result
+// only after the middleware chain returns.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Handler executes a single request attempt. Non-2xx responses are reported as
+// an *types.SDKError together with the Response they were parsed from; transport
+// failures return a nil Response.
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps a Handler to observe or alter each request attempt.
+// Middleware runs once per attempt, so retries pass through it again with
+// req.Attempt incremented. It must not modify req in place; use WithHeader
+// or copy the Request instead.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware appends middleware to the client's chain.
+// The first middleware is the outermost and sees each attempt first.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
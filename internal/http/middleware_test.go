@@ -0,0 +1,124 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/internal/backoff"
+	"github.com/Zillaforge/cloud-sdk/internal/types"
+)
+
+func TestClient_Do_MiddlewareOrder(t *testing.T) {
+	var gotHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Trace")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"ok"}`))
+	}))
+	defer server.Close()
+
+	var order []string
+	tag := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				order = append(order, name+">")
+				resp, err := next(ctx, req.WithHeader("X-Trace", req.header("X-Trace")+name))
+				order = append(order, "<"+name)
+				return resp, err
+			}
+		}
+	}
+
+	client := NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil,
+		WithMiddleware(tag("a")), WithMiddleware(tag("b")))
+
+	req := &Request{Method: "GET", Path: "/test"}
+	var result struct{ Name string }
+	if err := client.Do(context.Background(), req, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Name != "ok" {
+		t.Errorf("expected decoded result, got %+v", result)
+	}
+	if gotHeader != "ab" {
+		t.Errorf("expected header added by both middleware, got %q", gotHeader)
+	}
+	if want := []string{"a>", "b>", "<b", "<a"}; len(order) != 4 || order[0] != want[0] || order[1] != want[1] || order[2] != want[2] || order[3] != want[3] {
+		t.Errorf("expected order %v, got %v", want, order)
+	}
+	if req.Headers != nil {
+		t.Error("expected caller's request to be left unchanged")
+	}
+}
+
+func TestClient_Do_MiddlewareSeesAttemptsAndErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"errorCode":2001,"message":"backend down"}`))
+	}))
+	defer server.Close()
+
+	var attempts []int
+	var codes []int
+	observe := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			resp, err := next(ctx, req)
+			attempts = append(attempts, req.Attempt)
+			var sdkErr *types.SDKError
+			if errors.As(err, &sdkErr) && resp != nil && resp.StatusCode == sdkErr.StatusCode {
+				codes = append(codes, sdkErr.ErrorCode)
+			}
+			return resp, err
+		}
+	}
+
+	policy := &backoff.RetryPolicy{MaxAttempts: 3, InitialInterval: time.Millisecond}
+	client := NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil,
+		WithMiddleware(observe), WithRetryPolicy(policy))
+
+	if err := client.Do(context.Background(), &Request{Method: "GET", Path: "/test"}, nil); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if len(attempts) != 3 || attempts[0] != 1 || attempts[2] != 3 {
+		t.Errorf("expected attempts 1..3, got %v", attempts)
+	}
+	if len(codes) != 3 || codes[0] != 2001 {
+		t.Errorf("expected structured error codes, got %v", codes)
+	}
+}
+
+func TestClient_Do_MiddlewareShortCircuit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("transport should not be called")
+	}))
+	defer server.Close()
+
+	calls := 0
+	fault := func(_ Handler) Handler {
+		return func(_ context.Context, _ *Request) (*Response, error) {
+			calls++
+			if calls == 1 {
+				return &Response{StatusCode: http.StatusTooManyRequests}, types.NewHTTPError(http.StatusTooManyRequests, "")
+			}
+			return &Response{StatusCode: http.StatusOK, Body: []byte(`{"name":"injected"}`)}, nil
+		}
+	}
+
+	policy := &backoff.RetryPolicy{MaxAttempts: 2, InitialInterval: time.Millisecond}
+	client := NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil,
+		WithMiddleware(fault), WithRetryPolicy(policy))
+
+	var result struct{ Name string }
+	if err := client.Do(context.Background(), &Request{Method: "GET", Path: "/test"}, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 || result.Name != "injected" {
+		t.Errorf("expected injected response after retry, calls=%d result=%+v", calls, result)
+	}
+}
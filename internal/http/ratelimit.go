@@ -0,0 +1,85 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimitInfo describes the server-reported request quota from a response.
+// Fields the server did not report are left at -1 (counts) or zero (Reset).
+type RateLimitInfo struct {
+	// Method and Path identify the request that produced the response
+	Method string
+	Path   string
+
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+
+	// Limit is the request quota for the current window
+	Limit int
+
+	// Remaining is the number of requests left in the current window
+	Remaining int
+
+	// Reset is when the current window resets
+	Reset time.Time
+}
+
+// RateLimitCallback receives quota information from every response that reports it.
+type RateLimitCallback func(info RateLimitInfo)
+
+// WithRateLimitCallback registers a callback for server-reported rate-limit headers.
+func WithRateLimitCallback(fn RateLimitCallback) Option {
+	return func(c *Client) {
+		c.rateLimitCallback = fn
+	}
+}
+
+// resetEpochThreshold separates reset values given as Unix timestamps from
+// values given as seconds until reset.
+const resetEpochThreshold = 1_000_000_000
+
+// parseRateLimit extracts X-RateLimit-* (or RateLimit-*) headers from resp.
+// It returns false when the response carries no rate-limit headers.
+func parseRateLimit(req *Request, resp *http.Response, now time.Time) (RateLimitInfo, bool) {
+	info := RateLimitInfo{
+		Method:     req.Method,
+		Path:       req.Path,
+		StatusCode: resp.StatusCode,
+		Limit:      -1,
+		Remaining:  -1,
+	}
+	found := false
+
+	if v, ok := intHeader(resp.Header, "X-RateLimit-Limit", "RateLimit-Limit"); ok {
+		info.Limit = v
+		found = true
+	}
+	if v, ok := intHeader(resp.Header, "X-RateLimit-Remaining", "RateLimit-Remaining"); ok {
+		info.Remaining = v
+		found = true
+	}
+	if v, ok := intHeader(resp.Header, "X-RateLimit-Reset", "RateLimit-Reset"); ok {
+		if v >= resetEpochThreshold {
+			info.Reset = time.Unix(int64(v), 0)
+		} else {
+			info.Reset = now.Add(time.Duration(v) * time.Second)
+		}
+		found = true
+	}
+
+	return info, found
+}
+
+// intHeader returns the first of names present in h that parses as an integer.
+func intHeader(h http.Header, names ...string) (int, bool) {
+	for _, name := range names {
+		if raw := h.Get(name); raw != "" {
+			if v, err := strconv.Atoi(raw); err == nil {
+				return v, true
+			}
+		}
+	}
+	return 0, false
+}
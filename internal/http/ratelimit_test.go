@@ -0,0 +1,169 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/internal/backoff"
+	"github.com/Zillaforge/cloud-sdk/internal/types"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	req := &Request{Method: "GET", Path: "/servers"}
+
+	tests := []struct {
+		name      string
+		headers   map[string]string
+		wantFound bool
+		want      RateLimitInfo
+	}{
+		{
+			name:      "no headers",
+			wantFound: false,
+		},
+		{
+			name: "x-ratelimit with epoch reset",
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "100",
+				"X-RateLimit-Remaining": "7",
+				"X-RateLimit-Reset":     "1800000060",
+			},
+			wantFound: true,
+			want:      RateLimitInfo{Limit: 100, Remaining: 7, Reset: time.Unix(1_800_000_060, 0)},
+		},
+		{
+			name: "ietf names with delta reset",
+			headers: map[string]string{
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "30",
+			},
+			wantFound: true,
+			want:      RateLimitInfo{Limit: -1, Remaining: 0, Reset: now.Add(30 * time.Second)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+			for k, v := range tt.headers {
+				resp.Header.Set(k, v)
+			}
+
+			info, found := parseRateLimit(req, resp, now)
+			if found != tt.wantFound {
+				t.Fatalf("found = %v, want %v", found, tt.wantFound)
+			}
+			if !found {
+				return
+			}
+			if info.Limit != tt.want.Limit || info.Remaining != tt.want.Remaining || !info.Reset.Equal(tt.want.Reset) {
+				t.Errorf("got %+v, want %+v", info, tt.want)
+			}
+			if info.Method != "GET" || info.Path != "/servers" {
+				t.Errorf("expected request identity to be recorded, got %+v", info)
+			}
+		})
+	}
+}
+
+func TestClient_Do_RateLimitCallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "50")
+		w.Header().Set("X-RateLimit-Remaining", "49")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var got []RateLimitInfo
+	client := NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil,
+		WithRateLimitCallback(func(info RateLimitInfo) { got = append(got, info) }))
+
+	if err := client.Do(context.Background(), &Request{Method: "GET", Path: "/test"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].Limit != 50 || got[0].Remaining != 49 || got[0].StatusCode != http.StatusNoContent {
+		t.Errorf("unexpected callback invocations: %+v", got)
+	}
+}
+
+func TestClient_Do_RetryAfter(t *testing.T) {
+	var times []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		times = append(times, time.Now())
+		if len(times) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	policy := &backoff.RetryPolicy{MaxAttempts: 2, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond}
+	client := NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil, WithRetryPolicy(policy))
+
+	if err := client.Do(context.Background(), &Request{Method: "GET", Path: "/test"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(times) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(times))
+	}
+	if gap := times[1].Sub(times[0]); gap < 900*time.Millisecond {
+		t.Errorf("expected retry to honor Retry-After of 1s, waited %v", gap)
+	}
+}
+
+func TestClient_Do_RetriesExhaustedWithinDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := &backoff.RetryPolicy{MaxAttempts: 2, InitialInterval: time.Millisecond}
+	client := NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil, WithRetryPolicy(policy))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := client.Do(ctx, &Request{Method: "GET", Path: "/test"}, nil)
+	if err == nil || errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected exhausted retries not to report the deadline, got %v", err)
+	}
+}
+
+func TestClient_Do_RetryAfterBeyondDeadline(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	err := client.Do(ctx, &Request{Method: "GET", Path: "/test"}, nil)
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "Retry-After") {
+		t.Fatalf("expected the skipped retry to be reported against the deadline, got %v", err)
+	}
+	var sdkErr *types.SDKError
+	if !errors.As(err, &sdkErr) || sdkErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected the last response error to be kept, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected no retry past the deadline, got %d attempts", attempts)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected immediate failure, took %v", elapsed)
+	}
+}
@@ -0,0 +1,109 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/internal/types"
+	"github.com/Zillaforge/cloud-sdk/tracing"
+)
+
+// WithTracer enables tracing: a span per Do call named after Request.Operation,
+// with a child span per attempt whose context is propagated in the traceparent header.
+func WithTracer(t tracing.Tracer) Option {
+	return func(c *Client) {
+		c.tracer = t
+	}
+}
+
+type operationSpanKey struct{}
+
+// startOperation starts the span covering every attempt of req.
+func (c *Client) startOperation(ctx context.Context, req *Request) (context.Context, tracing.Span) {
+	name := req.Operation
+	if name == "" {
+		name = "HTTP " + req.Method
+	}
+	ctx, span := c.tracer.Start(ctx, name,
+		tracing.String(tracing.AttrOperation, name),
+		tracing.String(tracing.AttrHTTPMethod, req.Method),
+		tracing.String(tracing.AttrURLPath, req.Path),
+	)
+	return context.WithValue(ctx, operationSpanKey{}, span), span
+}
+
+// endOperation records the outcome of the operation on span and ends it.
+func endOperation(span tracing.Span, err error) {
+	if err != nil {
+		span.SetAttributes(errorAttributes(err)...)
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// traceRetry records a scheduled retry on the operation span in ctx, if any.
+func traceRetry(ctx context.Context, attempt int, wait time.Duration, err error) {
+	span, ok := ctx.Value(operationSpanKey{}).(tracing.Span)
+	if !ok {
+		return
+	}
+	attrs := append([]tracing.Attribute{
+		tracing.Int(tracing.AttrAttempt, attempt),
+		tracing.Int(tracing.AttrRetryBackoffMS, int(wait.Milliseconds())),
+	}, errorAttributes(err)...)
+	span.AddEvent("retry", attrs...)
+}
+
+// traceAttempt wraps next in a span per attempt and injects its context into the
+// request headers, through the tracer's own propagator when it has one.
+func (c *Client) traceAttempt(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		ctx, span := c.tracer.Start(ctx, "HTTP "+req.Method,
+			tracing.String(tracing.AttrHTTPMethod, req.Method),
+			tracing.String(tracing.AttrURLPath, req.Path),
+			tracing.Int(tracing.AttrAttempt, req.Attempt),
+		)
+		defer span.End()
+
+		if op, ok := ctx.Value(operationSpanKey{}).(tracing.Span); ok {
+			op.SetAttributes(tracing.Int(tracing.AttrAttempts, req.Attempt))
+		}
+		if propagator, ok := c.tracer.(tracing.Propagator); ok {
+			propagator.Inject(ctx, func(key, value string) {
+				req = req.WithHeader(key, value)
+			})
+		} else if sc := span.SpanContext(); sc.IsValid() {
+			req = req.WithHeader(tracing.TraceparentHeader, sc.Traceparent())
+		}
+
+		resp, err := next(ctx, req)
+		if resp != nil {
+			span.SetAttributes(tracing.Int(tracing.AttrHTTPStatusCode, resp.StatusCode))
+		}
+		if err != nil {
+			span.SetAttributes(errorAttributes(err)...)
+			span.RecordError(err)
+		}
+		return resp, err
+	}
+}
+
+// errorAttributes describes err with its HTTP status, API error code and category.
+func errorAttributes(err error) []tracing.Attribute {
+	var sdkErr *types.SDKError
+	if !errors.As(err, &sdkErr) {
+		return nil
+	}
+	var attrs []tracing.Attribute
+	if sdkErr.StatusCode != 0 {
+		attrs = append(attrs, tracing.Int(tracing.AttrHTTPStatusCode, sdkErr.StatusCode))
+	}
+	if sdkErr.ErrorCode != 0 {
+		attrs = append(attrs, tracing.Int(tracing.AttrErrorCode, sdkErr.ErrorCode))
+	}
+	if category, ok := sdkErr.Meta["category"].(string); ok {
+		attrs = append(attrs, tracing.String(tracing.AttrErrorCategory, category))
+	}
+	return attrs
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/internal/backoff"
+	"github.com/Zillaforge/cloud-sdk/tracing"
+)
+
+func TestClient_Do_Tracing(t *testing.T) {
+	var traceparents []string
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparents = append(traceparents, r.Header.Get("traceparent"))
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"errorCode":2001,"message":"busy"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	rec := tracing.NewRecorder()
+	policy := &backoff.RetryPolicy{MaxAttempts: 2, InitialInterval: time.Millisecond}
+	client := NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil,
+		WithTracer(rec.Tracer("test")), WithRetryPolicy(policy))
+
+	req := &Request{Method: "GET", Path: "/servers", Operation: "vps.servers.List"}
+	if err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := rec.Spans()
+	if len(spans) != 3 {
+		t.Fatalf("expected 2 attempt spans and 1 operation span, got %d", len(spans))
+	}
+	first, second, op := spans[0], spans[1], spans[2]
+
+	if op.Name != "vps.servers.List" || op.Attributes[tracing.AttrAttempts] != 2 {
+		t.Errorf("unexpected operation span: %s %v", op.Name, op.Attributes)
+	}
+	if len(op.Events) != 1 || op.Events[0].Name != "retry" || op.Events[0].Attributes[tracing.AttrErrorCode] != 2001 {
+		t.Errorf("expected one retry event with error code, got %+v", op.Events)
+	}
+	for i, span := range []*tracing.RecordedSpan{first, second} {
+		if span.Parent != op.Context {
+			t.Errorf("attempt %d: expected operation span as parent", i+1)
+		}
+		if span.Attributes[tracing.AttrAttempt] != i+1 {
+			t.Errorf("attempt %d: unexpected attempt attribute %v", i+1, span.Attributes[tracing.AttrAttempt])
+		}
+		if traceparents[i] != span.Context.Traceparent() {
+			t.Errorf("attempt %d: expected traceparent %q, got %q", i+1, span.Context.Traceparent(), traceparents[i])
+		}
+	}
+	if first.Attributes[tracing.AttrHTTPStatusCode] != http.StatusServiceUnavailable || first.Err == nil {
+		t.Errorf("expected failed first attempt, got %v", first.Attributes)
+	}
+	if second.Attributes[tracing.AttrHTTPStatusCode] != http.StatusOK || second.Err != nil {
+		t.Errorf("expected successful second attempt, got %v", second.Attributes)
+	}
+	if req.Headers != nil {
+		t.Error("expected caller's request to be left unchanged")
+	}
+}
+
+func TestClient_Do_TracingError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errorCode":1004,"message":"not found"}`))
+	}))
+	defer server.Close()
+
+	rec := tracing.NewRecorder()
+	client := NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil, WithTracer(rec.Tracer("test")))
+
+	if err := client.Do(context.Background(), &Request{Method: "GET", Path: "/x"}, nil); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	spans := rec.Spans()
+	op := spans[len(spans)-1]
+	if op.Name != "HTTP GET" || op.Err == nil || op.Attributes[tracing.AttrErrorCode] != 1004 {
+		t.Errorf("unexpected operation span: %s err=%v attrs=%v", op.Name, op.Err, op.Attributes)
+	}
+}
@@ -0,0 +1,117 @@
+package types
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Sentinel errors classifying SDKErrors. An SDKError matches a sentinel under
+// errors.Is when its platform error code maps to it in the catalog, or when its
+// HTTP status or client-side category implies it.
+var (
+	ErrBadRequest       = errors.New("bad request")
+	ErrUnauthorized     = errors.New("unauthorized")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrNotFound         = errors.New("not found")
+	ErrConflict         = errors.New("conflict")
+	ErrQuotaExceeded    = errors.New("quota exceeded")
+	ErrThrottled        = errors.New("throttled")
+	ErrTimeout          = errors.New("timeout")
+	ErrInternal         = errors.New("internal server error")
+)
+
+// Platform error codes returned in the errorCode field of error responses.
+// ErrorCodeForbidden accompanies a 403 when the token is invalid or expired, so
+// it classifies as ErrUnauthorized rather than ErrPermissionDenied.
+const (
+	ErrorCodeBadRequest = 1001
+	ErrorCodeForbidden  = 1003
+	ErrorCodeNotFound   = 1004
+	ErrorCodeInternal   = 2001
+)
+
+var (
+	errorCodesMu sync.RWMutex
+	errorCodes   = map[int]error{
+		ErrorCodeBadRequest: ErrBadRequest,
+		ErrorCodeForbidden:  ErrUnauthorized,
+		ErrorCodeNotFound:   ErrNotFound,
+		ErrorCodeInternal:   ErrInternal,
+	}
+)
+
+// ErrorForCode returns the sentinel error registered for a platform error code.
+func ErrorForCode(code int) (error, bool) {
+	errorCodesMu.RLock()
+	defer errorCodesMu.RUnlock()
+	err, ok := errorCodes[code]
+	return err, ok
+}
+
+// RegisterErrorCode maps a platform error code to a sentinel error, replacing any
+// existing mapping.
+func RegisterErrorCode(code int, sentinel error) {
+	errorCodesMu.Lock()
+	defer errorCodesMu.Unlock()
+	errorCodes[code] = sentinel
+}
+
+// statusKinds maps HTTP status codes to the sentinel they imply.
+var statusKinds = map[int]error{
+	http.StatusBadRequest:          ErrBadRequest,
+	http.StatusUnauthorized:        ErrUnauthorized,
+	http.StatusForbidden:           ErrPermissionDenied,
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusConflict:            ErrConflict,
+	http.StatusRequestTimeout:      ErrTimeout,
+	http.StatusTooManyRequests:     ErrThrottled,
+	http.StatusInternalServerError: ErrInternal,
+	http.StatusGatewayTimeout:      ErrTimeout,
+}
+
+// matchesKind reports whether e is classified as the sentinel target.
+func (e *SDKError) matchesKind(target error) bool {
+	if sentinel, ok := ErrorForCode(e.ErrorCode); ok && sentinel == target {
+		return true
+	}
+	if statusKinds[e.StatusCode] == target {
+		return true
+	}
+
+	switch target {
+	case ErrTimeout:
+		category, _ := e.Meta["category"].(string)
+		return e.StatusCode == 0 && category == "timeout"
+	case ErrQuotaExceeded:
+		return quotaStatuses[e.StatusCode] && quotaMessage(e.Message)
+	}
+	return false
+}
+
+// quotaStatuses are the statuses the services reject a request over its quota
+// with: 400 for volumes, 412 and 507 for snapshot storage, 403 and 409 elsewhere.
+// No error code identifies quotas, so the message tells them from other failures.
+var quotaStatuses = map[int]bool{
+	http.StatusBadRequest:          true,
+	http.StatusForbidden:           true,
+	http.StatusConflict:            true,
+	http.StatusPreconditionFailed:  true,
+	http.StatusInsufficientStorage: true,
+}
+
+// quotaMessage reports whether message says a quota is used up, such as
+// "Quota exceeded" or "project quota reached", as opposed to merely naming one.
+func quotaMessage(message string) bool {
+	message = strings.ToLower(message)
+	if !strings.Contains(message, "quota") {
+		return false
+	}
+	for _, verb := range []string{"exceed", "reached", "exhausted", "insufficient"} {
+		if strings.Contains(message, verb) {
+			return true
+		}
+	}
+	return false
+}
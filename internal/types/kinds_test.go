@@ -0,0 +1,68 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSDKError_IsKind(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    *SDKError
+		target error
+		want   bool
+	}{
+		{name: "not found by status", err: &SDKError{StatusCode: 404}, target: ErrNotFound, want: true},
+		{name: "not found by code", err: &SDKError{StatusCode: 400, ErrorCode: ErrorCodeNotFound}, target: ErrNotFound, want: true},
+		{name: "invalid token code", err: &SDKError{StatusCode: 403, ErrorCode: ErrorCodeForbidden}, target: ErrUnauthorized, want: true},
+		{name: "invalid token code without 403", err: &SDKError{StatusCode: 400, ErrorCode: ErrorCodeForbidden}, target: ErrPermissionDenied, want: false},
+		{name: "forbidden status", err: &SDKError{StatusCode: 403}, target: ErrPermissionDenied, want: true},
+		{name: "bad request code", err: &SDKError{StatusCode: 409, ErrorCode: ErrorCodeBadRequest}, target: ErrBadRequest, want: true},
+		{name: "conflict", err: &SDKError{StatusCode: 409, ErrorCode: ErrorCodeBadRequest}, target: ErrConflict, want: true},
+		{name: "internal code", err: &SDKError{StatusCode: 500, ErrorCode: ErrorCodeInternal}, target: ErrInternal, want: true},
+		{name: "throttled", err: &SDKError{StatusCode: 429}, target: ErrThrottled, want: true},
+		{name: "gateway timeout", err: &SDKError{StatusCode: 504}, target: ErrTimeout, want: true},
+		{name: "client timeout", err: NewTimeoutError(nil), target: ErrTimeout, want: true},
+		{name: "network error is not a timeout", err: NewNetworkError("reset", nil), target: ErrTimeout, want: false},
+		{name: "quota by message", err: &SDKError{StatusCode: 403, Message: "Quota exceeded for instances"}, target: ErrQuotaExceeded, want: true},
+		{name: "quota conflict", err: &SDKError{StatusCode: 409, Message: "project quota reached"}, target: ErrQuotaExceeded, want: true},
+		{name: "quota bad request", err: &SDKError{StatusCode: 400, Message: "Quota exceeded"}, target: ErrQuotaExceeded, want: true},
+		{name: "storage quota", err: &SDKError{StatusCode: 507, Message: "snapshot storage quota exhausted"}, target: ErrQuotaExceeded, want: true},
+		{name: "forbidden mentioning quota", err: &SDKError{StatusCode: 403, Message: "not allowed to change quotas"}, target: ErrQuotaExceeded, want: false},
+		{name: "payload too large is not a quota", err: &SDKError{StatusCode: 413}, target: ErrQuotaExceeded, want: false},
+		{name: "validation error mentioning quota", err: &SDKError{StatusCode: 400, Message: "invalid quota value"}, target: ErrQuotaExceeded, want: false},
+		{name: "server error mentioning quota", err: &SDKError{StatusCode: 500, Message: "quota service unavailable"}, target: ErrQuotaExceeded, want: false},
+		{name: "client error mentioning quota", err: &SDKError{Message: "quota"}, target: ErrQuotaExceeded, want: false},
+		{name: "mismatched kind", err: &SDKError{StatusCode: 404}, target: ErrConflict, want: false},
+		{name: "unknown code", err: &SDKError{StatusCode: 418, ErrorCode: 9999}, target: ErrNotFound, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := fmt.Errorf("failed to get server: %w", fmt.Errorf("failed to do request: %w", tt.err))
+			if got := errors.Is(wrapped, tt.target); got != tt.want {
+				t.Errorf("errors.Is(%v, %v) = %v, want %v", wrapped, tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterErrorCode(t *testing.T) {
+	const code = 4242
+	if _, ok := ErrorForCode(code); ok {
+		t.Fatalf("expected code %d to be unmapped", code)
+	}
+	defer func() {
+		errorCodesMu.Lock()
+		delete(errorCodes, code)
+		errorCodesMu.Unlock()
+	}()
+
+	RegisterErrorCode(code, ErrQuotaExceeded)
+	if got, ok := ErrorForCode(code); !ok || got != ErrQuotaExceeded {
+		t.Errorf("ErrorForCode(%d) = %v, %v", code, got, ok)
+	}
+	if !errors.Is(&SDKError{StatusCode: 400, ErrorCode: code}, ErrQuotaExceeded) {
+		t.Error("expected the registered code to match its sentinel")
+	}
+}
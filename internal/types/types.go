@@ -2,8 +2,13 @@
 package types
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/redact"
 )
 
 // Logger defines the interface for logging SDK operations.
@@ -13,6 +18,35 @@ type Logger interface {
 	Error(msg string, keysAndValues ...interface{})
 }
 
+// TokenSource supplies the bearer token used to authenticate requests.
+// Token is called before every request, so implementations should cache
+// tokens and must be safe for concurrent use.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticTokenSource is a TokenSource that always returns the same token. An empty
+// token is reported as an error, so a missing credential fails before the request
+// is sent.
+type StaticTokenSource string
+
+// Token returns the static token.
+func (s StaticTokenSource) Token(_ context.Context) (string, error) {
+	if s == "" {
+		return "", fmt.Errorf("token cannot be empty")
+	}
+	return string(s), nil
+}
+
+// TokenInvalidator is implemented by token sources that cache tokens.
+// The transport calls Invalidate with the rejected token when the API answers
+// HTTP 401 or error code 1003, so that the next call to Token fetches a fresh
+// one. Sources should ignore a token they no longer hand out: concurrent
+// requests rejected with the same token then cause a single refresh.
+type TokenInvalidator interface {
+	Invalidate(rejected string)
+}
+
 // SDKError represents a structured error returned by the SDK.
 // It includes HTTP status codes, error codes from the API, and additional metadata.
 type SDKError struct {
@@ -30,17 +64,37 @@ type SDKError struct {
 
 	// Cause is the underlying error that caused this error
 	Cause error
+
+	// RequestID is the server-assigned ID of the last attempt, when the response carried one
+	RequestID string
+
+	// Method and Path identify the request, with Path relative to the service base URL
+	Method string
+	Path   string
+
+	// Attempts is the number of attempts made, including retries
+	Attempts int
+
+	// Header holds the selected response headers of the last attempt, such as
+	// X-Request-Id, Retry-After and the rate-limit headers
+	Header http.Header
 }
 
 // Error implements the error interface.
 func (e *SDKError) Error() string {
-	if e.StatusCode == 0 {
-		return fmt.Sprintf("SDK error: %s", e.Message)
+	var msg string
+	switch {
+	case e.StatusCode == 0:
+		msg = fmt.Sprintf("SDK error: %s", e.Message)
+	case e.ErrorCode != 0:
+		msg = fmt.Sprintf("HTTP %d (code %d): %s", e.StatusCode, e.ErrorCode, e.Message)
+	default:
+		msg = fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
 	}
-	if e.ErrorCode != 0 {
-		return fmt.Sprintf("HTTP %d (code %d): %s", e.StatusCode, e.ErrorCode, e.Message)
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request ID %s)", e.RequestID)
 	}
-	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
+	return msg
 }
 
 // Unwrap returns the underlying cause error.
@@ -48,15 +102,52 @@ func (e *SDKError) Unwrap() error {
 	return e.Cause
 }
 
-// Is allows error comparison using errors.Is.
+// Is allows error comparison using errors.Is. An SDKError matches another
+// SDKError with the same status and error code, and the sentinel errors
+// (ErrNotFound, ErrThrottled, ...) that classify it.
 func (e *SDKError) Is(target error) bool {
 	var sdkErr *SDKError
 	if errors.As(target, &sdkErr) {
 		return e.StatusCode == sdkErr.StatusCode && e.ErrorCode == sdkErr.ErrorCode
 	}
+	return e.matchesKind(target)
+}
+
+// IsAmbiguous reports whether err leaves the outcome of a mutating request unknown:
+// the request may or may not have been applied by the server. This is the case for
+// network failures, timeouts and 5xx responses.
+func IsAmbiguous(err error) bool {
+	var sdkErr *SDKError
+	if !errors.As(err, &sdkErr) {
+		return false
+	}
+	if sdkErr.StatusCode >= 500 {
+		return true
+	}
+	if sdkErr.StatusCode == 0 {
+		category, _ := sdkErr.Meta["category"].(string)
+		return category == "network" || category == "timeout"
+	}
 	return false
 }
 
+// CreatedSinceSkew is how far a server clock may run behind the client's before
+// CreatedSince misses a resource created after start.
+const CreatedSinceSkew = 30 * time.Second
+
+// CreatedSince reports whether createdAt, an RFC3339 timestamp as returned by the
+// API, is not before start. start is read from the client clock and createdAt from
+// the server's, so CreatedSinceSkew is allowed between them; the API serializes
+// whole seconds, so the comparison is at second precision. An unparseable
+// timestamp reports false.
+func CreatedSince(createdAt string, start time.Time) bool {
+	created, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return false
+	}
+	return !created.Before(start.Add(-CreatedSinceSkew).Truncate(time.Second))
+}
+
 // NewSDKError creates a new SDKError.
 func NewSDKError(statusCode, errorCode int, message string, meta map[string]interface{}, cause error) *SDKError {
 	return &SDKError{
@@ -101,13 +192,36 @@ func NewCanceledError(cause error) *SDKError {
 	}
 }
 
+// NewAuthError creates an SDKError for failures to obtain a bearer token.
+func NewAuthError(cause error) *SDKError {
+	return &SDKError{
+		StatusCode: 0,
+		ErrorCode:  0,
+		Message:    "failed to obtain token",
+		Meta:       map[string]interface{}{"category": "auth"},
+		Cause:      cause,
+	}
+}
+
+// NewCircuitOpenError creates an SDKError for requests rejected by an open circuit breaker.
+// cause is the failure that prevented a retry, if any.
+func NewCircuitOpenError(service string, cause error) *SDKError {
+	return &SDKError{
+		StatusCode: 0,
+		ErrorCode:  0,
+		Message:    fmt.Sprintf("circuit open for %s", service),
+		Meta:       map[string]interface{}{"category": "circuit_open", "service": service},
+		Cause:      cause,
+	}
+}
+
 // NewHTTPError creates an SDKError from an HTTP response without a parseable body.
 func NewHTTPError(statusCode int, rawBody string) *SDKError {
 	return &SDKError{
 		StatusCode: statusCode,
 		ErrorCode:  0,
 		Message:    fmt.Sprintf("HTTP %d", statusCode),
-		Meta:       map[string]interface{}{"raw": rawBody},
+		Meta:       map[string]interface{}{"raw": string(redact.JSON([]byte(rawBody)))},
 		Cause:      nil,
 	}
 }
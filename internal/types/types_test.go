@@ -2,7 +2,9 @@ package types
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 )
 
 func TestSDKError_Error(t *testing.T) {
@@ -191,3 +193,58 @@ func TestNewHTTPError(t *testing.T) {
 		t.Errorf("Cause = %v, want nil", sdkError.Cause)
 	}
 }
+
+func TestNewHTTPError_RedactsRawBody(t *testing.T) {
+	sdkError := NewHTTPError(500, `{"error":"boom","request":{"password":"aHVudGVyMg=="}}`)
+	if raw, _ := sdkError.Meta["raw"].(string); raw != `{"error":"boom","request":{"password":"[REDACTED]"}}` {
+		t.Errorf("Meta raw = %v, want the password masked", raw)
+	}
+}
+
+func TestIsAmbiguous(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"network error", NewNetworkError("connection reset", nil), true},
+		{"timeout", NewTimeoutError(nil), true},
+		{"service unavailable", NewHTTPError(503, ""), true},
+		{"wrapped server error", fmt.Errorf("failed to create server: %w", NewSDKError(500, 2001, "internal", nil, nil)), true},
+		{"canceled", NewCanceledError(nil), false},
+		{"bad request", NewSDKError(400, 1001, "bad request", nil, nil), false},
+		{"non-SDK error", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAmbiguous(tt.err); got != tt.want {
+				t.Errorf("IsAmbiguous() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreatedSince(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 500_000_000, time.UTC)
+	tests := []struct {
+		name      string
+		createdAt string
+		want      bool
+	}{
+		{"after start", "2024-05-01T12:00:03Z", true},
+		{"same second", "2024-05-01T12:00:00Z", true},
+		{"server clock behind", "2024-05-01T11:59:40Z", true},
+		{"beyond the skew allowance", "2024-05-01T11:59:29Z", false},
+		{"hours before", "2024-05-01T09:00:00Z", false},
+		{"unparseable", "yesterday", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CreatedSince(tt.createdAt, start); got != tt.want {
+				t.Errorf("CreatedSince(%q) = %v, want %v", tt.createdAt, got, tt.want)
+			}
+		})
+	}
+}
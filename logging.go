@@ -0,0 +1,55 @@
+package cloudsdk
+
+import (
+	"log/slog"
+
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+)
+
+// LogLevel selects the level request logs are written at.
+type LogLevel = internalhttp.LogLevel
+
+// Levels for WithRequestLogging.
+const (
+	LogLevelDebug = internalhttp.LogLevelDebug
+	LogLevelInfo  = internalhttp.LogLevelInfo
+	LogLevelError = internalhttp.LogLevelError
+)
+
+// SlogLogger adapts a *slog.Logger to the SDK's Logger interface. Key-value
+// pairs are passed through as slog attributes. A nil logger uses slog.Default().
+//
+//	client, err := cloudsdk.New(baseURL, token, cloudsdk.WithLogger(cloudsdk.SlogLogger(slog.Default())))
+func SlogLogger(logger *slog.Logger) Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return slogLogger{logger: logger}
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (l slogLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.logger.Debug(msg, keysAndValues...)
+}
+
+func (l slogLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.logger.Info(msg, keysAndValues...)
+}
+
+func (l slogLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.logger.Error(msg, keysAndValues...)
+}
+
+// WithRequestLogging writes a structured log line for every request attempt and
+// its response through the client's Logger (see WithLogger): method, path,
+// attempt, status, request ID, duration and error. Lines are written at level.
+// When maxBodyBytes is positive, request and response bodies are included up to
+// that many bytes each; zero leaves bodies out.
+func WithRequestLogging(level LogLevel, maxBodyBytes int) ClientOption {
+	return func(c *Client) {
+		c.requestLogging = &internalhttp.RequestLogging{Level: level, MaxBodyBytes: maxBodyBytes}
+	}
+}
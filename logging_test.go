@@ -0,0 +1,80 @@
+package cloudsdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := SlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	logger.Debug("debug line", "attempt", 2)
+	logger.Info("info line", "path", "/servers")
+	logger.Error("error line", "status", 500)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d:\n%s", len(lines), buf.String())
+	}
+	want := []struct {
+		level, msg, key string
+		value           interface{}
+	}{
+		{level: "DEBUG", msg: "debug line", key: "attempt", value: float64(2)},
+		{level: "INFO", msg: "info line", key: "path", value: "/servers"},
+		{level: "ERROR", msg: "error line", key: "status", value: float64(500)},
+	}
+	for i, line := range lines {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", line, err)
+		}
+		if entry["level"] != want[i].level || entry["msg"] != want[i].msg || entry[want[i].key] != want[i].value {
+			t.Errorf("line %d = %v, want %+v", i, entry, want[i])
+		}
+	}
+
+	if SlogLogger(nil) == nil {
+		t.Error("expected a logger backed by slog.Default()")
+	}
+}
+
+func TestWithRequestLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"userId": "user-1", "account": "alice"})
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := SlogLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	client, err := New(server.URL, "test-token", WithLogger(logger), WithRequestLogging(LogLevelInfo, 16))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if _, err := client.IAM().Users().Get(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a request and a response line, got:\n%s", buf.String())
+	}
+	var resp map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &resp); err != nil {
+		t.Fatalf("invalid JSON line: %v", err)
+	}
+	if resp["msg"] != "received response" || resp["method"] != "GET" || resp["status"] != float64(200) || resp["operation"] != nil {
+		t.Errorf("unexpected response line: %v", resp)
+	}
+	if body, _ := resp["body"].(string); !strings.HasSuffix(body, "(truncated, 38 bytes)") {
+		t.Errorf("expected a truncated body, got %q", body)
+	}
+}
@@ -0,0 +1,31 @@
+package cloudsdk
+
+import (
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+)
+
+// ResponseMetadata describes the HTTP exchange behind an SDK call: method, path,
+// status, request ID, attempt count, response headers and duration.
+type ResponseMetadata = internalhttp.ResponseMetadata
+
+// DefaultErrorHeaders are the response headers copied onto SDKError.Header.
+var DefaultErrorHeaders = internalhttp.DefaultErrorHeaders
+
+// WithResponseMetadata fills md with the metadata of a call's last attempt,
+// whether the call succeeds or fails. Failed calls carry the same details on
+// the returned SDKError.
+//
+//	var md cloudsdk.ResponseMetadata
+//	server, err := vpsClient.Servers().Get(ctx, serverID, cloudsdk.WithResponseMetadata(&md))
+//	log.Printf("request %s took %d attempts", md.RequestID, md.Attempts)
+func WithResponseMetadata(md *ResponseMetadata) CallOption {
+	return internalhttp.WithCallResponseMetadata(md)
+}
+
+// WithErrorHeaders copies additional response headers onto SDKError.Header,
+// on top of DefaultErrorHeaders.
+func WithErrorHeaders(names ...string) ClientOption {
+	return func(c *Client) {
+		c.errorHeaders = append(c.errorHeaders, names...)
+	}
+}
@@ -0,0 +1,49 @@
+package cloudsdk_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	"github.com/Zillaforge/cloud-sdk/cloudsdktest"
+)
+
+func TestWithResponseMetadata(t *testing.T) {
+	fake := cloudsdktest.NewServer()
+	defer fake.Close()
+
+	client, err := cloudsdk.New(fake.URL, "token", cloudsdk.WithHTTPClient(fake.Client()))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	project, err := client.Project(context.Background(), cloudsdktest.DefaultProjectID)
+	if err != nil {
+		t.Fatalf("Project() failed: %v", err)
+	}
+
+	var md cloudsdk.ResponseMetadata
+	ctx := cloudsdk.WithCallOptions(context.Background(), cloudsdk.WithResponseMetadata(&md))
+	if _, err := project.VPS().Flavors().List(ctx, nil); err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	if md.StatusCode != 200 || md.Attempts != 1 || !strings.HasPrefix(md.RequestID, "req-") {
+		t.Errorf("unexpected metadata: %+v", md)
+	}
+	if !strings.HasSuffix(md.Path, "/flavors") {
+		t.Errorf("expected the flavors path, got %q", md.Path)
+	}
+
+	_, err = project.VPS().Servers().Get(context.Background(), "missing")
+	var sdkErr *cloudsdk.SDKError
+	if !errors.As(err, &sdkErr) {
+		t.Fatalf("expected SDKError, got %v", err)
+	}
+	if sdkErr.RequestID == "" || sdkErr.RequestID == md.RequestID || sdkErr.Header.Get("X-Request-Id") != sdkErr.RequestID {
+		t.Errorf("expected the error to carry its own request ID, got %q (headers %v)", sdkErr.RequestID, sdkErr.Header)
+	}
+	if sdkErr.Method != "GET" || !strings.HasSuffix(sdkErr.Path, "/servers/missing") || sdkErr.Attempts != 1 {
+		t.Errorf("unexpected error details: %s %s after %d attempts", sdkErr.Method, sdkErr.Path, sdkErr.Attempts)
+	}
+}
@@ -0,0 +1,17 @@
+package cloudsdk
+
+import (
+	"github.com/Zillaforge/cloud-sdk/metrics"
+)
+
+// MetricsRecorder receives an observation for every HTTP attempt made by the SDK:
+// service, operation, method, status code, attempt number, duration and error category.
+// metrics.NewPrometheus provides a ready-made implementation.
+type MetricsRecorder = metrics.Recorder
+
+// WithMetricsRecorder reports every HTTP attempt made by IAM, VPS and VRM clients to r.
+func WithMetricsRecorder(r MetricsRecorder) ClientOption {
+	return func(c *Client) {
+		c.metrics = r
+	}
+}
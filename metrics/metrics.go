@@ -0,0 +1,55 @@
+// Package metrics defines the hook the SDK calls for every HTTP attempt and
+// a ready-made Prometheus-compatible recorder.
+package metrics
+
+import (
+	"time"
+)
+
+// Recorder receives one Observation per HTTP attempt made by the SDK.
+// Implementations must be safe for concurrent use and should return quickly,
+// since they run on the request goroutine.
+type Recorder interface {
+	RecordAttempt(o Observation)
+}
+
+// Observation describes a single HTTP attempt.
+type Observation struct {
+	// Service is the API service: "iam", "vps" or "vrm"
+	Service string
+
+	// Operation names the SDK method, e.g. "vps.servers.Create"
+	Operation string
+
+	// Method is the HTTP method
+	Method string
+
+	// StatusCode is the HTTP status code, or 0 when no response was received
+	StatusCode int
+
+	// Attempt is the 1-based attempt number; values above 1 are retries
+	Attempt int
+
+	// Duration is the time the attempt took, excluding rate-limit and backoff waits
+	Duration time.Duration
+
+	// ErrorCategory classifies a failed attempt (empty on success): "throttled",
+	// "client_error", "server_error", or the SDKError category such as "network",
+	// "timeout", "canceled" or "auth"
+	ErrorCategory string
+}
+
+// Error categories reported for HTTP error responses.
+const (
+	CategoryThrottled   = "throttled"
+	CategoryClientError = "client_error"
+	CategoryServerError = "server_error"
+)
+
+// RecorderFunc adapts a function to the Recorder interface.
+type RecorderFunc func(o Observation)
+
+// RecordAttempt implements Recorder.
+func (f RecorderFunc) RecordAttempt(o Observation) {
+	f(o)
+}
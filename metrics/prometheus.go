@@ -0,0 +1,212 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the latency histogram bucket upper bounds, in seconds.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Prometheus is a Recorder that aggregates observations into counters and a
+// latency histogram and serves them in the Prometheus text exposition format:
+//
+//	<namespace>_requests_total{service,operation,method,code,category}
+//	<namespace>_retries_total{service,operation}
+//	<namespace>_request_duration_seconds{service,operation,method}
+//
+// Mount it on an HTTP mux next to the application's own metrics, or scrape it
+// through WriteTo from an existing collector.
+type Prometheus struct {
+	namespace string
+	buckets   []float64
+
+	mu        sync.Mutex
+	requests  map[requestKey]uint64
+	retries   map[operationKey]uint64
+	durations map[durationKey]*histogram
+}
+
+type requestKey struct {
+	service, operation, method, code, category string
+}
+
+type operationKey struct {
+	service, operation string
+}
+
+type durationKey struct {
+	service, operation, method string
+}
+
+type histogram struct {
+	counts []uint64 // Per bucket, non-cumulative
+	sum    float64
+	count  uint64
+}
+
+// PrometheusOption configures a Prometheus recorder.
+type PrometheusOption func(*Prometheus)
+
+// WithBuckets overrides the latency histogram buckets (upper bounds in seconds).
+func WithBuckets(buckets []float64) PrometheusOption {
+	return func(p *Prometheus) {
+		p.buckets = append([]float64(nil), buckets...)
+		sort.Float64s(p.buckets)
+	}
+}
+
+// NewPrometheus creates a recorder whose metric names start with namespace
+// (default "cloudsdk").
+func NewPrometheus(namespace string, opts ...PrometheusOption) *Prometheus {
+	if namespace == "" {
+		namespace = "cloudsdk"
+	}
+	p := &Prometheus{
+		namespace: namespace,
+		buckets:   DefaultBuckets,
+		requests:  make(map[requestKey]uint64),
+		retries:   make(map[operationKey]uint64),
+		durations: make(map[durationKey]*histogram),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// RecordAttempt implements Recorder.
+func (p *Prometheus) RecordAttempt(o Observation) {
+	code := ""
+	if o.StatusCode > 0 {
+		code = strconv.Itoa(o.StatusCode)
+	}
+	seconds := o.Duration.Seconds()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests[requestKey{o.Service, o.Operation, o.Method, code, o.ErrorCategory}]++
+	if o.Attempt > 1 {
+		p.retries[operationKey{o.Service, o.Operation}]++
+	}
+
+	dk := durationKey{o.Service, o.Operation, o.Method}
+	h, ok := p.durations[dk]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(p.buckets))}
+		p.durations[dk] = h
+	}
+	for i, bound := range p.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format.
+func (p *Prometheus) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = p.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format.
+func (p *Prometheus) WriteTo(w io.Writer) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+
+	name := p.namespace + "_requests_total"
+	fmt.Fprintf(cw, "# HELP %s HTTP attempts made by the SDK.\n# TYPE %s counter\n", name, name)
+	for _, k := range sortedKeys(p.requests, func(k requestKey) string {
+		return strings.Join([]string{k.service, k.operation, k.method, k.code, k.category}, "\x00")
+	}) {
+		fmt.Fprintf(cw, "%s{%s} %d\n", name, labels(
+			"service", k.service, "operation", k.operation, "method", k.method, "code", k.code, "category", k.category,
+		), p.requests[k])
+	}
+
+	name = p.namespace + "_retries_total"
+	fmt.Fprintf(cw, "# HELP %s HTTP attempts that were retries.\n# TYPE %s counter\n", name, name)
+	for _, k := range sortedKeys(p.retries, func(k operationKey) string {
+		return k.service + "\x00" + k.operation
+	}) {
+		fmt.Fprintf(cw, "%s{%s} %d\n", name, labels("service", k.service, "operation", k.operation), p.retries[k])
+	}
+
+	name = p.namespace + "_request_duration_seconds"
+	fmt.Fprintf(cw, "# HELP %s Duration of HTTP attempts made by the SDK.\n# TYPE %s histogram\n", name, name)
+	for _, k := range sortedKeys(p.durations, func(k durationKey) string {
+		return strings.Join([]string{k.service, k.operation, k.method}, "\x00")
+	}) {
+		h := p.durations[k]
+		base := labels("service", k.service, "operation", k.operation, "method", k.method)
+		var cumulative uint64
+		for i, bound := range p.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(cw, "%s_bucket{%s,le=\"%s\"} %d\n", name, base, strconv.FormatFloat(bound, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(cw, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, base, h.count)
+		fmt.Fprintf(cw, "%s_sum{%s} %s\n", name, base, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(cw, "%s_count{%s} %d\n", name, base, h.count)
+	}
+
+	if cw.err != nil {
+		return cw.n, cw.err
+	}
+	return cw.n, cw.w.Flush()
+}
+
+// labels formats alternating label names and values, escaping values.
+func labels(pairs ...string) string {
+	var b strings.Builder
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(pairs[i])
+		b.WriteString(`="`)
+		b.WriteString(labelEscaper.Replace(pairs[i+1]))
+		b.WriteByte('"')
+	}
+	return b.String()
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// sortedKeys returns the keys of m in a stable order.
+func sortedKeys[K comparable, V any](m map[K]V, sortKey func(K) string) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return sortKey(keys[i]) < sortKey(keys[j]) })
+	return keys
+}
+
+// countingWriter tracks bytes written and the first write error.
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheus_WriteTo(t *testing.T) {
+	p := NewPrometheus("", WithBuckets([]float64{1, 0.1}))
+
+	p.RecordAttempt(Observation{Service: "vps", Operation: "vps.servers.List", Method: "GET", StatusCode: 429, Attempt: 1, Duration: 50 * time.Millisecond, ErrorCategory: CategoryThrottled})
+	p.RecordAttempt(Observation{Service: "vps", Operation: "vps.servers.List", Method: "GET", StatusCode: 200, Attempt: 2, Duration: 500 * time.Millisecond})
+	p.RecordAttempt(Observation{Service: "vrm", Operation: `odd"name`, Method: "GET", Attempt: 1, Duration: 2 * time.Second, ErrorCategory: "network"})
+
+	var b strings.Builder
+	if _, err := p.WriteTo(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := b.String()
+
+	for _, want := range []string{
+		"# TYPE cloudsdk_requests_total counter",
+		`cloudsdk_requests_total{service="vps",operation="vps.servers.List",method="GET",code="429",category="throttled"} 1`,
+		`cloudsdk_requests_total{service="vps",operation="vps.servers.List",method="GET",code="200",category=""} 1`,
+		`cloudsdk_requests_total{service="vrm",operation="odd\"name",method="GET",code="",category="network"} 1`,
+		`cloudsdk_retries_total{service="vps",operation="vps.servers.List"} 1`,
+		"# TYPE cloudsdk_request_duration_seconds histogram",
+		`cloudsdk_request_duration_seconds_bucket{service="vps",operation="vps.servers.List",method="GET",le="0.1"} 1`,
+		`cloudsdk_request_duration_seconds_bucket{service="vps",operation="vps.servers.List",method="GET",le="1"} 2`,
+		`cloudsdk_request_duration_seconds_bucket{service="vps",operation="vps.servers.List",method="GET",le="+Inf"} 2`,
+		`cloudsdk_request_duration_seconds_sum{service="vps",operation="vps.servers.List",method="GET"} 0.55`,
+		`cloudsdk_request_duration_seconds_count{service="vps",operation="vps.servers.List",method="GET"} 2`,
+		`cloudsdk_request_duration_seconds_bucket{service="vrm",operation="odd\"name",method="GET",le="1"} 0`,
+		`cloudsdk_request_duration_seconds_bucket{service="vrm",operation="odd\"name",method="GET",le="+Inf"} 1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing line %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrometheus_ServeHTTP(t *testing.T) {
+	p := NewPrometheus("myapp_sdk")
+	p.RecordAttempt(Observation{Service: "iam", Operation: "iam.users.Get", Method: "GET", StatusCode: 200, Attempt: 1})
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("unexpected content type %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), `myapp_sdk_requests_total{service="iam",operation="iam.users.Get",method="GET",code="200",category=""} 1`) {
+		t.Errorf("unexpected body:\n%s", rec.Body.String())
+	}
+}
@@ -0,0 +1,40 @@
+package cloudsdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Zillaforge/cloud-sdk/metrics"
+)
+
+// TestWithMetricsRecorder tests that service calls are reported with their service label
+func TestWithMetricsRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"userId": "user-1"})
+	}))
+	defer server.Close()
+
+	prom := metrics.NewPrometheus("")
+	client, err := New(server.URL, "test-token", WithMetricsRecorder(prom))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if _, err := client.IAM().Users().Get(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var b strings.Builder
+	if _, err := prom.WriteTo(&b); err != nil {
+		t.Fatalf("WriteTo() failed: %v", err)
+	}
+	want := `cloudsdk_requests_total{service="iam",operation="iam.users.Get",method="GET",code="200",category=""} 1`
+	if !strings.Contains(b.String(), want) {
+		t.Errorf("expected %q in:\n%s", want, b.String())
+	}
+}
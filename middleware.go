@@ -0,0 +1,38 @@
+package cloudsdk
+
+import (
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+)
+
+// Request is a single SDK request as seen by middleware: method, path relative to
+// the service base URL (e.g. "/api/v1/project/{id}/servers"), JSON body and headers.
+type Request = internalhttp.Request
+
+// Response is the status, headers and raw body of a single request attempt.
+type Response = internalhttp.Response
+
+// Handler executes a single request attempt. API errors are returned as *SDKError
+// alongside the Response they were decoded from.
+type Handler = internalhttp.Handler
+
+// Middleware wraps each request attempt made by IAM, VPS and VRM clients.
+// It can add headers, audit or time requests, inspect SDKErrors, or answer
+// without calling next to inject faults:
+//
+//	audit := func(next cloudsdk.Handler) cloudsdk.Handler {
+//		return func(ctx context.Context, req *cloudsdk.Request) (*cloudsdk.Response, error) {
+//			resp, err := next(ctx, req.WithHeader("X-Request-Source", "pipeline"))
+//			log.Printf("%s %s attempt=%d err=%v", req.Method, req.Path, req.Attempt, err)
+//			return resp, err
+//		}
+//	}
+type Middleware = internalhttp.Middleware
+
+// WithMiddleware adds middleware around every request attempt, including retries.
+// Middleware runs in the order given, the first being the outermost.
+// Repeated use appends to the chain.
+func WithMiddleware(mw ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
@@ -0,0 +1,44 @@
+package cloudsdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestWithMiddleware tests that middleware wraps service client requests
+func TestWithMiddleware(t *testing.T) {
+	var gotHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Audit")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"userId": "user-1"})
+	}))
+	defer server.Close()
+
+	var paths []string
+	audit := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			paths = append(paths, req.Method+" "+req.Path)
+			return next(ctx, req.WithHeader("X-Audit", "on"))
+		}
+	}
+
+	client, err := New(server.URL, "test-token", WithMiddleware(audit))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if _, err := client.IAM().Users().Get(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(paths) != 1 || paths[0] != "GET /api/v1/user" {
+		t.Errorf("unexpected audited requests: %v", paths)
+	}
+	if gotHeader != "on" {
+		t.Errorf("expected middleware header to reach the server, got %q", gotHeader)
+	}
+}
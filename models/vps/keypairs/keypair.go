@@ -10,7 +10,7 @@ type Keypair struct {
 	PublicKey   string         `json:"public_key"`
 	Fingerprint string         `json:"fingerprint"`
 	UserID      string         `json:"user_id"`
-	PrivateKey  string         `json:"private_key,omitempty"`
+	PrivateKey  string         `json:"private_key,omitempty" redact:"true"`
 	User        *common.IDName `json:"user,omitempty"`
 	CreatedAt   string         `json:"createdAt"`
 	UpdatedAt   string         `json:"updatedAt"`
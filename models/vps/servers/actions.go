@@ -27,13 +27,13 @@ const (
 // ServerActionRequest is the body for Action.
 type ServerActionRequest struct {
 	Action     ServerAction `json:"action"`
-	RebootType RebootType   `json:"reboot_type,omitempty"` // for reboot
-	FlavorID   string       `json:"flavor_id,omitempty"`   // for resize
-	RootSize   int          `json:"root_size,omitempty"`   // for extend_root
-	PrivateKey string       `json:"private_key,omitempty"` // Base64 for get_pwd
+	RebootType RebootType   `json:"reboot_type,omitempty"`               // for reboot
+	FlavorID   string       `json:"flavor_id,omitempty"`                 // for resize
+	RootSize   int          `json:"root_size,omitempty"`                 // for extend_root
+	PrivateKey string       `json:"private_key,omitempty" redact:"true"` // Base64 for get_pwd
 }
 
 // ServerActionResponse is the response from Action.
 type ServerActionResponse struct {
-	Password string `json:"password,omitempty"` // returned for get_pwd action
+	Password string `json:"password,omitempty" redact:"true"` // returned for get_pwd action
 }
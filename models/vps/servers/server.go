@@ -52,7 +52,7 @@ type Server struct {
 	Namespace    string            `json:"namespace,omitempty"`
 	RootDiskID   string            `json:"root_disk_id,omitempty"`
 	RootDiskSize int               `json:"root_disk_size,omitempty"`
-	BootScript   string            `json:"boot_script,omitempty"`
+	BootScript   string            `json:"boot_script,omitempty" redact:"true"`
 	ApprovedAt   string            `json:"approvedAt,omitempty"`
 	CreatedAt    string            `json:"createdAt"`
 	UpdatedAt    string            `json:"updatedAt"`
@@ -91,9 +91,9 @@ type ServerCreateRequest struct {
 	NICs        []ServerNICCreateRequest `json:"nics"`
 	SGIDs       []string                 `json:"sg_ids,omitempty"`
 	KeypairID   string                   `json:"keypair_id,omitempty"`
-	Password    string                   `json:"password,omitempty"`    // Base64
-	BootScript  string                   `json:"boot_script,omitempty"` // Base64
-	VolumeIDs   []string                 `json:"volume_ids,omitempty"`  // deprecated
+	Password    string                   `json:"password,omitempty" redact:"true"`    // Base64
+	BootScript  string                   `json:"boot_script,omitempty" redact:"true"` // Base64
+	VolumeIDs   []string                 `json:"volume_ids,omitempty"`                // deprecated
 	Volumes     []ServerDiskRequest      `json:"volumes,omitempty"`
 }
 
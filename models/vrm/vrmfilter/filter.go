@@ -0,0 +1,264 @@
+// Package vrmfilter builds the where filters accepted by the VRM repository and
+// tag list endpoints.
+//
+// The VRM API matches a field against a value for equality, and a listing
+// returns the items matching every condition. Fields are the JSON field names of
+// the Tag and Repository models, dotted into nested objects (for example
+// "repository.namespace"), or one of the short names the API accepts:
+//
+//	repositories: os, creator, project-id
+//	tags:         repository-id, namespace, project-id
+//
+// Filters are checked against the model they are serialized for:
+//
+//	where, err := vrmfilter.Field("status").Eq(common.TagStatusActive).
+//		And(vrmfilter.Field("type").Eq(common.TagTypeCommon)).
+//		Tags()
+//	if err != nil {
+//		return err
+//	}
+//	list, err := vrmClient.Tags().List(ctx, &tags.ListTagsOptions{Where: where})
+package vrmfilter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/models/vrm/common"
+)
+
+// FieldRef names a field of a VRM model. Create one with Field.
+type FieldRef struct {
+	name string
+}
+
+// Field returns a reference to the named field, either a JSON field path or a
+// short name accepted by the API.
+func Field(name string) FieldRef {
+	return FieldRef{name: name}
+}
+
+// Eq returns a filter matching items whose field equals value. Strings and
+// string-based enums such as common.TagStatus are used as-is, time.Time values
+// are formatted as RFC3339 and other values with fmt.
+func (f FieldRef) Eq(value interface{}) Filter {
+	return Filter{conditions: []condition{{field: f.name, value: formatValue(value)}}}
+}
+
+// Filter is a conjunction of conditions. The zero value matches everything.
+type Filter struct {
+	conditions []condition
+}
+
+// condition is a single field=value match.
+type condition struct {
+	field string
+	value string
+}
+
+// And returns a filter matching the items that match f and every one of others.
+func (f Filter) And(others ...Filter) Filter {
+	conditions := make([]condition, 0, len(f.conditions))
+	conditions = append(conditions, f.conditions...)
+	for _, other := range others {
+		conditions = append(conditions, other.conditions...)
+	}
+	return Filter{conditions: conditions}
+}
+
+// String returns the conditions joined with " AND ", for logging.
+func (f Filter) String() string {
+	return strings.Join(f.strings(), " AND ")
+}
+
+// Tags validates f against the Tag model and returns the where parameters for
+// tags.ListTagsOptions.
+func (f Filter) Tags() ([]string, error) {
+	return f.serialize(tagModel)
+}
+
+// Repositories validates f against the Repository model and returns the where
+// parameters for repositories.ListRepositoriesOptions.
+func (f Filter) Repositories() ([]string, error) {
+	return f.serialize(repositoryModel)
+}
+
+func (f Filter) serialize(m model) ([]string, error) {
+	for _, c := range f.conditions {
+		if err := m.validate(c); err != nil {
+			return nil, err
+		}
+	}
+	return f.strings(), nil
+}
+
+func (f Filter) strings() []string {
+	if len(f.conditions) == 0 {
+		return nil
+	}
+	where := make([]string, len(f.conditions))
+	for i, c := range f.conditions {
+		where[i] = c.field + "=" + c.value
+	}
+	return where
+}
+
+// model describes a filterable VRM model.
+type model struct {
+	name    string
+	typ     reflect.Type
+	aliases map[string]string
+}
+
+var (
+	repositoryModel = model{
+		name: "repository",
+		typ:  reflect.TypeOf(common.Repository{}),
+		aliases: map[string]string{
+			"os":         "operatingSystem",
+			"creator":    "creator.id",
+			"project-id": "project.id",
+		},
+	}
+	tagModel = model{
+		name: "tag",
+		typ:  reflect.TypeOf(common.Tag{}),
+		aliases: map[string]string{
+			"repository-id": "repositoryID",
+			"namespace":     "repository.namespace",
+			"project-id":    "repository.project.id",
+		},
+	}
+)
+
+var (
+	timeType   = reflect.TypeOf(time.Time{})
+	stringType = reflect.TypeOf("")
+)
+
+// validate checks that c names a scalar field of the model and that its value
+// suits the field's type.
+func (m model) validate(c condition) error {
+	if c.field == "" {
+		return fmt.Errorf("%s filter on %q has an empty field name", m.name, c.value)
+	}
+	path := c.field
+	if alias, ok := m.aliases[path]; ok {
+		path = alias
+	}
+
+	typ := m.typ
+	keys := strings.Split(path, ".")
+	for i, key := range keys {
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		switch {
+		case typ.Kind() == reflect.Map && typ.Key() == stringType:
+			// Map keys are free-form, so anything below them is accepted
+			return nil
+		case typ.Kind() != reflect.Struct || typ == timeType:
+			return fmt.Errorf("unknown %s field %q: %s is not an object", m.name, c.field, strings.Join(keys[:i], "."))
+		}
+		field, ok := jsonField(typ, key)
+		if !ok {
+			return fmt.Errorf("unknown %s field %q", m.name, c.field)
+		}
+		typ = field.Type
+	}
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if err := checkValue(typ, c.value); err != nil {
+		return fmt.Errorf("invalid value %q for %s field %q: %w", c.value, m.name, c.field, err)
+	}
+	return nil
+}
+
+// jsonField returns the field of struct type typ serialized under name.
+func jsonField(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+		if tag == "" {
+			tag = field.Name
+		}
+		if tag == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// checkValue reports whether value can match a field of type typ.
+func checkValue(typ reflect.Type, value string) error {
+	if typ == timeType {
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return fmt.Errorf("expected an RFC3339 timestamp")
+		}
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		v := reflect.New(typ).Elem()
+		v.SetString(value)
+		if enum, ok := v.Interface().(interface{ IsValid() bool }); ok && !enum.IsValid() {
+			return fmt.Errorf("not a valid %s", typ.Name())
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("expected an integer")
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if _, err := strconv.ParseUint(value, 10, 64); err != nil {
+			return fmt.Errorf("expected a non-negative integer")
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("expected a number")
+		}
+	case reflect.Bool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("expected true or false")
+		}
+	case reflect.Interface:
+	case reflect.Struct, reflect.Map:
+		return fmt.Errorf("the field is an object, filter on one of its fields instead")
+	default:
+		return fmt.Errorf("%s fields cannot be filtered", typ.Kind())
+	}
+	return nil
+}
+
+// formatValue renders value the way the VRM API serializes it.
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() == reflect.String {
+			return rv.String()
+		}
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,114 @@
+package vrmfilter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/models/vrm/common"
+)
+
+func TestFilter_Tags(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name    string
+		filter  Filter
+		want    []string
+		wantErr string
+	}{
+		{name: "empty", filter: Filter{}, want: nil},
+		{
+			name:   "enums and and",
+			filter: Field("status").Eq(common.TagStatusActive).And(Field("type").Eq(common.TagTypeCommon)),
+			want:   []string{"status=active", "type=common"},
+		},
+		{
+			name:   "nested, alias and typed values",
+			filter: Field("repository.namespace").Eq("public").And(Field("project-id").Eq("proj-1"), Field("size").Eq(1024), Field("createdAt").Eq(created)),
+			want:   []string{"repository.namespace=public", "project-id=proj-1", "size=1024", "createdAt=2024-01-02T03:04:05Z"},
+		},
+		{name: "extra attributes", filter: Field("extra.os_distro").Eq("ubuntu"), want: []string{"extra.os_distro=ubuntu"}},
+		{name: "unknown field", filter: Field("colour").Eq("red"), wantErr: `unknown tag field "colour"`},
+		{name: "unknown nested field", filter: Field("repository.colour").Eq("red"), wantErr: `unknown tag field "repository.colour"`},
+		{name: "scalar is not an object", filter: Field("name.first").Eq("x"), wantErr: "name is not an object"},
+		{name: "object field", filter: Field("repository").Eq("x"), wantErr: "filter on one of its fields"},
+		{name: "list field", filter: Field("repository.tags").Eq("x"), wantErr: "slice fields cannot be filtered"},
+		{name: "invalid enum", filter: Field("status").Eq("running"), wantErr: "not a valid TagStatus"},
+		{name: "invalid integer", filter: Field("size").Eq("large"), wantErr: "expected an integer"},
+		{name: "invalid timestamp", filter: Field("updatedAt").Eq("yesterday"), wantErr: "expected an RFC3339 timestamp"},
+		{name: "empty field", filter: Field("").Eq("x"), wantErr: "empty field name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.filter.Tags()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFilter_Repositories(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  Filter
+		want    []string
+		wantErr string
+	}{
+		{
+			name:   "aliases",
+			filter: Field("os").Eq("linux").And(Field("creator").Eq("user-1"), Field("project-id").Eq("proj-1")),
+			want:   []string{"os=linux", "creator=user-1", "project-id=proj-1"},
+		},
+		{
+			name:   "model fields",
+			filter: Field("operatingSystem").Eq("windows").And(Field("namespace").Eq("public"), Field("count").Eq(2)),
+			want:   []string{"operatingSystem=windows", "namespace=public", "count=2"},
+		},
+		{name: "tag field", filter: Field("status").Eq("active"), wantErr: `unknown repository field "status"`},
+		{name: "tag alias", filter: Field("repository-id").Eq("repo-1"), wantErr: `unknown repository field "repository-id"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.filter.Repositories()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFilter_AndDoesNotAlias(t *testing.T) {
+	base := Field("type").Eq("common")
+	active := base.And(Field("status").Eq("active"))
+	queued := base.And(Field("status").Eq("queued"))
+
+	if got := active.String(); got != "type=common AND status=active" {
+		t.Errorf("unexpected filter %q", got)
+	}
+	if got := queued.String(); got != "type=common AND status=queued" {
+		t.Errorf("unexpected filter %q", got)
+	}
+	if got := base.String(); got != "type=common" {
+		t.Errorf("expected And to leave its receiver untouched, got %q", got)
+	}
+}
@@ -0,0 +1,16 @@
+package iam
+
+import (
+	"github.com/Zillaforge/cloud-sdk/modules/iam/projects"
+	"github.com/Zillaforge/cloud-sdk/modules/iam/users"
+)
+
+//go:generate go run github.com/Zillaforge/cloud-sdk/hack/mockgen
+
+// API is the IAM service implemented by Client.
+type API interface {
+	Users() users.UsersAPI
+	Projects() projects.ProjectsAPI
+}
+
+var _ API = (*Client)(nil)
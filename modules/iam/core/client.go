@@ -9,25 +9,32 @@ import (
 
 // Client represents the IAM API client.
 // IAM is non-project-scoped and provides identity and access management operations.
+// Its sub-clients are created once and are safe for concurrent use.
 type Client struct {
 	baseClient *internalhttp.Client
 	basePath   string
+
+	users    *users.Client
+	projects *projects.Client
 }
 
 // NewClient creates a new IAM client with the provided HTTP client.
 func NewClient(baseClient *internalhttp.Client) *Client {
+	basePath := "/api/v1/"
 	return &Client{
 		baseClient: baseClient,
-		basePath:   "/api/v1/",
+		basePath:   basePath,
+		users:      users.NewClient(baseClient, basePath),
+		projects:   projects.NewClient(baseClient, basePath),
 	}
 }
 
 // Users returns a client for user operations.
-func (c *Client) Users() *users.Client {
-	return users.NewClient(c.baseClient, c.basePath)
+func (c *Client) Users() users.UsersAPI {
+	return c.users
 }
 
 // Projects returns a client for project operations.
-func (c *Client) Projects() *projects.Client {
-	return projects.NewClient(c.baseClient, c.basePath)
+func (c *Client) Projects() projects.ProjectsAPI {
+	return c.projects
 }
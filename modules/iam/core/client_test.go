@@ -61,28 +61,28 @@ func TestClient_Projects(t *testing.T) {
 	}
 }
 
-func TestClient_FactoryMethods_ReturnNewInstances(t *testing.T) {
+func TestClient_FactoryMethods_ReuseInstances(t *testing.T) {
 	// Create a mock HTTP client
 	baseClient := &internalhttp.Client{}
 
 	// Create IAM client
 	client := iam.NewClient(baseClient)
 
-	// Call Users() twice and verify they're different instances
+	// Call Users() twice and verify the sub-client is shared
 	users1 := client.Users()
 	users2 := client.Users()
 
-	// Compare pointers - they should be different instances
-	if users1 == users2 {
-		t.Error("Users() should return new instances, but returned same instance")
+	// Compare pointers - they should be the same instance
+	if users1 != users2 {
+		t.Error("Users() should return the same instance, but returned a new one")
 	}
 
-	// Call Projects() twice and verify they're different instances
+	// Call Projects() twice and verify the sub-client is shared
 	projects1 := client.Projects()
 	projects2 := client.Projects()
 
-	// Compare pointers - they should be different instances
-	if projects1 == projects2 {
-		t.Error("Projects() should return new instances, but returned same instance")
+	// Compare pointers - they should be the same instance
+	if projects1 != projects2 {
+		t.Error("Projects() should return the same instance, but returned a new one")
 	}
 }
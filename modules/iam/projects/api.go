@@ -0,0 +1,22 @@
+package projects
+
+import (
+	"context"
+
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+	"github.com/Zillaforge/cloud-sdk/models/iam/projects"
+	"github.com/Zillaforge/cloud-sdk/pager"
+)
+
+//go:generate go run github.com/Zillaforge/cloud-sdk/hack/mockgen
+
+// ProjectsAPI lists and retrieves the projects visible to the caller.
+// Client implements it; projectsmock.ProjectsAPI is a test double.
+type ProjectsAPI interface {
+	List(ctx context.Context, opts *projects.ListProjectsOptions, callOpts ...internalhttp.CallOption) ([]*projects.ProjectMembership, error)
+	Iterate(ctx context.Context, opts *projects.ListProjectsOptions, callOpts ...internalhttp.CallOption) *pager.Iterator[*projects.ProjectMembership]
+	ListAll(ctx context.Context, opts *projects.ListProjectsOptions, callOpts ...internalhttp.CallOption) ([]*projects.ProjectMembership, error)
+	Get(ctx context.Context, projectID string, callOpts ...internalhttp.CallOption) (*projects.GetProjectResponse, error)
+}
+
+var _ ProjectsAPI = (*Client)(nil)
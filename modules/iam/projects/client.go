@@ -8,6 +8,7 @@ import (
 
 	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
 	"github.com/Zillaforge/cloud-sdk/models/iam/projects"
+	"github.com/Zillaforge/cloud-sdk/pager"
 )
 
 // Client handles project operations for the IAM API.
@@ -25,7 +26,42 @@ func NewClient(baseClient *internalhttp.Client, basePath string) *Client {
 }
 
 // List retrieves all projects the user belongs to with optional pagination.
-func (c *Client) List(ctx context.Context, opts *projects.ListProjectsOptions) ([]*projects.ProjectMembership, error) {
+func (c *Client) List(ctx context.Context, opts *projects.ListProjectsOptions, callOpts ...internalhttp.CallOption) ([]*projects.ProjectMembership, error) {
+	page, err := c.listPage(ctx, opts, callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+// Iterate returns an iterator over the projects the user belongs to, fetching
+// opts.Limit projects per request (pager.DefaultPageSize when unset) from
+// opts.Offset on, in opts.Order.
+func (c *Client) Iterate(ctx context.Context, opts *projects.ListProjectsOptions, callOpts ...internalhttp.CallOption) *pager.Iterator[*projects.ProjectMembership] {
+	var offset, pageSize int
+	var order *string
+	if opts != nil {
+		if opts.Offset != nil {
+			offset = *opts.Offset
+		}
+		if opts.Limit != nil {
+			pageSize = *opts.Limit
+		}
+		order = opts.Order
+	}
+	fetch := func(ctx context.Context, offset, limit int) (pager.Page[*projects.ProjectMembership], error) {
+		return c.listPage(ctx, &projects.ListProjectsOptions{Offset: &offset, Limit: &limit, Order: order}, callOpts...)
+	}
+	return pager.New(ctx, fetch, offset, pageSize)
+}
+
+// ListAll retrieves every project the user belongs to, paging through the listing.
+func (c *Client) ListAll(ctx context.Context, opts *projects.ListProjectsOptions, callOpts ...internalhttp.CallOption) ([]*projects.ProjectMembership, error) {
+	return pager.All(c.Iterate(ctx, opts, callOpts...))
+}
+
+// listPage fetches one page of projects together with the listing total.
+func (c *Client) listPage(ctx context.Context, opts *projects.ListProjectsOptions, callOpts ...internalhttp.CallOption) (pager.Page[*projects.ProjectMembership], error) {
 	// Build query parameters
 	path := c.basePath + "projects"
 	if opts != nil {
@@ -56,30 +92,32 @@ func (c *Client) List(ctx context.Context, opts *projects.ListProjectsOptions) (
 	var response projects.ListProjectsResponse
 
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "iam.projects.List",
 	}
 
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
-		return nil, fmt.Errorf("failed to list projects: %w", err)
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
+		return pager.Page[*projects.ProjectMembership]{}, fmt.Errorf("failed to list projects: %w", err)
 	}
 
-	return response.Projects, nil
+	return pager.Page[*projects.ProjectMembership]{Items: response.Projects, Total: response.Total}, nil
 }
 
 // Get retrieves specific project details by project ID.
-func (c *Client) Get(ctx context.Context, projectID string) (*projects.GetProjectResponse, error) {
+func (c *Client) Get(ctx context.Context, projectID string, callOpts ...internalhttp.CallOption) (*projects.GetProjectResponse, error) {
 	var response projects.GetProjectResponse
 
 	// Build path with project ID
 	path := c.basePath + "project/" + projectID
 
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "iam.projects.Get",
 	}
 
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to get project %s: %w", projectID, err)
 	}
 
@@ -413,3 +413,48 @@ func TestClient_Get_ContextTimeout(t *testing.T) {
 		t.Error("Get() should return nil result on timeout")
 	}
 }
+
+func TestClient_Iterate(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		offset := r.URL.Query().Get("offset")
+		var page []*projects.ProjectMembership
+		switch offset {
+		case "0":
+			page = []*projects.ProjectMembership{{Project: &projects.Project{ProjectID: "p1"}}, {Project: &projects.Project{ProjectID: "p2"}}}
+		case "2":
+			page = []*projects.ProjectMembership{{Project: &projects.Project{ProjectID: "p3"}}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(projects.ListProjectsResponse{Projects: page, Total: 3})
+	}))
+	defer server.Close()
+
+	baseClient := internalhttp.NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil)
+	client := projectsClient.NewClient(baseClient, "/api/v1/")
+
+	limit := 2
+	order := "desc"
+	it := client.Iterate(context.Background(), &projects.ListProjectsOptions{Limit: &limit, Order: &order})
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Item().Project.ProjectID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Iterate() failed: %v", err)
+	}
+	if len(ids) != 3 || ids[0] != "p1" || ids[2] != "p3" || it.Total() != 3 {
+		t.Errorf("expected p1..p3 with total 3, got %v (total %d)", ids, it.Total())
+	}
+	if len(requests) != 2 || requests[0] != "offset=0&limit=2&order=desc" || requests[1] != "offset=2&limit=2&order=desc" {
+		t.Errorf("unexpected page requests: %v", requests)
+	}
+
+	// The server returns fewer projects than the default page size, so the
+	// reported total drives the paging
+	all, err := client.ListAll(context.Background(), nil)
+	if err != nil || len(all) != 3 {
+		t.Errorf("expected ListAll to page up to the total, got %d projects, %v", len(all), err)
+	}
+}
@@ -0,0 +1,17 @@
+package users
+
+import (
+	"context"
+
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+	"github.com/Zillaforge/cloud-sdk/models/iam/users"
+)
+
+//go:generate go run github.com/Zillaforge/cloud-sdk/hack/mockgen
+
+// UsersAPI retrieves the authenticated user. Client implements it.
+type UsersAPI interface {
+	Get(ctx context.Context, callOpts ...internalhttp.CallOption) (*users.User, error)
+}
+
+var _ UsersAPI = (*Client)(nil)
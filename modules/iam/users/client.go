@@ -24,15 +24,16 @@ func NewClient(baseClient *internalhttp.Client, basePath string) *Client {
 }
 
 // Get retrieves the current authenticated user's information.
-func (c *Client) Get(ctx context.Context) (*users.User, error) {
+func (c *Client) Get(ctx context.Context, callOpts ...internalhttp.CallOption) (*users.User, error) {
 	var response users.GetUserResponse
 
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   c.basePath + "user",
+		Method:    "GET",
+		Path:      c.basePath + "user",
+		Operation: "iam.users.Get",
 	}
 
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
@@ -0,0 +1,32 @@
+package vps
+
+import (
+	"github.com/Zillaforge/cloud-sdk/modules/vps/flavors"
+	"github.com/Zillaforge/cloud-sdk/modules/vps/floatingips"
+	"github.com/Zillaforge/cloud-sdk/modules/vps/keypairs"
+	"github.com/Zillaforge/cloud-sdk/modules/vps/networks"
+	"github.com/Zillaforge/cloud-sdk/modules/vps/securitygroups"
+	"github.com/Zillaforge/cloud-sdk/modules/vps/servers"
+	"github.com/Zillaforge/cloud-sdk/modules/vps/snapshots"
+	"github.com/Zillaforge/cloud-sdk/modules/vps/volumes"
+	"github.com/Zillaforge/cloud-sdk/modules/vps/volumetypes"
+)
+
+//go:generate go run github.com/Zillaforge/cloud-sdk/hack/mockgen
+
+// API is the project-scoped VPS service. Client implements it, and each accessor
+// returns the interface of its sub-client so the whole tree can be replaced in tests.
+type API interface {
+	ProjectID() string
+	Networks() networks.NetworksAPI
+	FloatingIPs() floatingips.FloatingIPsAPI
+	Flavors() flavors.FlavorsAPI
+	Keypairs() keypairs.KeypairsAPI
+	SecurityGroups() securitygroups.SecurityGroupsAPI
+	Servers() servers.ServersAPI
+	Snapshots() snapshots.SnapshotsAPI
+	VolumeTypes() volumetypes.VolumeTypesAPI
+	Volumes() volumes.VolumesAPI
+}
+
+var _ API = (*Client)(nil)
@@ -19,21 +19,42 @@ import (
 
 // Client provides access to VPS operations for a specific project.
 // All operations are scoped to the project ID provided at creation.
+// Sub-clients are created once and are safe for concurrent use.
 type Client struct {
 	baseClient *internalhttp.Client
 	projectID  string
 	basePath   string
+
+	networks       *networks.Client
+	floatingIPs    *floatingips.Client
+	flavors        *flavors.Client
+	keypairs       *keypairs.Client
+	securityGroups *securitygroups.Client
+	servers        *servers.Client
+	snapshots      *snapshots.Client
+	volumeTypes    *volumetypes.Client
+	volumes        *volumes.Client
 }
 
 // NewClient creates a new project-scoped VPS client.
 // This is typically called via cloudsdk.Client.Project(projectID).VPS().
-func NewClient(baseURL, token, projectID string, httpClient *http.Client, logger types.Logger) *Client {
+func NewClient(baseURL, token, projectID string, httpClient *http.Client, logger types.Logger, opts ...internalhttp.Option) *Client {
 	basePath := "/api/v1/project/" + projectID
+	baseClient := internalhttp.NewClient(baseURL, token, httpClient, logger, opts...)
 
 	return &Client{
-		baseClient: internalhttp.NewClient(baseURL, token, httpClient, logger),
-		projectID:  projectID,
-		basePath:   basePath,
+		baseClient:     baseClient,
+		projectID:      projectID,
+		basePath:       basePath,
+		networks:       networks.NewClient(baseClient, projectID),
+		floatingIPs:    floatingips.NewClient(baseClient, projectID),
+		flavors:        flavors.NewClient(baseClient, projectID),
+		keypairs:       keypairs.NewClient(baseClient, projectID),
+		securityGroups: securitygroups.NewClient(baseClient, projectID),
+		servers:        servers.NewClient(baseClient, projectID),
+		snapshots:      snapshots.NewClient(baseClient, projectID),
+		volumeTypes:    volumetypes.NewClient(baseClient, projectID),
+		volumes:        volumes.NewClient(baseClient, projectID),
 	}
 }
 
@@ -43,46 +64,46 @@ func (c *Client) ProjectID() string {
 }
 
 // Networks returns the network operations client.
-func (c *Client) Networks() *networks.Client {
-	return networks.NewClient(c.baseClient, c.projectID)
+func (c *Client) Networks() networks.NetworksAPI {
+	return c.networks
 }
 
 // FloatingIPs returns the floating IP operations client.
-func (c *Client) FloatingIPs() *floatingips.Client {
-	return floatingips.NewClient(c.baseClient, c.projectID)
+func (c *Client) FloatingIPs() floatingips.FloatingIPsAPI {
+	return c.floatingIPs
 }
 
 // Flavors returns the flavors operations client.
-func (c *Client) Flavors() *flavors.Client {
-	return flavors.NewClient(c.baseClient, c.projectID)
+func (c *Client) Flavors() flavors.FlavorsAPI {
+	return c.flavors
 }
 
 // Keypairs returns the keypairs operations client.
-func (c *Client) Keypairs() *keypairs.Client {
-	return keypairs.NewClient(c.baseClient, c.projectID)
+func (c *Client) Keypairs() keypairs.KeypairsAPI {
+	return c.keypairs
 }
 
 // SecurityGroups returns the security groups operations client.
-func (c *Client) SecurityGroups() *securitygroups.Client {
-	return securitygroups.NewClient(c.baseClient, c.projectID)
+func (c *Client) SecurityGroups() securitygroups.SecurityGroupsAPI {
+	return c.securityGroups
 }
 
 // Servers returns the servers operations client.
-func (c *Client) Servers() *servers.Client {
-	return servers.NewClient(c.baseClient, c.projectID)
+func (c *Client) Servers() servers.ServersAPI {
+	return c.servers
 }
 
 // Snapshots returns the snapshots operations client.
-func (c *Client) Snapshots() *snapshots.Client {
-	return snapshots.NewClient(c.baseClient, c.projectID)
+func (c *Client) Snapshots() snapshots.SnapshotsAPI {
+	return c.snapshots
 }
 
 // VolumeTypes returns the volume types operations client.
-func (c *Client) VolumeTypes() *volumetypes.Client {
-	return volumetypes.NewClient(c.baseClient, c.projectID)
+func (c *Client) VolumeTypes() volumetypes.VolumeTypesAPI {
+	return c.volumeTypes
 }
 
 // Volumes returns the volumes operations client.
-func (c *Client) Volumes() *volumes.Client {
-	return volumes.NewClient(c.baseClient, c.projectID)
+func (c *Client) Volumes() volumes.VolumesAPI {
+	return c.volumes
 }
@@ -314,3 +314,18 @@ func TestNetwork(t *testing.T) {
 }
 
 var _ types.Logger = (*mockLogger)(nil)
+
+// TestClient_SubClientsReused tests that accessors return the same sub-client on every call
+func TestClient_SubClientsReused(t *testing.T) {
+	client := NewClient("https://api.example.com", "test-token", "proj-123", &http.Client{}, nil)
+
+	if client.Servers() != client.Servers() {
+		t.Error("expected Servers() to return the same client")
+	}
+	if client.Networks() != client.Networks() {
+		t.Error("expected Networks() to return the same client")
+	}
+	if client.Volumes() != client.Volumes() {
+		t.Error("expected Volumes() to return the same client")
+	}
+}
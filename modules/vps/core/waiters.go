@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/Zillaforge/cloud-sdk/internal/types"
-	"github.com/Zillaforge/cloud-sdk/internal/waiter"
 	floatingipsmodels "github.com/Zillaforge/cloud-sdk/models/vps/floatingips"
 	serversmodels "github.com/Zillaforge/cloud-sdk/models/vps/servers"
 	snapshotsmodels "github.com/Zillaforge/cloud-sdk/models/vps/snapshots"
@@ -16,12 +15,13 @@ import (
 	"github.com/Zillaforge/cloud-sdk/modules/vps/servers"
 	"github.com/Zillaforge/cloud-sdk/modules/vps/snapshots"
 	"github.com/Zillaforge/cloud-sdk/modules/vps/volumes"
+	"github.com/Zillaforge/cloud-sdk/waiter"
 )
 
 // ServerWaiterConfig holds configuration for server state waiting.
 type ServerWaiterConfig struct {
 	// Client is the servers client used to poll server state
-	Client *servers.Client
+	Client servers.ServersAPI
 
 	// ServerID is the ID of the server to monitor
 	ServerID string
@@ -36,7 +36,7 @@ type ServerWaiterConfig struct {
 // FloatingIPWaiterConfig holds configuration for floating IP state waiting.
 type FloatingIPWaiterConfig struct {
 	// Client is the floating IPs client used to poll floating IP state
-	Client *floatingips.Client
+	Client floatingips.FloatingIPsAPI
 
 	// FloatingIPID is the ID of the floating IP to monitor
 	FloatingIPID string
@@ -51,7 +51,7 @@ type FloatingIPWaiterConfig struct {
 // VolumeWaiterConfig holds configuration for volume state waiting.
 type VolumeWaiterConfig struct {
 	// Client is the volumes client used to poll volume state
-	Client *volumes.Client
+	Client volumes.VolumesAPI
 
 	// VolumeID is the ID of the volume to monitor
 	VolumeID string
@@ -66,7 +66,7 @@ type VolumeWaiterConfig struct {
 // SnapshotWaiterConfig holds configuration for snapshot state waiting.
 type SnapshotWaiterConfig struct {
 	// Client is the snapshots client used to poll snapshot state
-	Client *snapshots.Client
+	Client snapshots.SnapshotsAPI
 
 	// SnapshotID is the ID of the snapshot to monitor
 	SnapshotID string
@@ -351,7 +351,7 @@ func WaitForSnapshotStatus(ctx context.Context, cfg SnapshotWaiterConfig) error
 }
 
 // WaitForServerActive is a convenience function that waits for a server to become ACTIVE.
-func WaitForServerActive(ctx context.Context, client *servers.Client, serverID string, opts ...waiter.Option) error {
+func WaitForServerActive(ctx context.Context, client servers.ServersAPI, serverID string, opts ...waiter.Option) error {
 	return WaitForServerStatus(ctx, ServerWaiterConfig{
 		Client:        client,
 		ServerID:      serverID,
@@ -361,7 +361,7 @@ func WaitForServerActive(ctx context.Context, client *servers.Client, serverID s
 }
 
 // WaitForServerShutoff is a convenience function that waits for a server to become SHUTOFF.
-func WaitForServerShutoff(ctx context.Context, client *servers.Client, serverID string, opts ...waiter.Option) error {
+func WaitForServerShutoff(ctx context.Context, client servers.ServersAPI, serverID string, opts ...waiter.Option) error {
 	return WaitForServerStatus(ctx, ServerWaiterConfig{
 		Client:        client,
 		ServerID:      serverID,
@@ -373,7 +373,7 @@ func WaitForServerShutoff(ctx context.Context, client *servers.Client, serverID
 // WaitForServerDeleted is a convenience function that waits for a server to be deleted.
 // This function handles the case where Get returns a 404 (not found) error,
 // which indicates the server has been successfully deleted.
-func WaitForServerDeleted(ctx context.Context, client *servers.Client, serverID string, opts ...waiter.Option) error {
+func WaitForServerDeleted(ctx context.Context, client servers.ServersAPI, serverID string, opts ...waiter.Option) error {
 	// Default waiter options for deletion (can be overridden)
 	defaultOpts := []waiter.Option{
 		waiter.WithInterval(3 * time.Second),
@@ -386,9 +386,7 @@ func WaitForServerDeleted(ctx context.Context, client *servers.Client, serverID
 	checkState := func(ctx context.Context) (bool, error) {
 		_, err := client.Get(ctx, serverID)
 		if err != nil {
-			// Check if this is an SDKError with 404 status
-			var sdkErr *types.SDKError
-			if errors.As(err, &sdkErr) && sdkErr.StatusCode == 404 {
+			if errors.Is(err, types.ErrNotFound) {
 				return true, nil // Server is deleted
 			}
 			// Other errors should be propagated
@@ -402,7 +400,7 @@ func WaitForServerDeleted(ctx context.Context, client *servers.Client, serverID
 }
 
 // WaitForFloatingIPActive is a convenience function that waits for a floating IP to become ACTIVE.
-func WaitForFloatingIPActive(ctx context.Context, client *floatingips.Client, floatingIPID string, opts ...waiter.Option) error {
+func WaitForFloatingIPActive(ctx context.Context, client floatingips.FloatingIPsAPI, floatingIPID string, opts ...waiter.Option) error {
 	return WaitForFloatingIPStatus(ctx, FloatingIPWaiterConfig{
 		Client:        client,
 		FloatingIPID:  floatingIPID,
@@ -412,7 +410,7 @@ func WaitForFloatingIPActive(ctx context.Context, client *floatingips.Client, fl
 }
 
 // WaitForVolumeAvailable is a convenience function that waits for a volume to become AVAILABLE.
-func WaitForVolumeAvailable(ctx context.Context, client *volumes.Client, volumeID string, opts ...waiter.Option) error {
+func WaitForVolumeAvailable(ctx context.Context, client volumes.VolumesAPI, volumeID string, opts ...waiter.Option) error {
 	return WaitForVolumeStatus(ctx, VolumeWaiterConfig{
 		Client:        client,
 		VolumeID:      volumeID,
@@ -422,7 +420,7 @@ func WaitForVolumeAvailable(ctx context.Context, client *volumes.Client, volumeI
 }
 
 // WaitForVolumeInUse is a convenience function that waits for a volume to become IN-USE.
-func WaitForVolumeInUse(ctx context.Context, client *volumes.Client, volumeID string, opts ...waiter.Option) error {
+func WaitForVolumeInUse(ctx context.Context, client volumes.VolumesAPI, volumeID string, opts ...waiter.Option) error {
 	return WaitForVolumeStatus(ctx, VolumeWaiterConfig{
 		Client:        client,
 		VolumeID:      volumeID,
@@ -432,7 +430,7 @@ func WaitForVolumeInUse(ctx context.Context, client *volumes.Client, volumeID st
 }
 
 // WaitForSnapshotAvailable is a convenience function that waits for a snapshot to become AVAILABLE.
-func WaitForSnapshotAvailable(ctx context.Context, client *snapshots.Client, snapshotID string, opts ...waiter.Option) error {
+func WaitForSnapshotAvailable(ctx context.Context, client snapshots.SnapshotsAPI, snapshotID string, opts ...waiter.Option) error {
 	return WaitForSnapshotStatus(ctx, SnapshotWaiterConfig{
 		Client:        client,
 		SnapshotID:    snapshotID,
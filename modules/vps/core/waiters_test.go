@@ -10,15 +10,16 @@ import (
 	"time"
 
 	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
-	"github.com/Zillaforge/cloud-sdk/internal/waiter"
 	floatingipsmodels "github.com/Zillaforge/cloud-sdk/models/vps/floatingips"
 	serversmodels "github.com/Zillaforge/cloud-sdk/models/vps/servers"
 	snapshotsmodels "github.com/Zillaforge/cloud-sdk/models/vps/snapshots"
 	volumesmodels "github.com/Zillaforge/cloud-sdk/models/vps/volumes"
 	"github.com/Zillaforge/cloud-sdk/modules/vps/floatingips"
 	"github.com/Zillaforge/cloud-sdk/modules/vps/servers"
+	"github.com/Zillaforge/cloud-sdk/modules/vps/servers/serversmock"
 	"github.com/Zillaforge/cloud-sdk/modules/vps/snapshots"
 	"github.com/Zillaforge/cloud-sdk/modules/vps/volumes"
+	"github.com/Zillaforge/cloud-sdk/waiter"
 )
 
 // TestWaitForServerStatus_Success verifies waiting for a server to reach target status.
@@ -338,6 +339,31 @@ func TestWaitForServerActive(t *testing.T) {
 	}
 }
 
+// TestWaitForServerActive_WithMock verifies waiters accept a ServersAPI test double.
+func TestWaitForServerActive_WithMock(t *testing.T) {
+	statuses := []serversmodels.ServerStatus{serversmodels.ServerStatusBuild, serversmodels.ServerStatusActive}
+	mock := &serversmock.ServersAPI{
+		GetFunc: func(_ context.Context, serverID string, _ ...internalhttp.CallOption) (*servers.ServerResource, error) {
+			status := statuses[0]
+			if len(statuses) > 1 {
+				statuses = statuses[1:]
+			}
+			return servers.NewServerResource(&serversmodels.Server{ID: serverID, Status: status}, nil, nil), nil
+		},
+	}
+
+	err := WaitForServerActive(context.Background(), mock, "svr-test-1",
+		waiter.WithInterval(time.Millisecond),
+		waiter.WithMaxWait(time.Second),
+	)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if got := mock.CallCount("Get"); got != 2 {
+		t.Errorf("expected 2 Get calls, got %d", got)
+	}
+}
+
 // TestWaitForServerShutoff verifies the convenience function for waiting SHUTOFF status.
 func TestWaitForServerShutoff(t *testing.T) {
 	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
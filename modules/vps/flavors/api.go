@@ -0,0 +1,18 @@
+package flavors
+
+import (
+	"context"
+
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+	"github.com/Zillaforge/cloud-sdk/models/vps/flavors"
+)
+
+//go:generate go run github.com/Zillaforge/cloud-sdk/hack/mockgen
+
+// FlavorsAPI is the flavor catalog interface implemented by Client.
+type FlavorsAPI interface {
+	List(ctx context.Context, opts *flavors.ListFlavorsOptions, callOpts ...internalhttp.CallOption) ([]*flavors.Flavor, error)
+	Get(ctx context.Context, flavorID string, callOpts ...internalhttp.CallOption) (*flavors.Flavor, error)
+}
+
+var _ FlavorsAPI = (*Client)(nil)
@@ -29,7 +29,7 @@ func NewClient(baseClient *internalhttp.Client, projectID string) *Client {
 
 // List retrieves a list of available flavors with optional filtering.
 // GET /api/v1/project/{project-id}/flavors
-func (c *Client) List(ctx context.Context, opts *flavors.ListFlavorsOptions) ([]*flavors.Flavor, error) {
+func (c *Client) List(ctx context.Context, opts *flavors.ListFlavorsOptions, callOpts ...internalhttp.CallOption) ([]*flavors.Flavor, error) {
 	path := c.basePath + "/flavors"
 
 	// Build query parameters
@@ -55,12 +55,13 @@ func (c *Client) List(ctx context.Context, opts *flavors.ListFlavorsOptions) ([]
 	}
 
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.flavors.List",
 	}
 
 	var response flavors.FlavorListResponse
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to list flavors: %w", err)
 	}
 
@@ -69,16 +70,17 @@ func (c *Client) List(ctx context.Context, opts *flavors.ListFlavorsOptions) ([]
 
 // Get retrieves details of a specific flavor by ID.
 // GET /api/v1/project/{project-id}/flavors/{flavor-id}
-func (c *Client) Get(ctx context.Context, flavorID string) (*flavors.Flavor, error) {
+func (c *Client) Get(ctx context.Context, flavorID string, callOpts ...internalhttp.CallOption) (*flavors.Flavor, error) {
 	path := fmt.Sprintf("%s/flavors/%s", c.basePath, flavorID)
 
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.flavors.Get",
 	}
 
 	var flavor flavors.Flavor
-	if err := c.baseClient.Do(ctx, req, &flavor); err != nil {
+	if err := c.baseClient.Do(ctx, req, &flavor, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to get flavor %s: %w", flavorID, err)
 	}
 
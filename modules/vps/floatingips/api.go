@@ -0,0 +1,25 @@
+package floatingips
+
+import (
+	"context"
+
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+	"github.com/Zillaforge/cloud-sdk/models/vps/floatingips"
+)
+
+//go:generate go run github.com/Zillaforge/cloud-sdk/hack/mockgen
+
+// FloatingIPsAPI covers the floating IP lifecycle, including the approval
+// workflow. Client implements it.
+type FloatingIPsAPI interface {
+	List(ctx context.Context, opts *floatingips.ListFloatingIPsOptions, callOpts ...internalhttp.CallOption) ([]*floatingips.FloatingIP, error)
+	Create(ctx context.Context, req *floatingips.FloatingIPCreateRequest, callOpts ...internalhttp.CallOption) (*floatingips.FloatingIP, error)
+	Get(ctx context.Context, fipID string, callOpts ...internalhttp.CallOption) (*floatingips.FloatingIP, error)
+	Update(ctx context.Context, fipID string, req *floatingips.FloatingIPUpdateRequest, callOpts ...internalhttp.CallOption) (*floatingips.FloatingIP, error)
+	Delete(ctx context.Context, fipID string, callOpts ...internalhttp.CallOption) error
+	Approve(ctx context.Context, fipID string, callOpts ...internalhttp.CallOption) error
+	Reject(ctx context.Context, fipID string, callOpts ...internalhttp.CallOption) error
+	Disassociate(ctx context.Context, fipID string, callOpts ...internalhttp.CallOption) error
+}
+
+var _ FloatingIPsAPI = (*Client)(nil)
@@ -3,8 +3,10 @@ package floatingips
 import (
 	"context"
 	"fmt"
+	"time"
 
 	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+	"github.com/Zillaforge/cloud-sdk/internal/types"
 	"github.com/Zillaforge/cloud-sdk/models/vps/floatingips"
 )
 
@@ -29,12 +31,13 @@ func NewClient(baseClient *internalhttp.Client, projectID string) *Client {
 // GET /api/v1/project/{project-id}/floatingips
 // Returns a slice of FloatingIP directly (not wrapped in an object).
 // This is a breaking change from the old "items" field wrapper.
-func (c *Client) List(ctx context.Context, opts *floatingips.ListFloatingIPsOptions) ([]*floatingips.FloatingIP, error) {
+func (c *Client) List(ctx context.Context, opts *floatingips.ListFloatingIPsOptions, callOpts ...internalhttp.CallOption) ([]*floatingips.FloatingIP, error) {
 	path := fmt.Sprintf("%s/floatingips", c.basePath)
 
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.floatingips.List",
 	}
 
 	// Add query parameters if provided
@@ -93,7 +96,7 @@ func (c *Client) List(ctx context.Context, opts *floatingips.ListFloatingIPsOpti
 	// The API returns a wrapper object with "floatingips" field containing the array
 	// We unmarshal into FloatingIPListResponse then return just the slice
 	var response floatingips.FloatingIPListResponse
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to list floatingips: %w", err)
 	}
 
@@ -102,35 +105,73 @@ func (c *Client) List(ctx context.Context, opts *floatingips.ListFloatingIPsOpti
 
 // Create allocates a new floating IP.
 // POST /api/v1/project/{project-id}/floatingips
-func (c *Client) Create(ctx context.Context, req *floatingips.FloatingIPCreateRequest) (*floatingips.FloatingIP, error) {
+func (c *Client) Create(ctx context.Context, req *floatingips.FloatingIPCreateRequest, callOpts ...internalhttp.CallOption) (*floatingips.FloatingIP, error) {
+	// Reconciliation runs under the same call options as the create
+	ctx = internalhttp.WithCallOptions(ctx, callOpts...)
+	start := time.Now()
+
 	path := fmt.Sprintf("%s/floatingips", c.basePath)
 
 	httpReq := &internalhttp.Request{
-		Method: "POST",
-		Path:   path,
-		Body:   req,
+		Method:    "POST",
+		Path:      path,
+		Body:      req,
+		Operation: "vps.floatingips.Create",
 	}
 
 	var floatingIP floatingips.FloatingIP
 	if err := c.baseClient.Do(ctx, httpReq, &floatingIP); err != nil {
+		if existing := c.reconcileCreate(ctx, req, start, err); existing != nil {
+			return existing, nil
+		}
 		return nil, fmt.Errorf("failed to create floatingip: %w", err)
 	}
 
 	return &floatingIP, nil
 }
 
+// reconcileCreate looks up a floating IP by name after an ambiguous Create failure
+// (network error, timeout or 5xx) when reconciliation is enabled for the call.
+// Only floating IPs allocated since start are considered, and one is returned only
+// if it is the single such floating IP with the requested name.
+func (c *Client) reconcileCreate(ctx context.Context, req *floatingips.FloatingIPCreateRequest, start time.Time, createErr error) *floatingips.FloatingIP {
+	if req == nil || req.Name == "" || !types.IsAmbiguous(createErr) || !internalhttp.CallOptionsFromContext(ctx).Reconcile {
+		return nil
+	}
+
+	lookupCtx, cancel := internalhttp.ReconcileContext(ctx)
+	defer cancel()
+	candidates, err := c.List(lookupCtx, &floatingips.ListFloatingIPsOptions{Name: req.Name})
+	if err != nil {
+		return nil
+	}
+
+	var match *floatingips.FloatingIP
+	for _, candidate := range candidates {
+		if candidate.Name != req.Name || !types.CreatedSince(candidate.CreatedAt, start) {
+			continue
+		}
+		if match != nil {
+			return nil // Ambiguous: more than one floating IP with this name
+		}
+		match = candidate
+	}
+	return match
+}
+
 // Get retrieves a specific floating IP.
 // GET /api/v1/project/{project-id}/floatingips/{fip-id}
-func (c *Client) Get(ctx context.Context, fipID string) (*floatingips.FloatingIP, error) {
+func (c *Client) Get(ctx context.Context, fipID string, callOpts ...internalhttp.CallOption) (*floatingips.FloatingIP, error) {
 	path := fmt.Sprintf("%s/floatingips/%s", c.basePath, fipID)
 
 	httpReq := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.floatingips.Get",
 	}
 
 	var floatingIP floatingips.FloatingIP
-	if err := c.baseClient.Do(ctx, httpReq, &floatingIP); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &floatingIP, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to get floatingip: %w", err)
 	}
 
@@ -139,17 +180,18 @@ func (c *Client) Get(ctx context.Context, fipID string) (*floatingips.FloatingIP
 
 // Update updates floating IP fields (name, description).
 // PUT /api/v1/project/{project-id}/floatingips/{fip-id}
-func (c *Client) Update(ctx context.Context, fipID string, req *floatingips.FloatingIPUpdateRequest) (*floatingips.FloatingIP, error) {
+func (c *Client) Update(ctx context.Context, fipID string, req *floatingips.FloatingIPUpdateRequest, callOpts ...internalhttp.CallOption) (*floatingips.FloatingIP, error) {
 	path := fmt.Sprintf("%s/floatingips/%s", c.basePath, fipID)
 
 	httpReq := &internalhttp.Request{
-		Method: "PUT",
-		Path:   path,
-		Body:   req,
+		Method:    "PUT",
+		Path:      path,
+		Body:      req,
+		Operation: "vps.floatingips.Update",
 	}
 
 	var floatingIP floatingips.FloatingIP
-	if err := c.baseClient.Do(ctx, httpReq, &floatingIP); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &floatingIP, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to update floatingip: %w", err)
 	}
 
@@ -158,15 +200,16 @@ func (c *Client) Update(ctx context.Context, fipID string, req *floatingips.Floa
 
 // Delete releases a floating IP.
 // DELETE /api/v1/project/{project-id}/floatingips/{fip-id}
-func (c *Client) Delete(ctx context.Context, fipID string) error {
+func (c *Client) Delete(ctx context.Context, fipID string, callOpts ...internalhttp.CallOption) error {
 	path := fmt.Sprintf("%s/floatingips/%s", c.basePath, fipID)
 
 	httpReq := &internalhttp.Request{
-		Method: "DELETE",
-		Path:   path,
+		Method:    "DELETE",
+		Path:      path,
+		Operation: "vps.floatingips.Delete",
 	}
 
-	if err := c.baseClient.Do(ctx, httpReq, nil); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, nil, callOpts...); err != nil {
 		return fmt.Errorf("failed to delete floatingip: %w", err)
 	}
 	return nil
@@ -174,15 +217,16 @@ func (c *Client) Delete(ctx context.Context, fipID string) error {
 
 // Approve approves a pending floating IP request (admin only).
 // POST /api/v1/project/{project-id}/floatingips/{fip-id}/approve
-func (c *Client) Approve(ctx context.Context, fipID string) error {
+func (c *Client) Approve(ctx context.Context, fipID string, callOpts ...internalhttp.CallOption) error {
 	path := fmt.Sprintf("%s/floatingips/%s/approve", c.basePath, fipID)
 
 	httpReq := &internalhttp.Request{
-		Method: "POST",
-		Path:   path,
+		Method:    "POST",
+		Path:      path,
+		Operation: "vps.floatingips.Approve",
 	}
 
-	if err := c.baseClient.Do(ctx, httpReq, nil); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, nil, callOpts...); err != nil {
 		return fmt.Errorf("failed to approve floatingip: %w", err)
 	}
 	return nil
@@ -190,15 +234,16 @@ func (c *Client) Approve(ctx context.Context, fipID string) error {
 
 // Reject rejects a pending floating IP request (admin only).
 // POST /api/v1/project/{project-id}/floatingips/{fip-id}/reject
-func (c *Client) Reject(ctx context.Context, fipID string) error {
+func (c *Client) Reject(ctx context.Context, fipID string, callOpts ...internalhttp.CallOption) error {
 	path := fmt.Sprintf("%s/floatingips/%s/reject", c.basePath, fipID)
 
 	httpReq := &internalhttp.Request{
-		Method: "POST",
-		Path:   path,
+		Method:    "POST",
+		Path:      path,
+		Operation: "vps.floatingips.Reject",
 	}
 
-	if err := c.baseClient.Do(ctx, httpReq, nil); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, nil, callOpts...); err != nil {
 		return fmt.Errorf("failed to reject floatingip: %w", err)
 	}
 	return nil
@@ -206,16 +251,17 @@ func (c *Client) Reject(ctx context.Context, fipID string) error {
 
 // Disassociate disassociates a floating IP from its port.
 // POST /api/v1/project/{project-id}/floatingips/{fip-id}/disassociate
-func (c *Client) Disassociate(ctx context.Context, fipID string) error {
+func (c *Client) Disassociate(ctx context.Context, fipID string, callOpts ...internalhttp.CallOption) error {
 	path := fmt.Sprintf("%s/floatingips/%s/disassociate", c.basePath, fipID)
 
 	httpReq := &internalhttp.Request{
-		Method: "POST",
-		Path:   path,
+		Method:    "POST",
+		Path:      path,
+		Operation: "vps.floatingips.Disassociate",
 	}
 
 	var floatingIP floatingips.FloatingIP
-	if err := c.baseClient.Do(ctx, httpReq, &floatingIP); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &floatingIP, callOpts...); err != nil {
 		return fmt.Errorf("failed to disassociate floatingip: %w", err)
 	}
 	return nil
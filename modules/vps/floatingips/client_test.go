@@ -541,3 +541,45 @@ func TestClient_Disassociate_Error(t *testing.T) {
 		t.Error("expected error, got nil")
 	}
 }
+
+func TestClient_Create_Reconcile(t *testing.T) {
+	listCalls := 0
+	existing := []*floatingips.FloatingIP{{ID: "fip-1", Name: "edge", CreatedAt: time.Now().UTC().Format(time.RFC3339)}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		listCalls++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(floatingips.FloatingIPListResponse{FloatingIPs: existing})
+	}))
+	defer server.Close()
+
+	baseClient := internalhttp.NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil)
+	client := NewClient(baseClient, "proj-123")
+	req := &floatingips.FloatingIPCreateRequest{Name: "edge"}
+
+	// Without reconciliation the ambiguous failure is returned
+	if _, err := client.Create(context.Background(), req); err == nil {
+		t.Fatal("expected error without reconciliation")
+	}
+
+	ctx := internalhttp.WithCallOptions(context.Background(), internalhttp.WithCallReconcile())
+	fip, err := client.Create(ctx, req)
+	if err != nil {
+		t.Fatalf("expected reconciled floating IP, got error %v", err)
+	}
+	if fip.ID != "fip-1" {
+		t.Errorf("expected fip-1, got %s", fip.ID)
+	}
+	if listCalls != 1 {
+		t.Errorf("expected 1 list call, got %d", listCalls)
+	}
+
+	// A floating IP allocated before the call is not mistaken for the new one
+	existing[0].CreatedAt = time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	if _, err := client.Create(ctx, req); err == nil {
+		t.Error("expected the create error for an older floating IP with the same name")
+	}
+}
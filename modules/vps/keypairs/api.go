@@ -0,0 +1,21 @@
+package keypairs
+
+import (
+	"context"
+
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+	"github.com/Zillaforge/cloud-sdk/models/vps/keypairs"
+)
+
+//go:generate go run github.com/Zillaforge/cloud-sdk/hack/mockgen
+
+// KeypairsAPI manages SSH keypairs. Client implements it.
+type KeypairsAPI interface {
+	List(ctx context.Context, opts *keypairs.ListKeypairsOptions, callOpts ...internalhttp.CallOption) ([]*keypairs.Keypair, error)
+	Create(ctx context.Context, req *keypairs.KeypairCreateRequest, callOpts ...internalhttp.CallOption) (*keypairs.Keypair, error)
+	Get(ctx context.Context, keypairID string, callOpts ...internalhttp.CallOption) (*keypairs.Keypair, error)
+	Update(ctx context.Context, keypairID string, req *keypairs.KeypairUpdateRequest, callOpts ...internalhttp.CallOption) (*keypairs.Keypair, error)
+	Delete(ctx context.Context, keypairID string, callOpts ...internalhttp.CallOption) error
+}
+
+var _ KeypairsAPI = (*Client)(nil)
@@ -28,7 +28,7 @@ func NewClient(baseClient *internalhttp.Client, projectID string) *Client {
 
 // List retrieves a list of keypairs with optional filtering.
 // GET /api/v1/project/{project-id}/keypairs
-func (c *Client) List(ctx context.Context, opts *keypairs.ListKeypairsOptions) ([]*keypairs.Keypair, error) {
+func (c *Client) List(ctx context.Context, opts *keypairs.ListKeypairsOptions, callOpts ...internalhttp.CallOption) ([]*keypairs.Keypair, error) {
 	path := c.basePath + "/keypairs"
 
 	// Build query parameters
@@ -44,12 +44,13 @@ func (c *Client) List(ctx context.Context, opts *keypairs.ListKeypairsOptions) (
 	}
 
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.keypairs.List",
 	}
 
 	var response keypairs.KeypairListResponse
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to list keypairs: %w", err)
 	}
 
@@ -64,17 +65,18 @@ func (c *Client) List(ctx context.Context, opts *keypairs.ListKeypairsOptions) (
 
 // Create creates a new keypair.
 // POST /api/v1/project/{project-id}/keypairs
-func (c *Client) Create(ctx context.Context, req *keypairs.KeypairCreateRequest) (*keypairs.Keypair, error) {
+func (c *Client) Create(ctx context.Context, req *keypairs.KeypairCreateRequest, callOpts ...internalhttp.CallOption) (*keypairs.Keypair, error) {
 	path := fmt.Sprintf("%s/keypairs", c.basePath)
 
 	httpReq := &internalhttp.Request{
-		Method: "POST",
-		Path:   path,
-		Body:   req,
+		Method:    "POST",
+		Path:      path,
+		Body:      req,
+		Operation: "vps.keypairs.Create",
 	}
 
 	var keypair keypairs.Keypair
-	if err := c.baseClient.Do(ctx, httpReq, &keypair); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &keypair, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to create keypair: %w", err)
 	}
 
@@ -83,16 +85,17 @@ func (c *Client) Create(ctx context.Context, req *keypairs.KeypairCreateRequest)
 
 // Get retrieves details of a specific keypair by ID.
 // GET /api/v1/project/{project-id}/keypairs/{keypair-id}
-func (c *Client) Get(ctx context.Context, keypairID string) (*keypairs.Keypair, error) {
+func (c *Client) Get(ctx context.Context, keypairID string, callOpts ...internalhttp.CallOption) (*keypairs.Keypair, error) {
 	path := fmt.Sprintf("%s/keypairs/%s", c.basePath, keypairID)
 
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.keypairs.Get",
 	}
 
 	var keypair keypairs.Keypair
-	if err := c.baseClient.Do(ctx, req, &keypair); err != nil {
+	if err := c.baseClient.Do(ctx, req, &keypair, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to get keypair %s: %w", keypairID, err)
 	}
 
@@ -101,17 +104,18 @@ func (c *Client) Get(ctx context.Context, keypairID string) (*keypairs.Keypair,
 
 // Update updates keypair description.
 // PUT /api/v1/project/{project-id}/keypairs/{keypair-id}
-func (c *Client) Update(ctx context.Context, keypairID string, req *keypairs.KeypairUpdateRequest) (*keypairs.Keypair, error) {
+func (c *Client) Update(ctx context.Context, keypairID string, req *keypairs.KeypairUpdateRequest, callOpts ...internalhttp.CallOption) (*keypairs.Keypair, error) {
 	path := fmt.Sprintf("%s/keypairs/%s", c.basePath, keypairID)
 
 	httpReq := &internalhttp.Request{
-		Method: "PUT",
-		Path:   path,
-		Body:   req,
+		Method:    "PUT",
+		Path:      path,
+		Body:      req,
+		Operation: "vps.keypairs.Update",
 	}
 
 	var keypair keypairs.Keypair
-	if err := c.baseClient.Do(ctx, httpReq, &keypair); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &keypair, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to update keypair %s: %w", keypairID, err)
 	}
 
@@ -120,15 +124,16 @@ func (c *Client) Update(ctx context.Context, keypairID string, req *keypairs.Key
 
 // Delete deletes a keypair.
 // DELETE /api/v1/project/{project-id}/keypairs/{keypair-id}
-func (c *Client) Delete(ctx context.Context, keypairID string) error {
+func (c *Client) Delete(ctx context.Context, keypairID string, callOpts ...internalhttp.CallOption) error {
 	path := fmt.Sprintf("%s/keypairs/%s", c.basePath, keypairID)
 
 	httpReq := &internalhttp.Request{
-		Method: "DELETE",
-		Path:   path,
+		Method:    "DELETE",
+		Path:      path,
+		Operation: "vps.keypairs.Delete",
 	}
 
-	if err := c.baseClient.Do(ctx, httpReq, nil); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, nil, callOpts...); err != nil {
 		return fmt.Errorf("failed to delete keypair %s: %w", keypairID, err)
 	}
 
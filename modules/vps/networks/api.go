@@ -0,0 +1,25 @@
+package networks
+
+import (
+	"context"
+
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+	"github.com/Zillaforge/cloud-sdk/models/vps/networks"
+)
+
+//go:generate go run github.com/Zillaforge/cloud-sdk/hack/mockgen
+
+// NetworksAPI manages project networks. Client implements it; the returned
+// resources expose their ports through PortOperations.
+type NetworksAPI interface {
+	List(ctx context.Context, opts *networks.ListNetworksOptions, callOpts ...internalhttp.CallOption) ([]*NetworkResource, error)
+	Create(ctx context.Context, req *networks.NetworkCreateRequest, callOpts ...internalhttp.CallOption) (*NetworkResource, error)
+	Get(ctx context.Context, networkID string, callOpts ...internalhttp.CallOption) (*NetworkResource, error)
+	Update(ctx context.Context, networkID string, req *networks.NetworkUpdateRequest, callOpts ...internalhttp.CallOption) (*NetworkResource, error)
+	Delete(ctx context.Context, networkID string, callOpts ...internalhttp.CallOption) error
+}
+
+var (
+	_ NetworksAPI    = (*Client)(nil)
+	_ PortOperations = (*PortsClient)(nil)
+)
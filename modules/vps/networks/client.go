@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"sync"
 
 	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
 	"github.com/Zillaforge/cloud-sdk/models/vps/networks"
@@ -29,7 +30,7 @@ func NewClient(baseClient *internalhttp.Client, projectID string) *Client {
 
 // List retrieves all networks for the project with optional filters.
 // GET /api/v1/project/{project-id}/networks
-func (c *Client) List(ctx context.Context, opts *networks.ListNetworksOptions) ([]*NetworkResource, error) {
+func (c *Client) List(ctx context.Context, opts *networks.ListNetworksOptions, callOpts ...internalhttp.CallOption) ([]*NetworkResource, error) {
 	path := c.basePath + "/networks"
 
 	// Build query parameters
@@ -66,27 +67,21 @@ func (c *Client) List(ctx context.Context, opts *networks.ListNetworksOptions) (
 	}
 
 	req := &internalhttp.Request{
-		Method:  "GET",
-		Path:    path,
-		Headers: make(map[string]string),
+		Method:    "GET",
+		Path:      path,
+		Headers:   make(map[string]string),
+		Operation: "vps.networks.List",
 	}
 
 	var response networks.NetworkListResponse
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to list networks: %w", err)
 	}
 
-	// Wrap networks in NetworkResource
+	// Wrap networks in NetworkResource; each builds its port client once, on first use
 	networkResources := make([]*NetworkResource, len(response.Networks))
 	for i, network := range response.Networks {
-		networkResources[i] = &NetworkResource{
-			Network: network,
-			portOps: &PortsClient{
-				baseClient: c.baseClient,
-				projectID:  c.projectID,
-				networkID:  network.ID,
-			},
-		}
+		networkResources[i] = c.newNetworkResource(network, network.ID)
 	}
 
 	return networkResources, nil
@@ -94,99 +89,82 @@ func (c *Client) List(ctx context.Context, opts *networks.ListNetworksOptions) (
 
 // Create creates a new network.
 // POST /api/v1/project/{project-id}/networks
-func (c *Client) Create(ctx context.Context, req *networks.NetworkCreateRequest) (*NetworkResource, error) {
+func (c *Client) Create(ctx context.Context, req *networks.NetworkCreateRequest, callOpts ...internalhttp.CallOption) (*NetworkResource, error) {
 	path := fmt.Sprintf("/api/v1/project/%s/networks", c.projectID)
 
 	// Make request
 	httpReq := &internalhttp.Request{
-		Method: "POST",
-		Path:   path,
-		Body:   req,
+		Method:    "POST",
+		Path:      path,
+		Body:      req,
+		Operation: "vps.networks.Create",
 	}
 
 	var network networks.Network
-	if err := c.baseClient.Do(ctx, httpReq, &network); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &network, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to create network: %w", err)
 	}
 
 	// Wrap in NetworkResource with sub-resource operations
-	return &NetworkResource{
-		Network: &network,
-		portOps: &PortsClient{
-			baseClient: c.baseClient,
-			projectID:  c.projectID,
-			networkID:  network.ID,
-		},
-	}, nil
+	return c.newNetworkResource(&network, network.ID), nil
 }
 
 // Get retrieves a specific network with sub-resource operations.
 // GET /api/v1/project/{project-id}/networks/{net-id}
-func (c *Client) Get(ctx context.Context, networkID string) (*NetworkResource, error) {
+func (c *Client) Get(ctx context.Context, networkID string, callOpts ...internalhttp.CallOption) (*NetworkResource, error) {
 	path := fmt.Sprintf("/api/v1/project/%s/networks/%s", c.projectID, networkID)
 
 	// Make request
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.networks.Get",
 	}
 
 	var network networks.Network
-	if err := c.baseClient.Do(ctx, req, &network); err != nil {
+	if err := c.baseClient.Do(ctx, req, &network, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to get network %s: %w", networkID, err)
 	}
 
 	// Wrap in NetworkResource with sub-resource operations
-	return &NetworkResource{
-		Network: &network,
-		portOps: &PortsClient{
-			baseClient: c.baseClient,
-			projectID:  c.projectID,
-			networkID:  networkID,
-		},
-	}, nil
+	return c.newNetworkResource(&network, networkID), nil
 }
 
 // Update updates network name/description.
 // PUT /api/v1/project/{project-id}/networks/{net-id}
-func (c *Client) Update(ctx context.Context, networkID string, req *networks.NetworkUpdateRequest) (*NetworkResource, error) {
+func (c *Client) Update(ctx context.Context, networkID string, req *networks.NetworkUpdateRequest, callOpts ...internalhttp.CallOption) (*NetworkResource, error) {
 	path := fmt.Sprintf("/api/v1/project/%s/networks/%s", c.projectID, networkID)
 
 	// Make request
 	httpReq := &internalhttp.Request{
-		Method: "PUT",
-		Path:   path,
-		Body:   req,
+		Method:    "PUT",
+		Path:      path,
+		Body:      req,
+		Operation: "vps.networks.Update",
 	}
 
 	var network networks.Network
-	if err := c.baseClient.Do(ctx, httpReq, &network); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &network, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to update network %s: %w", networkID, err)
 	}
 
 	// Wrap in NetworkResource with sub-resource operations
-	return &NetworkResource{
-		Network: &network,
-		portOps: &PortsClient{
-			baseClient: c.baseClient,
-			projectID:  c.projectID,
-			networkID:  networkID,
-		},
-	}, nil
+	return c.newNetworkResource(&network, networkID), nil
 }
 
 // Delete deletes a network.
 // DELETE /api/v1/project/{project-id}/networks/{net-id}
-func (c *Client) Delete(ctx context.Context, networkID string) error {
+func (c *Client) Delete(ctx context.Context, networkID string, callOpts ...internalhttp.CallOption) error {
 	path := fmt.Sprintf("/api/v1/project/%s/networks/%s", c.projectID, networkID)
 
 	// Make request
 	req := &internalhttp.Request{
-		Method: "DELETE",
-		Path:   path,
+		Method:    "DELETE",
+		Path:      path,
+		Operation: "vps.networks.Delete",
 	}
 
-	if err := c.baseClient.Do(ctx, req, nil); err != nil {
+	if err := c.baseClient.Do(ctx, req, nil, callOpts...); err != nil {
 		return fmt.Errorf("failed to delete network %s: %w", networkID, err)
 	}
 
@@ -197,14 +175,36 @@ func (c *Client) Delete(ctx context.Context, networkID string) error {
 type NetworkResource struct {
 	*networks.Network
 	portOps PortOperations
+
+	// client and networkID back Ports when portOps is unset
+	client    *Client
+	networkID string
+	portsOnce sync.Once
+}
+
+// newNetworkResource wraps network for networkID; its port client is built on
+// the first call to Ports.
+func (c *Client) newNetworkResource(network *networks.Network, networkID string) *NetworkResource {
+	return &NetworkResource{Network: network, client: c, networkID: networkID}
+}
+
+// NewNetworkResource wraps network with the given port operations.
+func NewNetworkResource(network *networks.Network, ports PortOperations) *NetworkResource {
+	return &NetworkResource{Network: network, portOps: ports}
 }
 
 // Ports returns the port operations for this network.
 func (nr *NetworkResource) Ports() PortOperations {
+	if nr.client == nil {
+		return nr.portOps
+	}
+	nr.portsOnce.Do(func() {
+		nr.portOps = &PortsClient{baseClient: nr.client.baseClient, projectID: nr.client.projectID, networkID: nr.networkID}
+	})
 	return nr.portOps
 }
 
 // PortOperations defines operations on network ports (sub-resource).
 type PortOperations interface {
-	List(ctx context.Context) ([]*networks.NetworkPort, error)
+	List(ctx context.Context, callOpts ...internalhttp.CallOption) ([]*networks.NetworkPort, error)
 }
@@ -17,17 +17,18 @@ type PortsClient struct {
 
 // List lists all ports on the network.
 // GET /api/v1/project/{project-id}/networks/{net-id}/ports
-func (c *PortsClient) List(ctx context.Context) ([]*networks.NetworkPort, error) {
+func (c *PortsClient) List(ctx context.Context, callOpts ...internalhttp.CallOption) ([]*networks.NetworkPort, error) {
 	path := fmt.Sprintf("/api/v1/project/%s/networks/%s/ports", c.projectID, c.networkID)
 
 	// Make request
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.networks.ports.List",
 	}
 
 	var ports []*networks.NetworkPort
-	if err := c.baseClient.Do(ctx, req, &ports); err != nil {
+	if err := c.baseClient.Do(ctx, req, &ports, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to list ports for network %s: %w", c.networkID, err)
 	}
 
@@ -0,0 +1,30 @@
+package securitygroups
+
+import (
+	"context"
+
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+	"github.com/Zillaforge/cloud-sdk/models/vps/securitygroups"
+)
+
+//go:generate go run github.com/Zillaforge/cloud-sdk/hack/mockgen
+
+// SecurityGroupsAPI manages security groups. Client implements it.
+type SecurityGroupsAPI interface {
+	List(ctx context.Context, opts *securitygroups.ListSecurityGroupsOptions, callOpts ...internalhttp.CallOption) ([]*SecurityGroupResource, error)
+	Create(ctx context.Context, req securitygroups.SecurityGroupCreateRequest, callOpts ...internalhttp.CallOption) (*SecurityGroupResource, error)
+	Get(ctx context.Context, sgID string, callOpts ...internalhttp.CallOption) (*SecurityGroupResource, error)
+	Update(ctx context.Context, sgID string, req securitygroups.SecurityGroupUpdateRequest, callOpts ...internalhttp.CallOption) (*SecurityGroupResource, error)
+	Delete(ctx context.Context, sgID string, callOpts ...internalhttp.CallOption) error
+}
+
+// RulesAPI manages the rules of one security group. RulesClient implements it.
+type RulesAPI interface {
+	Create(ctx context.Context, req securitygroups.SecurityGroupRuleCreateRequest, callOpts ...internalhttp.CallOption) (*securitygroups.SecurityGroupRule, error)
+	Delete(ctx context.Context, ruleID string, callOpts ...internalhttp.CallOption) error
+}
+
+var (
+	_ SecurityGroupsAPI = (*Client)(nil)
+	_ RulesAPI          = (*RulesClient)(nil)
+)
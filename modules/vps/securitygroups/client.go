@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"sync"
 
 	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
 	"github.com/Zillaforge/cloud-sdk/models/vps/securitygroups"
@@ -21,11 +22,33 @@ type Client struct {
 // It provides the Rules() method to access rule management operations.
 type SecurityGroupResource struct {
 	*securitygroups.SecurityGroup
-	rulesOps *RulesClient
+	rulesOps RulesAPI
+
+	// client and securityGroupID back Rules when rulesOps is unset
+	client          *Client
+	securityGroupID string
+	rulesOnce       sync.Once
+}
+
+// newSecurityGroupResource wraps securityGroup for securityGroupID; its rules
+// client is built on the first call to Rules.
+func (c *Client) newSecurityGroupResource(securityGroup *securitygroups.SecurityGroup, securityGroupID string) *SecurityGroupResource {
+	return &SecurityGroupResource{SecurityGroup: securityGroup, client: c, securityGroupID: securityGroupID}
+}
+
+// NewSecurityGroupResource wraps securityGroup with the given rule operations.
+func NewSecurityGroupResource(securityGroup *securitygroups.SecurityGroup, rules RulesAPI) *SecurityGroupResource {
+	return &SecurityGroupResource{SecurityGroup: securityGroup, rulesOps: rules}
 }
 
 // Rules returns a client for managing rules within this security group.
-func (sgr *SecurityGroupResource) Rules() *RulesClient {
+func (sgr *SecurityGroupResource) Rules() RulesAPI {
+	if sgr.client == nil {
+		return sgr.rulesOps
+	}
+	sgr.rulesOnce.Do(func() {
+		sgr.rulesOps = NewRulesClient(sgr.client.baseClient, sgr.client.projectID, sgr.securityGroupID)
+	})
 	return sgr.rulesOps
 }
 
@@ -41,7 +64,7 @@ func NewClient(baseClient *internalhttp.Client, projectID string) *Client {
 
 // List retrieves a list of security groups with optional filtering.
 // GET /api/v1/project/{project-id}/security_groups
-func (c *Client) List(ctx context.Context, opts *securitygroups.ListSecurityGroupsOptions) ([]*SecurityGroupResource, error) {
+func (c *Client) List(ctx context.Context, opts *securitygroups.ListSecurityGroupsOptions, callOpts ...internalhttp.CallOption) ([]*SecurityGroupResource, error) {
 	path := c.basePath + "/security_groups"
 
 	// Build query parameters
@@ -63,22 +86,20 @@ func (c *Client) List(ctx context.Context, opts *securitygroups.ListSecurityGrou
 	}
 
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.securitygroups.List",
 	}
 
 	var response securitygroups.SecurityGroupListResponse
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to list security groups: %w", err)
 	}
 
-	// Wrap security groups in SecurityGroupResource
+	// Wrap security groups in SecurityGroupResource; each builds its rules client once, on first use
 	sgResources := make([]*SecurityGroupResource, len(response.SecurityGroups))
 	for i, sg := range response.SecurityGroups {
-		sgResources[i] = &SecurityGroupResource{
-			SecurityGroup: &response.SecurityGroups[i],
-			rulesOps:      NewRulesClient(c.baseClient, c.projectID, sg.ID),
-		}
+		sgResources[i] = c.newSecurityGroupResource(&response.SecurityGroups[i], sg.ID)
 	}
 
 	return sgResources, nil
@@ -86,87 +107,76 @@ func (c *Client) List(ctx context.Context, opts *securitygroups.ListSecurityGrou
 
 // Create creates a new security group.
 // POST /api/v1/project/{project-id}/security_groups
-func (c *Client) Create(ctx context.Context, req securitygroups.SecurityGroupCreateRequest) (*SecurityGroupResource, error) {
+func (c *Client) Create(ctx context.Context, req securitygroups.SecurityGroupCreateRequest, callOpts ...internalhttp.CallOption) (*SecurityGroupResource, error) {
 	path := fmt.Sprintf("%s/security_groups", c.basePath)
 
 	httpReq := &internalhttp.Request{
-		Method: "POST",
-		Path:   path,
-		Body:   req,
+		Method:    "POST",
+		Path:      path,
+		Body:      req,
+		Operation: "vps.securitygroups.Create",
 	}
 
 	var sg securitygroups.SecurityGroup
-	if err := c.baseClient.Do(ctx, httpReq, &sg); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &sg, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to create security group: %w", err)
 	}
 
-	// Wrap the security group with rules client
-	rulesClient := NewRulesClient(c.baseClient, c.projectID, sg.ID)
-	return &SecurityGroupResource{
-		SecurityGroup: &sg,
-		rulesOps:      rulesClient,
-	}, nil
+	return c.newSecurityGroupResource(&sg, sg.ID), nil
 }
 
 // Get retrieves details of a specific security group by ID.
 // GET /api/v1/project/{project-id}/security_groups/{sg-id}
 // Returns a SecurityGroupResource that provides access to rule management via Rules() method.
-func (c *Client) Get(ctx context.Context, sgID string) (*SecurityGroupResource, error) {
+func (c *Client) Get(ctx context.Context, sgID string, callOpts ...internalhttp.CallOption) (*SecurityGroupResource, error) {
 	path := fmt.Sprintf("%s/security_groups/%s", c.basePath, sgID)
 
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.securitygroups.Get",
 	}
 
 	var sg securitygroups.SecurityGroup
-	if err := c.baseClient.Do(ctx, req, &sg); err != nil {
+	if err := c.baseClient.Do(ctx, req, &sg, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to get security group %s: %w", sgID, err)
 	}
 
-	// Wrap the security group with rules client
-	rulesClient := NewRulesClient(c.baseClient, c.projectID, sgID)
-	return &SecurityGroupResource{
-		SecurityGroup: &sg,
-		rulesOps:      rulesClient,
-	}, nil
+	return c.newSecurityGroupResource(&sg, sgID), nil
 }
 
 // Update updates security group name and/or description.
 // PUT /api/v1/project/{project-id}/security_groups/{sg-id}
-func (c *Client) Update(ctx context.Context, sgID string, req securitygroups.SecurityGroupUpdateRequest) (*SecurityGroupResource, error) {
+func (c *Client) Update(ctx context.Context, sgID string, req securitygroups.SecurityGroupUpdateRequest, callOpts ...internalhttp.CallOption) (*SecurityGroupResource, error) {
 	path := fmt.Sprintf("%s/security_groups/%s", c.basePath, sgID)
 
 	httpReq := &internalhttp.Request{
-		Method: "PUT",
-		Path:   path,
-		Body:   req,
+		Method:    "PUT",
+		Path:      path,
+		Body:      req,
+		Operation: "vps.securitygroups.Update",
 	}
 
 	var sg securitygroups.SecurityGroup
-	if err := c.baseClient.Do(ctx, httpReq, &sg); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &sg, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to update security group %s: %w", sgID, err)
 	}
 
-	// Wrap the security group with rules client
-	rulesClient := NewRulesClient(c.baseClient, c.projectID, sgID)
-	return &SecurityGroupResource{
-		SecurityGroup: &sg,
-		rulesOps:      rulesClient,
-	}, nil
+	return c.newSecurityGroupResource(&sg, sgID), nil
 }
 
 // Delete removes a security group.
 // DELETE /api/v1/project/{project-id}/security_groups/{sg-id}
-func (c *Client) Delete(ctx context.Context, sgID string) error {
+func (c *Client) Delete(ctx context.Context, sgID string, callOpts ...internalhttp.CallOption) error {
 	path := fmt.Sprintf("%s/security_groups/%s", c.basePath, sgID)
 
 	req := &internalhttp.Request{
-		Method: "DELETE",
-		Path:   path,
+		Method:    "DELETE",
+		Path:      path,
+		Operation: "vps.securitygroups.Delete",
 	}
 
-	if err := c.baseClient.Do(ctx, req, nil); err != nil {
+	if err := c.baseClient.Do(ctx, req, nil, callOpts...); err != nil {
 		return fmt.Errorf("failed to delete security group %s: %w", sgID, err)
 	}
 
@@ -29,17 +29,18 @@ func NewRulesClient(baseClient *internalhttp.Client, projectID, securityGroupID
 
 // Create creates a new rule in the security group.
 // POST /api/v1/project/{project-id}/security_groups/{sg-id}/rules
-func (rc *RulesClient) Create(ctx context.Context, req securitygroups.SecurityGroupRuleCreateRequest) (*securitygroups.SecurityGroupRule, error) {
+func (rc *RulesClient) Create(ctx context.Context, req securitygroups.SecurityGroupRuleCreateRequest, callOpts ...internalhttp.CallOption) (*securitygroups.SecurityGroupRule, error) {
 	path := fmt.Sprintf("%s/rules", rc.basePath)
 
 	httpReq := &internalhttp.Request{
-		Method: "POST",
-		Path:   path,
-		Body:   req,
+		Method:    "POST",
+		Path:      path,
+		Body:      req,
+		Operation: "vps.securitygroups.rules.Create",
 	}
 
 	var rule securitygroups.SecurityGroupRule
-	if err := rc.baseClient.Do(ctx, httpReq, &rule); err != nil {
+	if err := rc.baseClient.Do(ctx, httpReq, &rule, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to create rule in security group %s: %w", rc.securityGroupID, err)
 	}
 
@@ -48,15 +49,16 @@ func (rc *RulesClient) Create(ctx context.Context, req securitygroups.SecurityGr
 
 // Delete removes a rule from the security group.
 // DELETE /api/v1/project/{project-id}/security_groups/{sg-id}/rules/{rule-id}
-func (rc *RulesClient) Delete(ctx context.Context, ruleID string) error {
+func (rc *RulesClient) Delete(ctx context.Context, ruleID string, callOpts ...internalhttp.CallOption) error {
 	path := fmt.Sprintf("%s/rules/%s", rc.basePath, ruleID)
 
 	req := &internalhttp.Request{
-		Method: "DELETE",
-		Path:   path,
+		Method:    "DELETE",
+		Path:      path,
+		Operation: "vps.securitygroups.rules.Delete",
 	}
 
-	if err := rc.baseClient.Do(ctx, req, nil); err != nil {
+	if err := rc.baseClient.Do(ctx, req, nil, callOpts...); err != nil {
 		return fmt.Errorf("failed to delete rule %s from security group %s: %w", ruleID, rc.securityGroupID, err)
 	}
 
@@ -0,0 +1,29 @@
+package servers
+
+import (
+	"context"
+
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+	"github.com/Zillaforge/cloud-sdk/models/vps/servers"
+)
+
+//go:generate go run github.com/Zillaforge/cloud-sdk/hack/mockgen
+
+// ServersAPI manages server instances. Client implements it; NIC and volume
+// attachments are reached through the returned ServerResource.
+type ServersAPI interface {
+	List(ctx context.Context, opts *servers.ServersListRequest, callOpts ...internalhttp.CallOption) ([]*ServerResource, error)
+	Create(ctx context.Context, req *servers.ServerCreateRequest, callOpts ...internalhttp.CallOption) (*ServerResource, error)
+	Get(ctx context.Context, serverID string, callOpts ...internalhttp.CallOption) (*ServerResource, error)
+	Update(ctx context.Context, serverID string, req *servers.ServerUpdateRequest, callOpts ...internalhttp.CallOption) (*ServerResource, error)
+	Delete(ctx context.Context, serverID string, callOpts ...internalhttp.CallOption) error
+	Action(ctx context.Context, serverID string, req *servers.ServerActionRequest, callOpts ...internalhttp.CallOption) error
+	Metrics(ctx context.Context, serverID string, req *servers.ServerMetricsRequest, callOpts ...internalhttp.CallOption) (*servers.ServerMetricsResponse, error)
+	GetVNCConsoleURL(ctx context.Context, serverID string, callOpts ...internalhttp.CallOption) (*servers.ServerConsoleURLResponse, error)
+}
+
+var (
+	_ ServersAPI       = (*Client)(nil)
+	_ NICOperations    = (*NICsClient)(nil)
+	_ VolumeOperations = (*VolumesClient)(nil)
+)
@@ -3,8 +3,11 @@ package servers
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+	"github.com/Zillaforge/cloud-sdk/internal/types"
 	"github.com/Zillaforge/cloud-sdk/models/vps/floatingips"
 	"github.com/Zillaforge/cloud-sdk/models/vps/servers"
 )
@@ -25,7 +28,7 @@ func NewClient(baseClient *internalhttp.Client, projectID string) *Client {
 
 // List retrieves all servers for the project with optional filters.
 // GET /api/v1/project/{project-id}/servers
-func (c *Client) List(ctx context.Context, opts *servers.ServersListRequest) ([]*ServerResource, error) {
+func (c *Client) List(ctx context.Context, opts *servers.ServersListRequest, callOpts ...internalhttp.CallOption) ([]*ServerResource, error) {
 	path := fmt.Sprintf("/api/v1/project/%s/servers", c.projectID)
 
 	// Build query parameters
@@ -56,31 +59,20 @@ func (c *Client) List(ctx context.Context, opts *servers.ServersListRequest) ([]
 
 	// Make request
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.servers.List",
 	}
 
 	var response servers.ServersListResponse
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to list servers: %w", err)
 	}
 
-	// Wrap servers in ServerResource
+	// Wrap servers in ServerResource; each builds its sub-resource clients once, on first use
 	serverResources := make([]*ServerResource, len(response.Servers))
 	for i, server := range response.Servers {
-		serverResources[i] = &ServerResource{
-			Server: server,
-			nicOps: &NICsClient{
-				baseClient: c.baseClient,
-				projectID:  c.projectID,
-				serverID:   server.ID,
-			},
-			volumeOps: &VolumesClient{
-				baseClient: c.baseClient,
-				projectID:  c.projectID,
-				serverID:   server.ID,
-			},
-		}
+		serverResources[i] = c.newServerResource(server, server.ID)
 	}
 
 	return serverResources, nil
@@ -88,114 +80,118 @@ func (c *Client) List(ctx context.Context, opts *servers.ServersListRequest) ([]
 
 // Create provisions a new server instance.
 // POST /api/v1/project/{project-id}/servers
-func (c *Client) Create(ctx context.Context, req *servers.ServerCreateRequest) (*ServerResource, error) {
+func (c *Client) Create(ctx context.Context, req *servers.ServerCreateRequest, callOpts ...internalhttp.CallOption) (*ServerResource, error) {
+	// Reconciliation runs under the same call options as the create
+	ctx = internalhttp.WithCallOptions(ctx, callOpts...)
+	start := time.Now()
+
 	path := fmt.Sprintf("/api/v1/project/%s/servers", c.projectID)
 
 	// Make request
 	httpReq := &internalhttp.Request{
-		Method: "POST",
-		Path:   path,
-		Body:   req,
+		Method:    "POST",
+		Path:      path,
+		Body:      req,
+		Operation: "vps.servers.Create",
 	}
 
 	var server servers.Server
 	if err := c.baseClient.Do(ctx, httpReq, &server); err != nil {
+		if existing := c.reconcileCreate(ctx, req, start, err); existing != nil {
+			return existing, nil
+		}
 		return nil, fmt.Errorf("failed to create server: %w", err)
 	}
 
 	// Wrap in ServerResource with sub-resource operations
-	return &ServerResource{
-		Server: &server,
-		nicOps: &NICsClient{
-			baseClient: c.baseClient,
-			projectID:  c.projectID,
-			serverID:   server.ID,
-		},
-		volumeOps: &VolumesClient{
-			baseClient: c.baseClient,
-			projectID:  c.projectID,
-			serverID:   server.ID,
-		},
-	}, nil
+	return c.newServerResource(&server, server.ID), nil
+}
+
+// reconcileCreate looks up a server by name after an ambiguous Create failure
+// (network error, timeout or 5xx) when reconciliation is enabled for the call.
+// Names are not unique, so only servers created since start are considered, and
+// the server is returned only if exactly one of them has the requested name.
+func (c *Client) reconcileCreate(ctx context.Context, req *servers.ServerCreateRequest, start time.Time, createErr error) *ServerResource {
+	if req == nil || req.Name == "" || !types.IsAmbiguous(createErr) || !internalhttp.CallOptionsFromContext(ctx).Reconcile {
+		return nil
+	}
+
+	lookupCtx, cancel := internalhttp.ReconcileContext(ctx)
+	defer cancel()
+	candidates, err := c.List(lookupCtx, &servers.ServersListRequest{Name: req.Name})
+	if err != nil {
+		return nil
+	}
+
+	var match *ServerResource
+	for _, candidate := range candidates {
+		if candidate.Name != req.Name || !types.CreatedSince(candidate.CreatedAt, start) {
+			continue
+		}
+		if match != nil {
+			return nil // Ambiguous: more than one server with this name
+		}
+		match = candidate
+	}
+	return match
 }
 
 // Get retrieves a specific server with sub-resource operations.
 // GET /api/v1/project/{project-id}/servers/{svr-id}
-func (c *Client) Get(ctx context.Context, serverID string) (*ServerResource, error) {
+func (c *Client) Get(ctx context.Context, serverID string, callOpts ...internalhttp.CallOption) (*ServerResource, error) {
 	path := fmt.Sprintf("/api/v1/project/%s/servers/%s", c.projectID, serverID)
 
 	// Make request
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.servers.Get",
 	}
 
 	var server servers.Server
-	if err := c.baseClient.Do(ctx, req, &server); err != nil {
+	if err := c.baseClient.Do(ctx, req, &server, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to get server %s: %w", serverID, err)
 	}
 
 	// Wrap in ServerResource with sub-resource operations
-	return &ServerResource{
-		Server: &server,
-		nicOps: &NICsClient{
-			baseClient: c.baseClient,
-			projectID:  c.projectID,
-			serverID:   serverID,
-		},
-		volumeOps: &VolumesClient{
-			baseClient: c.baseClient,
-			projectID:  c.projectID,
-			serverID:   serverID,
-		},
-	}, nil
+	return c.newServerResource(&server, serverID), nil
 }
 
 // Update modifies server name/description.
 // PUT /api/v1/project/{project-id}/servers/{svr-id}
-func (c *Client) Update(ctx context.Context, serverID string, req *servers.ServerUpdateRequest) (*ServerResource, error) {
+func (c *Client) Update(ctx context.Context, serverID string, req *servers.ServerUpdateRequest, callOpts ...internalhttp.CallOption) (*ServerResource, error) {
 	path := fmt.Sprintf("/api/v1/project/%s/servers/%s", c.projectID, serverID)
 
 	// Make request
 	httpReq := &internalhttp.Request{
-		Method: "PUT",
-		Path:   path,
-		Body:   req,
+		Method:    "PUT",
+		Path:      path,
+		Body:      req,
+		Operation: "vps.servers.Update",
 	}
 
 	var server servers.Server
-	if err := c.baseClient.Do(ctx, httpReq, &server); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &server, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to update server %s: %w", serverID, err)
 	}
 
 	// Wrap in ServerResource with sub-resource operations
-	return &ServerResource{
-		Server: &server,
-		nicOps: &NICsClient{
-			baseClient: c.baseClient,
-			projectID:  c.projectID,
-			serverID:   serverID,
-		},
-		volumeOps: &VolumesClient{
-			baseClient: c.baseClient,
-			projectID:  c.projectID,
-			serverID:   serverID,
-		},
-	}, nil
+	return c.newServerResource(&server, serverID), nil
 }
 
 // Delete removes a server instance.
 // DELETE /api/v1/project/{project-id}/servers/{svr-id}
-func (c *Client) Delete(ctx context.Context, serverID string) error {
+func (c *Client) Delete(ctx context.Context, serverID string, callOpts ...internalhttp.CallOption) error {
 	path := fmt.Sprintf("/api/v1/project/%s/servers/%s", c.projectID, serverID)
 
 	// Make request
 	req := &internalhttp.Request{
-		Method: "DELETE",
-		Path:   path,
+		Method:    "DELETE",
+		Path:      path,
+		Operation: "vps.servers.Delete",
 	}
 
-	if err := c.baseClient.Do(ctx, req, nil); err != nil {
+	if err := c.baseClient.Do(ctx, req, nil, callOpts...); err != nil {
 		return fmt.Errorf("failed to delete server %s: %w", serverID, err)
 	}
 
@@ -204,17 +200,18 @@ func (c *Client) Delete(ctx context.Context, serverID string) error {
 
 // Action performs a control action on a server.
 // POST /api/v1/project/{project-id}/servers/{svr-id}/action
-func (c *Client) Action(ctx context.Context, serverID string, req *servers.ServerActionRequest) error {
+func (c *Client) Action(ctx context.Context, serverID string, req *servers.ServerActionRequest, callOpts ...internalhttp.CallOption) error {
 	path := fmt.Sprintf("/api/v1/project/%s/servers/%s/action", c.projectID, serverID)
 
 	// Make request
 	httpReq := &internalhttp.Request{
-		Method: "POST",
-		Path:   path,
-		Body:   req,
+		Method:    "POST",
+		Path:      path,
+		Body:      req,
+		Operation: "vps.servers.Action",
 	}
 
-	if err := c.baseClient.Do(ctx, httpReq, nil); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, nil, callOpts...); err != nil {
 		return fmt.Errorf("failed to perform action on server %s: %w", serverID, err)
 	}
 
@@ -223,7 +220,7 @@ func (c *Client) Action(ctx context.Context, serverID string, req *servers.Serve
 
 // Metrics retrieves time-series metrics for a server.
 // GET /api/v1/project/{project-id}/servers/{svr-id}/metric
-func (c *Client) Metrics(ctx context.Context, serverID string, req *servers.ServerMetricsRequest) (*servers.ServerMetricsResponse, error) {
+func (c *Client) Metrics(ctx context.Context, serverID string, req *servers.ServerMetricsRequest, callOpts ...internalhttp.CallOption) (*servers.ServerMetricsResponse, error) {
 	path := fmt.Sprintf("/api/v1/project/%s/servers/%s/metric", c.projectID, serverID)
 
 	// Build query parameters
@@ -251,12 +248,13 @@ func (c *Client) Metrics(ctx context.Context, serverID string, req *servers.Serv
 
 	// Make request
 	httpReq := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.servers.Metrics",
 	}
 
 	var response servers.ServerMetricsResponse
-	if err := c.baseClient.Do(ctx, httpReq, &response); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to get metrics for server %s: %w", serverID, err)
 	}
 
@@ -265,17 +263,18 @@ func (c *Client) Metrics(ctx context.Context, serverID string, req *servers.Serv
 
 // GetVNCConsoleURL retrieves the VNC console URL for a server.
 // GET /api/v1/project/{project-id}/servers/{svr-id}/vnc_url
-func (c *Client) GetVNCConsoleURL(ctx context.Context, serverID string) (*servers.ServerConsoleURLResponse, error) {
+func (c *Client) GetVNCConsoleURL(ctx context.Context, serverID string, callOpts ...internalhttp.CallOption) (*servers.ServerConsoleURLResponse, error) {
 	path := fmt.Sprintf("/api/v1/project/%s/servers/%s/vnc_url", c.projectID, serverID)
 
 	// Make request
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.servers.GetVNCConsoleURL",
 	}
 
 	var response servers.ServerConsoleURLResponse
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to get VNC console URL for server %s: %w", serverID, err)
 	}
 
@@ -287,30 +286,61 @@ type ServerResource struct {
 	*servers.Server
 	nicOps    NICOperations
 	volumeOps VolumeOperations
+
+	// client and serverID back the sub-resource operations when nicOps and
+	// volumeOps are unset; the onces build each of them a single time
+	client      *Client
+	serverID    string
+	nicsOnce    sync.Once
+	volumesOnce sync.Once
+}
+
+// newServerResource wraps server for serverID without allocating its
+// sub-resource clients, which are only needed for a fraction of listed servers.
+func (c *Client) newServerResource(server *servers.Server, serverID string) *ServerResource {
+	return &ServerResource{Server: server, client: c, serverID: serverID}
+}
+
+// NewServerResource wraps server with the given NIC and volume operations.
+// Test doubles of ServersAPI use it to return servers with fake sub-resources.
+func NewServerResource(server *servers.Server, nics NICOperations, volumes VolumeOperations) *ServerResource {
+	return &ServerResource{Server: server, nicOps: nics, volumeOps: volumes}
 }
 
 // NICs returns the NIC operations for this server.
 func (sr *ServerResource) NICs() NICOperations {
+	if sr.client == nil {
+		return sr.nicOps
+	}
+	sr.nicsOnce.Do(func() {
+		sr.nicOps = &NICsClient{baseClient: sr.client.baseClient, projectID: sr.client.projectID, serverID: sr.serverID}
+	})
 	return sr.nicOps
 }
 
 // Volumes returns the volume operations for this server.
 func (sr *ServerResource) Volumes() VolumeOperations {
+	if sr.client == nil {
+		return sr.volumeOps
+	}
+	sr.volumesOnce.Do(func() {
+		sr.volumeOps = &VolumesClient{baseClient: sr.client.baseClient, projectID: sr.client.projectID, serverID: sr.serverID}
+	})
 	return sr.volumeOps
 }
 
 // NICOperations defines operations on server NICs (sub-resource).
 type NICOperations interface {
-	List(ctx context.Context) ([]*servers.ServerNIC, error)
-	Add(ctx context.Context, req *servers.ServerNICCreateRequest) (*servers.ServerNIC, error)
-	Update(ctx context.Context, nicID string, req *servers.ServerNICUpdateRequest) (*servers.ServerNIC, error)
-	Delete(ctx context.Context, nicID string) error
-	AssociateFloatingIP(ctx context.Context, nicID string, req *servers.ServerNICAssociateFloatingIPRequest) (*floatingips.FloatingIP, error)
+	List(ctx context.Context, callOpts ...internalhttp.CallOption) ([]*servers.ServerNIC, error)
+	Add(ctx context.Context, req *servers.ServerNICCreateRequest, callOpts ...internalhttp.CallOption) (*servers.ServerNIC, error)
+	Update(ctx context.Context, nicID string, req *servers.ServerNICUpdateRequest, callOpts ...internalhttp.CallOption) (*servers.ServerNIC, error)
+	Delete(ctx context.Context, nicID string, callOpts ...internalhttp.CallOption) error
+	AssociateFloatingIP(ctx context.Context, nicID string, req *servers.ServerNICAssociateFloatingIPRequest, callOpts ...internalhttp.CallOption) (*floatingips.FloatingIP, error)
 }
 
 // VolumeOperations defines operations on server volumes (sub-resource).
 type VolumeOperations interface {
-	List(ctx context.Context) ([]*servers.ServerVolume, error)
-	Attach(ctx context.Context, volumeID string) error
-	Detach(ctx context.Context, volumeID string) error
+	List(ctx context.Context, callOpts ...internalhttp.CallOption) ([]*servers.ServerVolume, error)
+	Attach(ctx context.Context, volumeID string, callOpts ...internalhttp.CallOption) error
+	Detach(ctx context.Context, volumeID string, callOpts ...internalhttp.CallOption) error
 }
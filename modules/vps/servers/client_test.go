@@ -120,6 +120,62 @@ func TestClient_List(t *testing.T) {
 	}
 }
 
+func TestClient_List_SubResources(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v1/project/proj-123/servers":
+			_ = json.NewEncoder(w).Encode(&servers.ServersListResponse{
+				Servers: []*servers.Server{{ID: "svr-1"}, {ID: "svr-2"}},
+			})
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	baseClient := internalhttp.NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil)
+	client := NewClient(baseClient, "proj-123")
+
+	result, err := client.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, sr := range result {
+		if sr.nicOps != nil || sr.volumeOps != nil {
+			t.Fatal("expected sub-resource clients not to be allocated by List")
+		}
+	}
+
+	if result[1].NICs() != result[1].NICs() || result[1].Volumes() != result[1].Volumes() {
+		t.Error("expected each server to reuse its sub-resource clients")
+	}
+	if result[0].NICs() == result[1].NICs() {
+		t.Error("expected servers not to share NIC clients")
+	}
+
+	if _, err := result[1].NICs().List(context.Background()); err != nil {
+		t.Fatalf("NICs().List() failed: %v", err)
+	}
+	if _, err := result[1].Volumes().List(context.Background()); err != nil {
+		t.Fatalf("Volumes().List() failed: %v", err)
+	}
+	want := []string{
+		"/api/v1/project/proj-123/servers",
+		"/api/v1/project/proj-123/servers/svr-2/nics",
+		"/api/v1/project/proj-123/servers/svr-2/volumes",
+	}
+	if len(paths) != len(want) {
+		t.Fatalf("expected paths %v, got %v", want, paths)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("expected path %s, got %s", want[i], paths[i])
+		}
+	}
+}
+
 func TestClient_Create(t *testing.T) {
 	mockResponse := &servers.Server{
 		ID:        "svr-new",
@@ -356,3 +412,137 @@ func TestClient_VNCURL(t *testing.T) {
 		t.Errorf("expected URL %s, got %s", mockResponse.URL, result.URL)
 	}
 }
+
+func TestClient_Create_Reconcile(t *testing.T) {
+	recent := time.Now().UTC().Format(time.RFC3339)
+	old := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	tests := []struct {
+		name          string
+		reconcile     bool
+		createStatus  int
+		listServers   []*servers.Server
+		wantErr       bool
+		wantID        string
+		wantListCalls int
+	}{
+		{
+			name:          "ambiguous failure reconciled by name",
+			reconcile:     true,
+			createStatus:  http.StatusBadGateway,
+			listServers:   []*servers.Server{{ID: "svr-1", Name: "web", CreatedAt: recent}, {ID: "svr-2", Name: "web-2", CreatedAt: recent}},
+			wantID:        "svr-1",
+			wantListCalls: 1,
+		},
+		{
+			name:          "older server with the same name is not reconciled",
+			reconcile:     true,
+			createStatus:  http.StatusBadGateway,
+			listServers:   []*servers.Server{{ID: "svr-1", Name: "web", CreatedAt: old}},
+			wantErr:       true,
+			wantListCalls: 1,
+		},
+		{
+			name:          "only servers created since the call are considered",
+			reconcile:     true,
+			createStatus:  http.StatusBadGateway,
+			listServers:   []*servers.Server{{ID: "svr-1", Name: "web", CreatedAt: old}, {ID: "svr-2", Name: "web", CreatedAt: recent}},
+			wantID:        "svr-2",
+			wantListCalls: 1,
+		},
+		{
+			name:          "reconciliation disabled",
+			reconcile:     false,
+			createStatus:  http.StatusBadGateway,
+			listServers:   []*servers.Server{{ID: "svr-1", Name: "web"}},
+			wantErr:       true,
+			wantListCalls: 0,
+		},
+		{
+			name:          "client error is not ambiguous",
+			reconcile:     true,
+			createStatus:  http.StatusBadRequest,
+			listServers:   []*servers.Server{{ID: "svr-1", Name: "web"}},
+			wantErr:       true,
+			wantListCalls: 0,
+		},
+		{
+			name:          "multiple matches are not reconciled",
+			reconcile:     true,
+			createStatus:  http.StatusInternalServerError,
+			listServers:   []*servers.Server{{ID: "svr-1", Name: "web", CreatedAt: recent}, {ID: "svr-2", Name: "web", CreatedAt: recent}},
+			wantErr:       true,
+			wantListCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listCalls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					w.WriteHeader(tt.createStatus)
+					return
+				}
+				listCalls++
+				if r.URL.Query().Get("name") != "web" {
+					t.Errorf("expected name filter 'web', got %q", r.URL.RawQuery)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(servers.ServersListResponse{Servers: tt.listServers})
+			}))
+			defer server.Close()
+
+			baseClient := internalhttp.NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil)
+			client := NewClient(baseClient, "proj-123")
+
+			var md internalhttp.ResponseMetadata
+			ctx := internalhttp.WithCallOptions(context.Background(), internalhttp.WithCallResponseMetadata(&md))
+			if tt.reconcile {
+				ctx = internalhttp.WithCallOptions(ctx, internalhttp.WithCallReconcile())
+			}
+
+			result, err := client.Create(ctx, &servers.ServerCreateRequest{Name: "web"})
+			if md.StatusCode != tt.createStatus {
+				t.Errorf("expected the create's metadata (status %d), got status %d", tt.createStatus, md.StatusCode)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && result.ID != tt.wantID {
+				t.Errorf("expected server %s, got %s", tt.wantID, result.ID)
+			}
+			if listCalls != tt.wantListCalls {
+				t.Errorf("expected %d list calls, got %d", tt.wantListCalls, listCalls)
+			}
+		})
+	}
+}
+
+func TestClient_Create_ReconcileAfterDeadline(t *testing.T) {
+	created := time.Now().UTC().Format(time.RFC3339)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			// The server creates the server but answers after the caller's deadline
+			<-release
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(servers.ServersListResponse{Servers: []*servers.Server{{ID: "svr-1", Name: "web", CreatedAt: created}}})
+	}))
+	defer server.Close()
+	defer close(release)
+
+	baseClient := internalhttp.NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil)
+	client := NewClient(baseClient, "proj-123")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	result, err := client.Create(ctx, &servers.ServerCreateRequest{Name: "web"}, internalhttp.WithCallReconcile())
+	if err != nil {
+		t.Fatalf("expected the lookup to reconcile the timed out create, got %v", err)
+	}
+	if result.ID != "svr-1" {
+		t.Errorf("expected server svr-1, got %s", result.ID)
+	}
+}
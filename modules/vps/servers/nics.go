@@ -18,17 +18,18 @@ type NICsClient struct {
 
 // List lists all network interfaces on the server.
 // GET /api/v1/project/{project-id}/servers/{svr-id}/nics
-func (c *NICsClient) List(ctx context.Context) ([]*servers.ServerNIC, error) {
+func (c *NICsClient) List(ctx context.Context, callOpts ...internalhttp.CallOption) ([]*servers.ServerNIC, error) {
 	path := fmt.Sprintf("/api/v1/project/%s/servers/%s/nics", c.projectID, c.serverID)
 
 	// Make request
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.servers.nics.List",
 	}
 
 	var response servers.ServerNICsListResponse
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to list NICs for server %s: %w", c.serverID, err)
 	}
 
@@ -37,18 +38,19 @@ func (c *NICsClient) List(ctx context.Context) ([]*servers.ServerNIC, error) {
 
 // Add attaches a new vNIC to the server.
 // POST /api/v1/project/{project-id}/servers/{svr-id}/nics
-func (c *NICsClient) Add(ctx context.Context, req *servers.ServerNICCreateRequest) (*servers.ServerNIC, error) {
+func (c *NICsClient) Add(ctx context.Context, req *servers.ServerNICCreateRequest, callOpts ...internalhttp.CallOption) (*servers.ServerNIC, error) {
 	path := fmt.Sprintf("/api/v1/project/%s/servers/%s/nics", c.projectID, c.serverID)
 
 	// Make request
 	httpReq := &internalhttp.Request{
-		Method: "POST",
-		Path:   path,
-		Body:   req,
+		Method:    "POST",
+		Path:      path,
+		Body:      req,
+		Operation: "vps.servers.nics.Add",
 	}
 
 	var nic servers.ServerNIC
-	if err := c.baseClient.Do(ctx, httpReq, &nic); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &nic, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to add NIC to server %s: %w", c.serverID, err)
 	}
 
@@ -57,18 +59,19 @@ func (c *NICsClient) Add(ctx context.Context, req *servers.ServerNICCreateReques
 
 // Update updates security groups on an existing vNIC.
 // PUT /api/v1/project/{project-id}/servers/{svr-id}/nics/{nic-id}
-func (c *NICsClient) Update(ctx context.Context, nicID string, req *servers.ServerNICUpdateRequest) (*servers.ServerNIC, error) {
+func (c *NICsClient) Update(ctx context.Context, nicID string, req *servers.ServerNICUpdateRequest, callOpts ...internalhttp.CallOption) (*servers.ServerNIC, error) {
 	path := fmt.Sprintf("/api/v1/project/%s/servers/%s/nics/%s", c.projectID, c.serverID, nicID)
 
 	// Make request
 	httpReq := &internalhttp.Request{
-		Method: "PUT",
-		Path:   path,
-		Body:   req,
+		Method:    "PUT",
+		Path:      path,
+		Body:      req,
+		Operation: "vps.servers.nics.Update",
 	}
 
 	var nic servers.ServerNIC
-	if err := c.baseClient.Do(ctx, httpReq, &nic); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &nic, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to update NIC %s for server %s: %w", nicID, c.serverID, err)
 	}
 
@@ -77,16 +80,17 @@ func (c *NICsClient) Update(ctx context.Context, nicID string, req *servers.Serv
 
 // Delete detaches and removes a vNIC from the server.
 // DELETE /api/v1/project/{project-id}/servers/{svr-id}/nics/{nic-id}
-func (c *NICsClient) Delete(ctx context.Context, nicID string) error {
+func (c *NICsClient) Delete(ctx context.Context, nicID string, callOpts ...internalhttp.CallOption) error {
 	path := fmt.Sprintf("/api/v1/project/%s/servers/%s/nics/%s", c.projectID, c.serverID, nicID)
 
 	// Make request
 	req := &internalhttp.Request{
-		Method: "DELETE",
-		Path:   path,
+		Method:    "DELETE",
+		Path:      path,
+		Operation: "vps.servers.nics.Delete",
 	}
 
-	if err := c.baseClient.Do(ctx, req, nil); err != nil {
+	if err := c.baseClient.Do(ctx, req, nil, callOpts...); err != nil {
 		return fmt.Errorf("failed to delete NIC %s from server %s: %w", nicID, c.serverID, err)
 	}
 
@@ -95,18 +99,19 @@ func (c *NICsClient) Delete(ctx context.Context, nicID string) error {
 
 // AssociateFloatingIP associates a floating IP to a specific vNIC.
 // POST /api/v1/project/{project-id}/servers/{svr-id}/nics/{nic-id}/floatingip
-func (c *NICsClient) AssociateFloatingIP(ctx context.Context, nicID string, req *servers.ServerNICAssociateFloatingIPRequest) (*floatingips.FloatingIP, error) {
+func (c *NICsClient) AssociateFloatingIP(ctx context.Context, nicID string, req *servers.ServerNICAssociateFloatingIPRequest, callOpts ...internalhttp.CallOption) (*floatingips.FloatingIP, error) {
 	path := fmt.Sprintf("/api/v1/project/%s/servers/%s/nics/%s/floatingip", c.projectID, c.serverID, nicID)
 
 	// Make request
 	httpReq := &internalhttp.Request{
-		Method: "POST",
-		Path:   path,
-		Body:   req,
+		Method:    "POST",
+		Path:      path,
+		Body:      req,
+		Operation: "vps.servers.nics.AssociateFloatingIP",
 	}
 
 	var response floatingips.FloatingIP
-	if err := c.baseClient.Do(ctx, httpReq, &response); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to associate floating IP to NIC %s for server %s: %w", nicID, c.serverID, err)
 	}
 
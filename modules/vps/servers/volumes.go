@@ -17,17 +17,18 @@ type VolumesClient struct {
 
 // List lists all volume attachments for the server.
 // GET /api/v1/project/{project-id}/servers/{svr-id}/volumes
-func (c *VolumesClient) List(ctx context.Context) ([]*servers.ServerVolume, error) {
+func (c *VolumesClient) List(ctx context.Context, callOpts ...internalhttp.CallOption) ([]*servers.ServerVolume, error) {
 	path := fmt.Sprintf("/api/v1/project/%s/servers/%s/volumes", c.projectID, c.serverID)
 
 	// Make request
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.servers.volumes.List",
 	}
 
 	var response servers.ServerVolumesResponse
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to list volumes for server %s: %w", c.serverID, err)
 	}
 
@@ -36,16 +37,17 @@ func (c *VolumesClient) List(ctx context.Context) ([]*servers.ServerVolume, erro
 
 // Attach attaches a volume to the server.
 // POST /api/v1/project/{project-id}/servers/{svr-id}/volumes/{vol-id}
-func (c *VolumesClient) Attach(ctx context.Context, volumeID string) error {
+func (c *VolumesClient) Attach(ctx context.Context, volumeID string, callOpts ...internalhttp.CallOption) error {
 	path := fmt.Sprintf("/api/v1/project/%s/servers/%s/volumes/%s", c.projectID, c.serverID, volumeID)
 
 	// Make request
 	req := &internalhttp.Request{
-		Method: "POST",
-		Path:   path,
+		Method:    "POST",
+		Path:      path,
+		Operation: "vps.servers.volumes.Attach",
 	}
 
-	if err := c.baseClient.Do(ctx, req, nil); err != nil {
+	if err := c.baseClient.Do(ctx, req, nil, callOpts...); err != nil {
 		return fmt.Errorf("failed to attach volume %s to server %s: %w", volumeID, c.serverID, err)
 	}
 
@@ -54,16 +56,17 @@ func (c *VolumesClient) Attach(ctx context.Context, volumeID string) error {
 
 // Detach detaches a volume from the server.
 // DELETE /api/v1/project/{project-id}/servers/{svr-id}/volumes/{vol-id}
-func (c *VolumesClient) Detach(ctx context.Context, volumeID string) error {
+func (c *VolumesClient) Detach(ctx context.Context, volumeID string, callOpts ...internalhttp.CallOption) error {
 	path := fmt.Sprintf("/api/v1/project/%s/servers/%s/volumes/%s", c.projectID, c.serverID, volumeID)
 
 	// Make request
 	req := &internalhttp.Request{
-		Method: "DELETE",
-		Path:   path,
+		Method:    "DELETE",
+		Path:      path,
+		Operation: "vps.servers.volumes.Detach",
 	}
 
-	if err := c.baseClient.Do(ctx, req, nil); err != nil {
+	if err := c.baseClient.Do(ctx, req, nil, callOpts...); err != nil {
 		return fmt.Errorf("failed to detach volume %s from server %s: %w", volumeID, c.serverID, err)
 	}
 
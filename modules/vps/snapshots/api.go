@@ -0,0 +1,21 @@
+package snapshots
+
+import (
+	"context"
+
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+	snapshotsmodel "github.com/Zillaforge/cloud-sdk/models/vps/snapshots"
+)
+
+//go:generate go run github.com/Zillaforge/cloud-sdk/hack/mockgen
+
+// SnapshotsAPI manages volume snapshots. Client implements it.
+type SnapshotsAPI interface {
+	Create(ctx context.Context, req *snapshotsmodel.CreateSnapshotRequest, callOpts ...internalhttp.CallOption) (*snapshotsmodel.Snapshot, error)
+	List(ctx context.Context, opts *snapshotsmodel.ListSnapshotsOptions, callOpts ...internalhttp.CallOption) ([]*snapshotsmodel.Snapshot, error)
+	Get(ctx context.Context, id string, callOpts ...internalhttp.CallOption) (*snapshotsmodel.Snapshot, error)
+	Update(ctx context.Context, id string, reqBody *snapshotsmodel.UpdateSnapshotRequest, callOpts ...internalhttp.CallOption) (*snapshotsmodel.Snapshot, error)
+	Delete(ctx context.Context, id string, callOpts ...internalhttp.CallOption) error
+}
+
+var _ SnapshotsAPI = (*Client)(nil)
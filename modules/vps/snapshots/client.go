@@ -22,17 +22,17 @@ func NewClient(baseClient *internalhttp.Client, projectID string) *Client {
 }
 
 // Create creates a new snapshot.
-func (c *Client) Create(ctx context.Context, req *snapshotsmodel.CreateSnapshotRequest) (*snapshotsmodel.Snapshot, error) {
+func (c *Client) Create(ctx context.Context, req *snapshotsmodel.CreateSnapshotRequest, callOpts ...internalhttp.CallOption) (*snapshotsmodel.Snapshot, error) {
 	if err := req.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
 	path := c.basePath + "/snapshots"
 
-	r := &internalhttp.Request{Method: "POST", Path: path, Body: req}
+	r := &internalhttp.Request{Method: "POST", Path: path, Body: req, Operation: "vps.snapshots.Create"}
 
 	var resp snapshotsmodel.Snapshot
-	if err := c.baseClient.Do(ctx, r, &resp); err != nil {
+	if err := c.baseClient.Do(ctx, r, &resp, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to create snapshot: %w", err)
 	}
 
@@ -40,7 +40,7 @@ func (c *Client) Create(ctx context.Context, req *snapshotsmodel.CreateSnapshotR
 }
 
 // List retrieves a list of snapshots with optional filters.
-func (c *Client) List(ctx context.Context, opts *snapshotsmodel.ListSnapshotsOptions) ([]*snapshotsmodel.Snapshot, error) {
+func (c *Client) List(ctx context.Context, opts *snapshotsmodel.ListSnapshotsOptions, callOpts ...internalhttp.CallOption) ([]*snapshotsmodel.Snapshot, error) {
 	path := c.basePath + "/snapshots"
 
 	query := url.Values{}
@@ -63,10 +63,10 @@ func (c *Client) List(ctx context.Context, opts *snapshotsmodel.ListSnapshotsOpt
 		path += "?" + query.Encode()
 	}
 
-	req := &internalhttp.Request{Method: "GET", Path: path}
+	req := &internalhttp.Request{Method: "GET", Path: path, Operation: "vps.snapshots.List"}
 
 	var response snapshotsmodel.SnapshotListResponse
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to list snapshots: %w", err)
 	}
 
@@ -74,13 +74,13 @@ func (c *Client) List(ctx context.Context, opts *snapshotsmodel.ListSnapshotsOpt
 }
 
 // Get retrieves a specific snapshot by id.
-func (c *Client) Get(ctx context.Context, id string) (*snapshotsmodel.Snapshot, error) {
+func (c *Client) Get(ctx context.Context, id string, callOpts ...internalhttp.CallOption) (*snapshotsmodel.Snapshot, error) {
 	path := fmt.Sprintf("%s/snapshots/%s", c.basePath, id)
 
-	req := &internalhttp.Request{Method: "GET", Path: path}
+	req := &internalhttp.Request{Method: "GET", Path: path, Operation: "vps.snapshots.Get"}
 
 	var response snapshotsmodel.Snapshot
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to get snapshot %s: %w", id, err)
 	}
 
@@ -88,16 +88,16 @@ func (c *Client) Get(ctx context.Context, id string) (*snapshotsmodel.Snapshot,
 }
 
 // Update updates snapshot metadata (e.g., rename).
-func (c *Client) Update(ctx context.Context, id string, reqBody *snapshotsmodel.UpdateSnapshotRequest) (*snapshotsmodel.Snapshot, error) {
+func (c *Client) Update(ctx context.Context, id string, reqBody *snapshotsmodel.UpdateSnapshotRequest, callOpts ...internalhttp.CallOption) (*snapshotsmodel.Snapshot, error) {
 	if err := reqBody.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 	path := fmt.Sprintf("%s/snapshots/%s", c.basePath, id)
 
-	req := &internalhttp.Request{Method: "PUT", Path: path, Body: reqBody}
+	req := &internalhttp.Request{Method: "PUT", Path: path, Body: reqBody, Operation: "vps.snapshots.Update"}
 
 	var response snapshotsmodel.Snapshot
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to update snapshot %s: %w", id, err)
 	}
 
@@ -105,11 +105,11 @@ func (c *Client) Update(ctx context.Context, id string, reqBody *snapshotsmodel.
 }
 
 // Delete deletes a snapshot by id.
-func (c *Client) Delete(ctx context.Context, id string) error {
+func (c *Client) Delete(ctx context.Context, id string, callOpts ...internalhttp.CallOption) error {
 	path := fmt.Sprintf("%s/snapshots/%s", c.basePath, id)
-	req := &internalhttp.Request{Method: "DELETE", Path: path}
+	req := &internalhttp.Request{Method: "DELETE", Path: path, Operation: "vps.snapshots.Delete"}
 
-	if err := c.baseClient.Do(ctx, req, nil); err != nil {
+	if err := c.baseClient.Do(ctx, req, nil, callOpts...); err != nil {
 		return fmt.Errorf("failed to delete snapshot %s: %w", id, err)
 	}
 	return nil
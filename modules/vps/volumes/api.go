@@ -0,0 +1,22 @@
+package volumes
+
+import (
+	"context"
+
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+	volumesmodel "github.com/Zillaforge/cloud-sdk/models/vps/volumes"
+)
+
+//go:generate go run github.com/Zillaforge/cloud-sdk/hack/mockgen
+
+// VolumesAPI manages block storage volumes. Client implements it.
+type VolumesAPI interface {
+	Create(ctx context.Context, request *volumesmodel.CreateVolumeRequest, callOpts ...internalhttp.CallOption) (*volumesmodel.Volume, error)
+	Update(ctx context.Context, volumeID string, request *volumesmodel.UpdateVolumeRequest, callOpts ...internalhttp.CallOption) (*volumesmodel.Volume, error)
+	Delete(ctx context.Context, volumeID string, callOpts ...internalhttp.CallOption) error
+	List(ctx context.Context, opts *volumesmodel.ListVolumesOptions, callOpts ...internalhttp.CallOption) ([]*volumesmodel.Volume, error)
+	Get(ctx context.Context, volumeID string, callOpts ...internalhttp.CallOption) (*volumesmodel.Volume, error)
+	Action(ctx context.Context, volumeID string, request *volumesmodel.VolumeActionRequest, callOpts ...internalhttp.CallOption) error
+}
+
+var _ VolumesAPI = (*Client)(nil)
@@ -30,7 +30,7 @@ func NewClient(baseClient *internalhttp.Client, projectID string) *Client {
 
 // Create creates a new volume.
 // POST /api/v1/project/{project-id}/volumes
-func (c *Client) Create(ctx context.Context, request *volumesmodel.CreateVolumeRequest) (*volumesmodel.Volume, error) {
+func (c *Client) Create(ctx context.Context, request *volumesmodel.CreateVolumeRequest, callOpts ...internalhttp.CallOption) (*volumesmodel.Volume, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
@@ -38,13 +38,14 @@ func (c *Client) Create(ctx context.Context, request *volumesmodel.CreateVolumeR
 	path := c.basePath + "/volumes"
 
 	req := &internalhttp.Request{
-		Method: "POST",
-		Path:   path,
-		Body:   request,
+		Method:    "POST",
+		Path:      path,
+		Body:      request,
+		Operation: "vps.volumes.Create",
 	}
 
 	var response volumesmodel.Volume
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to create volume: %w", err)
 	}
 
@@ -53,7 +54,7 @@ func (c *Client) Create(ctx context.Context, request *volumesmodel.CreateVolumeR
 
 // Update updates volume metadata (name, description).
 // PUT /api/v1/project/{project-id}/volumes/{volume-id}
-func (c *Client) Update(ctx context.Context, volumeID string, request *volumesmodel.UpdateVolumeRequest) (*volumesmodel.Volume, error) {
+func (c *Client) Update(ctx context.Context, volumeID string, request *volumesmodel.UpdateVolumeRequest, callOpts ...internalhttp.CallOption) (*volumesmodel.Volume, error) {
 	if err := request.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
@@ -61,13 +62,14 @@ func (c *Client) Update(ctx context.Context, volumeID string, request *volumesmo
 	path := fmt.Sprintf("%s/volumes/%s", c.basePath, volumeID)
 
 	req := &internalhttp.Request{
-		Method: "PUT",
-		Path:   path,
-		Body:   request,
+		Method:    "PUT",
+		Path:      path,
+		Body:      request,
+		Operation: "vps.volumes.Update",
 	}
 
 	var response volumesmodel.Volume
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to update volume %s: %w", volumeID, err)
 	}
 
@@ -76,15 +78,16 @@ func (c *Client) Update(ctx context.Context, volumeID string, request *volumesmo
 
 // Delete deletes a volume.
 // DELETE /api/v1/project/{project-id}/volumes/{volume-id}
-func (c *Client) Delete(ctx context.Context, volumeID string) error {
+func (c *Client) Delete(ctx context.Context, volumeID string, callOpts ...internalhttp.CallOption) error {
 	path := fmt.Sprintf("%s/volumes/%s", c.basePath, volumeID)
 
 	req := &internalhttp.Request{
-		Method: "DELETE",
-		Path:   path,
+		Method:    "DELETE",
+		Path:      path,
+		Operation: "vps.volumes.Delete",
 	}
 
-	if err := c.baseClient.Do(ctx, req, nil); err != nil {
+	if err := c.baseClient.Do(ctx, req, nil, callOpts...); err != nil {
 		return fmt.Errorf("failed to delete volume %s: %w", volumeID, err)
 	}
 
@@ -93,7 +96,7 @@ func (c *Client) Delete(ctx context.Context, volumeID string) error {
 
 // List retrieves a list of volumes with optional filtering.
 // GET /api/v1/project/{project-id}/volumes
-func (c *Client) List(ctx context.Context, opts *volumesmodel.ListVolumesOptions) ([]*volumesmodel.Volume, error) {
+func (c *Client) List(ctx context.Context, opts *volumesmodel.ListVolumesOptions, callOpts ...internalhttp.CallOption) ([]*volumesmodel.Volume, error) {
 	path := c.basePath + "/volumes"
 
 	// Build query parameters
@@ -121,12 +124,13 @@ func (c *Client) List(ctx context.Context, opts *volumesmodel.ListVolumesOptions
 	}
 
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.volumes.List",
 	}
 
 	var response volumesmodel.VolumeListResponse
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to list volumes: %w", err)
 	}
 
@@ -135,16 +139,17 @@ func (c *Client) List(ctx context.Context, opts *volumesmodel.ListVolumesOptions
 
 // Get retrieves details of a specific volume by ID.
 // GET /api/v1/project/{project-id}/volumes/{volume-id}
-func (c *Client) Get(ctx context.Context, volumeID string) (*volumesmodel.Volume, error) {
+func (c *Client) Get(ctx context.Context, volumeID string, callOpts ...internalhttp.CallOption) (*volumesmodel.Volume, error) {
 	path := fmt.Sprintf("%s/volumes/%s", c.basePath, volumeID)
 
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.volumes.Get",
 	}
 
 	var response volumesmodel.Volume
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to get volume %s: %w", volumeID, err)
 	}
 
@@ -154,7 +159,7 @@ func (c *Client) Get(ctx context.Context, volumeID string) (*volumesmodel.Volume
 // Action performs an action on a volume (attach, detach, extend, revert).
 // POST /api/v1/project/{project-id}/volumes/{volume-id}/action
 // Returns 202 Accepted for async operations.
-func (c *Client) Action(ctx context.Context, volumeID string, request *volumesmodel.VolumeActionRequest) error {
+func (c *Client) Action(ctx context.Context, volumeID string, request *volumesmodel.VolumeActionRequest, callOpts ...internalhttp.CallOption) error {
 	if err := request.Validate(); err != nil {
 		return fmt.Errorf("invalid request: %w", err)
 	}
@@ -162,12 +167,13 @@ func (c *Client) Action(ctx context.Context, volumeID string, request *volumesmo
 	path := fmt.Sprintf("%s/volumes/%s/action", c.basePath, volumeID)
 
 	req := &internalhttp.Request{
-		Method: "POST",
-		Path:   path,
-		Body:   request,
+		Method:    "POST",
+		Path:      path,
+		Body:      request,
+		Operation: "vps.volumes.Action",
 	}
 
-	if err := c.baseClient.Do(ctx, req, nil); err != nil {
+	if err := c.baseClient.Do(ctx, req, nil, callOpts...); err != nil {
 		return fmt.Errorf("failed to perform action %s on volume %s: %w", request.Action, volumeID, err)
 	}
 
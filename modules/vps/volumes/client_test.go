@@ -10,6 +10,7 @@ import (
 	"time"
 
 	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+	"github.com/Zillaforge/cloud-sdk/internal/types"
 	"github.com/Zillaforge/cloud-sdk/models/vps/common"
 	volumesmodel "github.com/Zillaforge/cloud-sdk/models/vps/volumes"
 )
@@ -695,6 +696,26 @@ func TestVolumes_Create_Contract(t *testing.T) {
 	}
 }
 
+func TestClient_Create_QuotaExceeded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": "Quota exceeded"}`))
+	}))
+	defer server.Close()
+
+	baseClient := internalhttp.NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil)
+	client := NewClient(baseClient, "proj-123")
+
+	_, err := client.Create(context.Background(), &volumesmodel.CreateVolumeRequest{Name: "test-volume", Type: "SSD", Size: 10000})
+	if !errors.Is(err, types.ErrQuotaExceeded) {
+		t.Fatalf("expected a quota error, got %v", err)
+	}
+	if errors.Is(err, types.ErrPermissionDenied) {
+		t.Errorf("expected the quota error not to be a permission error, got %v", err)
+	}
+}
+
 // T031: Contract test for PUT /volumes/{id} (update)
 func TestVolumes_Update_Contract(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,16 @@
+package volumetypes
+
+import (
+	"context"
+
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+)
+
+//go:generate go run github.com/Zillaforge/cloud-sdk/hack/mockgen
+
+// VolumeTypesAPI lists the volume types a project can use. Client implements it.
+type VolumeTypesAPI interface {
+	List(ctx context.Context, callOpts ...internalhttp.CallOption) ([]string, error)
+}
+
+var _ VolumeTypesAPI = (*Client)(nil)
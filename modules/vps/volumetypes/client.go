@@ -28,16 +28,17 @@ func NewClient(baseClient *internalhttp.Client, projectID string) *Client {
 
 // List retrieves a list of available volume types in the project.
 // GET /api/v1/project/{project-id}/volume_types
-func (c *Client) List(ctx context.Context) ([]string, error) {
+func (c *Client) List(ctx context.Context, callOpts ...internalhttp.CallOption) ([]string, error) {
 	path := c.basePath + "/volume_types"
 
 	req := &internalhttp.Request{
-		Method: "GET",
-		Path:   path,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vps.volumetypes.List",
 	}
 
 	var response volumetypesmodel.VolumeTypeListResponse
-	if err := c.baseClient.Do(ctx, req, &response); err != nil {
+	if err := c.baseClient.Do(ctx, req, &response, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to list volume types: %w", err)
 	}
 
@@ -0,0 +1,17 @@
+package vrm
+
+import (
+	"github.com/Zillaforge/cloud-sdk/modules/vrm/repositories"
+	"github.com/Zillaforge/cloud-sdk/modules/vrm/tags"
+)
+
+//go:generate go run github.com/Zillaforge/cloud-sdk/hack/mockgen
+
+// API is the project-scoped VRM service implemented by Client.
+type API interface {
+	ProjectID() string
+	Repositories() repositories.RepositoriesAPI
+	Tags() tags.TagsAPI
+}
+
+var _ API = (*Client)(nil)
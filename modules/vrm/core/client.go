@@ -12,24 +12,32 @@ import (
 
 // Client provides access to VRM operations for a specific project.
 // All operations are scoped to the project ID provided at creation.
-// It provides sub-clients for repositories and tags management.
+// It provides sub-clients for repositories and tags management, created once
+// and safe for concurrent use.
 type Client struct {
 	baseClient *internalhttp.Client
 	projectID  string
 	basePath   string
+
+	repositories *repositories.Client
+	tags         *tags.Client
 }
 
 // NewClient creates a new project-scoped VRM client.
 // This is typically called via cloudsdk.Client.Project(projectID).VRM().
 // The client is configured with the provided base URL, authentication token,
 // and project scope. It uses the provided HTTP client and logger for operations.
-func NewClient(baseURL, token, projectID string, httpClient *http.Client, logger types.Logger) *Client {
+func NewClient(baseURL, token, projectID string, httpClient *http.Client, logger types.Logger, opts ...internalhttp.Option) *Client {
 	basePath := "/api/v1/project/" + projectID
 
+	baseClient := internalhttp.NewClient(baseURL, token, httpClient, logger, opts...)
+
 	return &Client{
-		baseClient: internalhttp.NewClient(baseURL, token, httpClient, logger),
-		projectID:  projectID,
-		basePath:   basePath,
+		baseClient:   baseClient,
+		projectID:    projectID,
+		basePath:     basePath,
+		repositories: repositories.NewClient(baseClient, projectID, basePath),
+		tags:         tags.NewClient(baseClient, projectID, basePath),
 	}
 }
 
@@ -41,12 +49,12 @@ func (c *Client) ProjectID() string {
 
 // Repositories returns the repository operations client.
 // Use this client to perform CRUD operations on VRM repositories within the project scope.
-func (c *Client) Repositories() *repositories.Client {
-	return repositories.NewClient(c.baseClient, c.projectID, c.basePath)
+func (c *Client) Repositories() repositories.RepositoriesAPI {
+	return c.repositories
 }
 
 // Tags returns the tag operations client.
 // Use this client to perform CRUD operations on VRM tags within the project scope.
-func (c *Client) Tags() *tags.Client {
-	return tags.NewClient(c.baseClient, c.projectID, c.basePath)
+func (c *Client) Tags() tags.TagsAPI {
+	return c.tags
 }
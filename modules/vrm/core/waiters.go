@@ -5,15 +5,15 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/Zillaforge/cloud-sdk/internal/waiter"
 	commonmodels "github.com/Zillaforge/cloud-sdk/models/vrm/common"
 	"github.com/Zillaforge/cloud-sdk/modules/vrm/tags"
+	"github.com/Zillaforge/cloud-sdk/waiter"
 )
 
 // TagWaiterConfig holds configuration for tag state waiting.
 type TagWaiterConfig struct {
 	// Client is the tags client used to poll tag state
-	Client *tags.Client
+	Client tags.TagsAPI
 
 	// TagID is the ID of the tag to monitor
 	TagID string
@@ -94,7 +94,7 @@ func WaitForTagStatus(ctx context.Context, cfg TagWaiterConfig) error {
 }
 
 // WaitForTagActive is a convenience function that waits for a tag to become ACTIVE.
-func WaitForTagActive(ctx context.Context, client *tags.Client, tagID string, opts ...waiter.Option) error {
+func WaitForTagActive(ctx context.Context, client tags.TagsAPI, tagID string, opts ...waiter.Option) error {
 	return WaitForTagStatus(ctx, TagWaiterConfig{
 		Client:        client,
 		TagID:         tagID,
@@ -104,7 +104,7 @@ func WaitForTagActive(ctx context.Context, client *tags.Client, tagID string, op
 }
 
 // WaitForTagAvailable is a convenience function that waits for a tag to become AVAILABLE.
-func WaitForTagAvailable(ctx context.Context, client *tags.Client, tagID string, opts ...waiter.Option) error {
+func WaitForTagAvailable(ctx context.Context, client tags.TagsAPI, tagID string, opts ...waiter.Option) error {
 	return WaitForTagStatus(ctx, TagWaiterConfig{
 		Client:        client,
 		TagID:         tagID,
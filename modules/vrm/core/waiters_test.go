@@ -9,9 +9,9 @@ import (
 	"time"
 
 	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
-	"github.com/Zillaforge/cloud-sdk/internal/waiter"
 	commonmodels "github.com/Zillaforge/cloud-sdk/models/vrm/common"
 	"github.com/Zillaforge/cloud-sdk/modules/vrm/tags"
+	"github.com/Zillaforge/cloud-sdk/waiter"
 )
 
 // TestWaitForTagStatus_Success verifies waiting for a tag to reach target status.
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+	repmod "github.com/Zillaforge/cloud-sdk/models/vrm/repositories"
+	"github.com/Zillaforge/cloud-sdk/pager"
+)
+
+//go:generate go run github.com/Zillaforge/cloud-sdk/hack/mockgen
+
+// RepositoriesAPI manages image repositories, including uploads and server
+// snapshots. Client implements it; tags of a repository are reached through
+// the returned RepositoryResource.
+type RepositoriesAPI interface {
+	List(ctx context.Context, opts *repmod.ListRepositoriesOptions, callOpts ...internalhttp.CallOption) ([]*RepositoryResource, error)
+	Iterate(ctx context.Context, opts *repmod.ListRepositoriesOptions, callOpts ...internalhttp.CallOption) *pager.Iterator[*RepositoryResource]
+	ListAll(ctx context.Context, opts *repmod.ListRepositoriesOptions, callOpts ...internalhttp.CallOption) ([]*RepositoryResource, error)
+	Create(ctx context.Context, req *repmod.CreateRepositoryRequest, callOpts ...internalhttp.CallOption) (*RepositoryResource, error)
+	// Deprecated: Use Create with cloudsdk.WithNamespace(namespace).
+	CreateWithNamespace(ctx context.Context, req *repmod.CreateRepositoryRequest, namespace string) (*RepositoryResource, error)
+	Get(ctx context.Context, repositoryID string, callOpts ...internalhttp.CallOption) (*RepositoryResource, error)
+	// Deprecated: Use Get with cloudsdk.WithNamespace(namespace).
+	GetWithNamespace(ctx context.Context, repositoryID string, namespace string) (*RepositoryResource, error)
+	Update(ctx context.Context, repositoryID string, req *repmod.UpdateRepositoryRequest, callOpts ...internalhttp.CallOption) (*RepositoryResource, error)
+	// Deprecated: Use Update with cloudsdk.WithNamespace(namespace).
+	UpdateWithNamespace(ctx context.Context, repositoryID string, req *repmod.UpdateRepositoryRequest, namespace string) (*RepositoryResource, error)
+	Delete(ctx context.Context, repositoryID string, callOpts ...internalhttp.CallOption) error
+	// Deprecated: Use Delete with cloudsdk.WithNamespace(namespace).
+	DeleteWithNamespace(ctx context.Context, repositoryID string, namespace string) error
+	Snapshot(ctx context.Context, serverID string, req repmod.SnapshotRequester, callOpts ...internalhttp.CallOption) (*repmod.CreateSnapshotResponse, error)
+	// Deprecated: Use Snapshot with cloudsdk.WithNamespace(namespace).
+	SnapshotWithNamespace(ctx context.Context, serverID string, req repmod.SnapshotRequester, namespace string) (*repmod.CreateSnapshotResponse, error)
+	Upload(ctx context.Context, req repmod.UploadRequester, callOpts ...internalhttp.CallOption) (*repmod.UploadImageResponse, error)
+	// Deprecated: Use Upload with cloudsdk.WithNamespace(namespace).
+	UploadWithNamespace(ctx context.Context, req repmod.UploadRequester, namespace string) (*repmod.UploadImageResponse, error)
+}
+
+var (
+	_ RepositoriesAPI = (*Client)(nil)
+	_ TagOperations   = (*TagsClient)(nil)
+)
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"sync"
 
 	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
 	repmod "github.com/Zillaforge/cloud-sdk/models/vrm/repositories"
 	tagmod "github.com/Zillaforge/cloud-sdk/models/vrm/tags"
+	"github.com/Zillaforge/cloud-sdk/pager"
 )
 
 // Client provides access to repository operations for a specific project.
@@ -34,13 +36,43 @@ func NewClient(baseClient *internalhttp.Client, projectID, basePath string) *Cli
 // List retrieves all repositories in the project.
 // GET /api/v1/project/{project-id}/repositories
 // Supports pagination via limit/offset and filtering via where conditions.
-// Uses default namespace handling (no X-Namespace header).
-func (c *Client) List(ctx context.Context, opts *repmod.ListRepositoriesOptions) ([]*RepositoryResource, error) {
+// opts.Namespace selects the namespace; a namespace call option takes precedence.
+func (c *Client) List(ctx context.Context, opts *repmod.ListRepositoriesOptions, callOpts ...internalhttp.CallOption) ([]*RepositoryResource, error) {
+	page, err := c.listPage(ctx, opts, callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+// Iterate returns an iterator over the repositories matching opts, fetching
+// opts.Limit repositories per request (pager.DefaultPageSize when not positive)
+// from opts.Offset on.
+func (c *Client) Iterate(ctx context.Context, opts *repmod.ListRepositoriesOptions, callOpts ...internalhttp.CallOption) *pager.Iterator[*RepositoryResource] {
+	base := repmod.ListRepositoriesOptions{}
+	if opts != nil {
+		base = *opts
+	}
+	fetch := func(ctx context.Context, offset, limit int) (pager.Page[*RepositoryResource], error) {
+		pageOpts := base
+		pageOpts.Offset, pageOpts.Limit = offset, limit
+		return c.listPage(ctx, &pageOpts, callOpts...)
+	}
+	return pager.New(ctx, fetch, base.Offset, base.Limit)
+}
+
+// ListAll retrieves every repository matching opts, paging through the listing.
+func (c *Client) ListAll(ctx context.Context, opts *repmod.ListRepositoriesOptions, callOpts ...internalhttp.CallOption) ([]*RepositoryResource, error) {
+	return pager.All(c.Iterate(ctx, opts, callOpts...))
+}
+
+// listPage fetches one page of repositories together with the listing total.
+func (c *Client) listPage(ctx context.Context, opts *repmod.ListRepositoriesOptions, callOpts ...internalhttp.CallOption) (pager.Page[*RepositoryResource], error) {
 	if opts == nil {
 		opts = &repmod.ListRepositoriesOptions{}
 	}
 	if err := opts.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid list options: %w", err)
+		return pager.Page[*RepositoryResource]{}, fmt.Errorf("invalid list options: %w", err)
 	}
 
 	path := c.basePath + "/repositories"
@@ -67,45 +99,31 @@ func (c *Client) List(ctx context.Context, opts *repmod.ListRepositoriesOptions)
 	}
 
 	req := &internalhttp.Request{
-		Method:  "GET",
-		Path:    path,
-		Headers: headers,
+		Method:    "GET",
+		Path:      path,
+		Headers:   headers,
+		Operation: "vrm.repositories.List",
 	}
 
 	var resp repmod.ListRepositoriesResponse
-	if err := c.baseClient.Do(ctx, req, &resp); err != nil {
-		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	if err := c.baseClient.Do(ctx, req, &resp, callOpts...); err != nil {
+		return pager.Page[*RepositoryResource]{}, fmt.Errorf("failed to list repositories: %w", err)
 	}
 
 	repos := resp.Repositories
 
-	// Wrap repositories in RepositoryResource
+	// Wrap repositories in RepositoryResource; each builds its tags client once, on first use
 	repoResources := make([]*RepositoryResource, len(repos))
 	for i, repo := range repos {
-		repoResources[i] = &RepositoryResource{
-			Repository: repo,
-			tagOps: &TagsClient{
-				baseClient:   c.baseClient,
-				repositoryID: repo.ID,
-				basePath:     c.basePath,
-			},
-		}
+		repoResources[i] = c.newRepositoryResource(repo, repo.ID)
 	}
 
-	return repoResources, nil
+	return pager.Page[*RepositoryResource]{Items: repoResources, Total: resp.Total}, nil
 }
 
 // Create creates a new repository.
 // POST /api/v1/project/{project-id}/repository
-// Uses default namespace handling (no X-Namespace header).
-func (c *Client) Create(ctx context.Context, req *repmod.CreateRepositoryRequest) (*RepositoryResource, error) {
-	return c.CreateWithNamespace(ctx, req, "")
-}
-
-// CreateWithNamespace creates a new repository with optional namespace header.
-// POST /api/v1/project/{project-id}/repository
-// If namespace is provided, sets the X-Namespace header for multi-tenant operations.
-func (c *Client) CreateWithNamespace(ctx context.Context, req *repmod.CreateRepositoryRequest, namespace string) (*RepositoryResource, error) {
+func (c *Client) Create(ctx context.Context, req *repmod.CreateRepositoryRequest, callOpts ...internalhttp.CallOption) (*RepositoryResource, error) {
 	if req == nil {
 		return nil, fmt.Errorf("create request cannot be nil")
 	}
@@ -115,85 +133,59 @@ func (c *Client) CreateWithNamespace(ctx context.Context, req *repmod.CreateRepo
 
 	path := c.basePath + "/repository"
 
-	headers := make(map[string]string)
-	if namespace != "" {
-		headers["X-Namespace"] = namespace
-	}
-
 	httpReq := &internalhttp.Request{
-		Method:  "POST",
-		Path:    path,
-		Body:    req,
-		Headers: headers,
+		Method:    "POST",
+		Path:      path,
+		Body:      req,
+		Operation: "vrm.repositories.Create",
 	}
 
 	var repo repmod.Repository
-	if err := c.baseClient.Do(ctx, httpReq, &repo); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &repo, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to create repository: %w", err)
 	}
 
 	// Wrap in RepositoryResource with sub-resource operations
-	return &RepositoryResource{
-		Repository: &repo,
-		tagOps: &TagsClient{
-			baseClient:   c.baseClient,
-			repositoryID: repo.ID,
-			basePath:     c.basePath,
-		},
-	}, nil
+	return c.newRepositoryResource(&repo, repo.ID), nil
 }
 
-// Get retrieves a specific repository.
-// GET /api/v1/project/{project-id}/repository/{repository-id}
-// Uses default namespace handling (no X-Namespace header).
-func (c *Client) Get(ctx context.Context, repositoryID string) (*RepositoryResource, error) {
-	return c.GetWithNamespace(ctx, repositoryID, "")
+// CreateWithNamespace creates a new repository in namespace.
+//
+// Deprecated: Use Create with cloudsdk.WithNamespace(namespace).
+func (c *Client) CreateWithNamespace(ctx context.Context, req *repmod.CreateRepositoryRequest, namespace string) (*RepositoryResource, error) {
+	return c.Create(ctx, req, internalhttp.WithCallNamespace(namespace))
 }
 
-// GetWithNamespace retrieves a specific repository with optional namespace header.
+// Get retrieves a specific repository.
 // GET /api/v1/project/{project-id}/repository/{repository-id}
-// If namespace is provided, sets the X-Namespace header for multi-tenant operations.
-func (c *Client) GetWithNamespace(ctx context.Context, repositoryID string, namespace string) (*RepositoryResource, error) {
+func (c *Client) Get(ctx context.Context, repositoryID string, callOpts ...internalhttp.CallOption) (*RepositoryResource, error) {
 	path := c.basePath + "/repository/" + url.PathEscape(repositoryID)
 
-	headers := make(map[string]string)
-	if namespace != "" {
-		headers["X-Namespace"] = namespace
-	}
-
 	req := &internalhttp.Request{
-		Method:  "GET",
-		Path:    path,
-		Headers: headers,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vrm.repositories.Get",
 	}
 
 	var repo repmod.Repository
-	if err := c.baseClient.Do(ctx, req, &repo); err != nil {
+	if err := c.baseClient.Do(ctx, req, &repo, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to get repository %s: %w", repositoryID, err)
 	}
 
 	// Wrap in RepositoryResource with sub-resource operations
-	return &RepositoryResource{
-		Repository: &repo,
-		tagOps: &TagsClient{
-			baseClient:   c.baseClient,
-			repositoryID: repositoryID,
-			basePath:     c.basePath,
-		},
-	}, nil
+	return c.newRepositoryResource(&repo, repositoryID), nil
 }
 
-// Update updates an existing repository.
-// PUT /api/v1/project/{project-id}/repository/{repository-id}
-// Uses default namespace handling (no X-Namespace header).
-func (c *Client) Update(ctx context.Context, repositoryID string, req *repmod.UpdateRepositoryRequest) (*RepositoryResource, error) {
-	return c.UpdateWithNamespace(ctx, repositoryID, req, "")
+// GetWithNamespace retrieves a specific repository from namespace.
+//
+// Deprecated: Use Get with cloudsdk.WithNamespace(namespace).
+func (c *Client) GetWithNamespace(ctx context.Context, repositoryID string, namespace string) (*RepositoryResource, error) {
+	return c.Get(ctx, repositoryID, internalhttp.WithCallNamespace(namespace))
 }
 
-// UpdateWithNamespace updates an existing repository with optional namespace header.
+// Update updates an existing repository.
 // PUT /api/v1/project/{project-id}/repository/{repository-id}
-// If namespace is provided, sets the X-Namespace header for multi-tenant operations.
-func (c *Client) UpdateWithNamespace(ctx context.Context, repositoryID string, req *repmod.UpdateRepositoryRequest, namespace string) (*RepositoryResource, error) {
+func (c *Client) Update(ctx context.Context, repositoryID string, req *repmod.UpdateRepositoryRequest, callOpts ...internalhttp.CallOption) (*RepositoryResource, error) {
 	if req == nil {
 		return nil, fmt.Errorf("update request cannot be nil")
 	}
@@ -203,74 +195,57 @@ func (c *Client) UpdateWithNamespace(ctx context.Context, repositoryID string, r
 
 	path := c.basePath + "/repository/" + url.PathEscape(repositoryID)
 
-	headers := make(map[string]string)
-	if namespace != "" {
-		headers["X-Namespace"] = namespace
-	}
-
 	httpReq := &internalhttp.Request{
-		Method:  "PUT",
-		Path:    path,
-		Body:    req,
-		Headers: headers,
+		Method:    "PUT",
+		Path:      path,
+		Body:      req,
+		Operation: "vrm.repositories.Update",
 	}
 
 	var repo repmod.Repository
-	if err := c.baseClient.Do(ctx, httpReq, &repo); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &repo, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to update repository %s: %w", repositoryID, err)
 	}
 
 	// Wrap in RepositoryResource with sub-resource operations
-	return &RepositoryResource{
-		Repository: &repo,
-		tagOps: &TagsClient{
-			baseClient:   c.baseClient,
-			repositoryID: repositoryID,
-			basePath:     c.basePath,
-		},
-	}, nil
+	return c.newRepositoryResource(&repo, repositoryID), nil
 }
 
-// Delete deletes a repository.
-// DELETE /api/v1/project/{project-id}/repository/{repository-id}
-// Uses default namespace handling (no X-Namespace header).
-func (c *Client) Delete(ctx context.Context, repositoryID string) error {
-	return c.DeleteWithNamespace(ctx, repositoryID, "")
+// UpdateWithNamespace updates an existing repository in namespace.
+//
+// Deprecated: Use Update with cloudsdk.WithNamespace(namespace).
+func (c *Client) UpdateWithNamespace(ctx context.Context, repositoryID string, req *repmod.UpdateRepositoryRequest, namespace string) (*RepositoryResource, error) {
+	return c.Update(ctx, repositoryID, req, internalhttp.WithCallNamespace(namespace))
 }
 
-// DeleteWithNamespace deletes a repository with optional namespace header.
+// Delete deletes a repository.
 // DELETE /api/v1/project/{project-id}/repository/{repository-id}
-// If namespace is provided, sets the X-Namespace header for multi-tenant operations.
-func (c *Client) DeleteWithNamespace(ctx context.Context, repositoryID string, namespace string) error {
+func (c *Client) Delete(ctx context.Context, repositoryID string, callOpts ...internalhttp.CallOption) error {
 	path := c.basePath + "/repository/" + url.PathEscape(repositoryID)
 
-	headers := make(map[string]string)
-	if namespace != "" {
-		headers["X-Namespace"] = namespace
-	}
-
 	req := &internalhttp.Request{
-		Method:  "DELETE",
-		Path:    path,
-		Headers: headers,
+		Method:    "DELETE",
+		Path:      path,
+		Operation: "vrm.repositories.Delete",
 	}
 
-	if err := c.baseClient.Do(ctx, req, nil); err != nil {
+	if err := c.baseClient.Do(ctx, req, nil, callOpts...); err != nil {
 		return fmt.Errorf("failed to delete repository %s: %w", repositoryID, err)
 	}
 
 	return nil
 }
 
-// Snapshot triggers a snapshot operation for a server and returns the associated repository resource.
-// POST /api/v1/project/{project-id}/server/{server-id}/snapshot
-func (c *Client) Snapshot(ctx context.Context, serverID string, req repmod.SnapshotRequester) (*repmod.CreateSnapshotResponse, error) {
-	return c.SnapshotWithNamespace(ctx, serverID, req, "")
+// DeleteWithNamespace deletes a repository from namespace.
+//
+// Deprecated: Use Delete with cloudsdk.WithNamespace(namespace).
+func (c *Client) DeleteWithNamespace(ctx context.Context, repositoryID string, namespace string) error {
+	return c.Delete(ctx, repositoryID, internalhttp.WithCallNamespace(namespace))
 }
 
-// SnapshotWithNamespace triggers a snapshot operation with optional namespace header for multi-tenant use cases.
+// Snapshot triggers a snapshot operation for a server and returns the associated repository resource.
 // POST /api/v1/project/{project-id}/server/{server-id}/snapshot
-func (c *Client) SnapshotWithNamespace(ctx context.Context, serverID string, req repmod.SnapshotRequester, namespace string) (*repmod.CreateSnapshotResponse, error) {
+func (c *Client) Snapshot(ctx context.Context, serverID string, req repmod.SnapshotRequester, callOpts ...internalhttp.CallOption) (*repmod.CreateSnapshotResponse, error) {
 	if strings.TrimSpace(serverID) == "" {
 		return nil, fmt.Errorf("server ID cannot be empty")
 	}
@@ -285,20 +260,15 @@ func (c *Client) SnapshotWithNamespace(ctx context.Context, serverID string, req
 
 	path := c.basePath + "/server/" + url.PathEscape(serverID) + "/snapshot"
 
-	headers := make(map[string]string)
-	if namespace != "" {
-		headers["X-Namespace"] = namespace
-	}
-
 	httpReq := &internalhttp.Request{
-		Method:  "POST",
-		Path:    path,
-		Body:    &createReq,
-		Headers: headers,
+		Method:    "POST",
+		Path:      path,
+		Body:      &createReq,
+		Operation: "vrm.repositories.Snapshot",
 	}
 
 	var resp repmod.CreateSnapshotResponse
-	if err := c.baseClient.Do(ctx, httpReq, &resp); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &resp, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to snapshot server %s: %w", serverID, err)
 	}
 	if resp.Repository == nil {
@@ -311,16 +281,17 @@ func (c *Client) SnapshotWithNamespace(ctx context.Context, serverID string, req
 	return &resp, nil
 }
 
-// Upload uploads an image into VRM and returns the affected repository resource.
-// POST /api/v1/project/{project-id}/upload
-// Supports three modes: creating a new repository, uploading to an existing repository, or uploading to an existing tag.
-func (c *Client) Upload(ctx context.Context, req repmod.UploadRequester) (*repmod.UploadImageResponse, error) {
-	return c.UploadWithNamespace(ctx, req, "")
+// SnapshotWithNamespace triggers a snapshot operation in namespace.
+//
+// Deprecated: Use Snapshot with cloudsdk.WithNamespace(namespace).
+func (c *Client) SnapshotWithNamespace(ctx context.Context, serverID string, req repmod.SnapshotRequester, namespace string) (*repmod.CreateSnapshotResponse, error) {
+	return c.Snapshot(ctx, serverID, req, internalhttp.WithCallNamespace(namespace))
 }
 
-// UploadWithNamespace uploads an image with optional namespace scoping via X-Namespace header.
+// Upload uploads an image into VRM and returns the affected repository resource.
 // POST /api/v1/project/{project-id}/upload
-func (c *Client) UploadWithNamespace(ctx context.Context, req repmod.UploadRequester, namespace string) (*repmod.UploadImageResponse, error) {
+// Supports three modes: creating a new repository, uploading to an existing repository, or uploading to an existing tag.
+func (c *Client) Upload(ctx context.Context, req repmod.UploadRequester, callOpts ...internalhttp.CallOption) (*repmod.UploadImageResponse, error) {
 	if req == nil {
 		return nil, fmt.Errorf("upload request cannot be nil")
 	}
@@ -332,20 +303,15 @@ func (c *Client) UploadWithNamespace(ctx context.Context, req repmod.UploadReque
 
 	path := c.basePath + "/upload"
 
-	headers := make(map[string]string)
-	if namespace != "" {
-		headers["X-Namespace"] = namespace
-	}
-
 	httpReq := &internalhttp.Request{
-		Method:  "POST",
-		Path:    path,
-		Body:    &uploadReq,
-		Headers: headers,
+		Method:    "POST",
+		Path:      path,
+		Body:      &uploadReq,
+		Operation: "vrm.repositories.Upload",
 	}
 
 	var resp repmod.UploadImageResponse
-	if err := c.baseClient.Do(ctx, httpReq, &resp); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &resp, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to upload image: %w", err)
 	}
 	if resp.Repository == nil {
@@ -358,21 +324,53 @@ func (c *Client) UploadWithNamespace(ctx context.Context, req repmod.UploadReque
 	return &resp, nil
 }
 
+// UploadWithNamespace uploads an image into namespace.
+//
+// Deprecated: Use Upload with cloudsdk.WithNamespace(namespace).
+func (c *Client) UploadWithNamespace(ctx context.Context, req repmod.UploadRequester, namespace string) (*repmod.UploadImageResponse, error) {
+	return c.Upload(ctx, req, internalhttp.WithCallNamespace(namespace))
+}
+
 // RepositoryResource wraps a Repository with sub-resource operations.
 type RepositoryResource struct {
 	*repmod.Repository
 	tagOps TagOperations
+
+	// client and repositoryID back Tags when tagOps is unset
+	client       *Client
+	repositoryID string
+	tagsOnce     sync.Once
+}
+
+// newRepositoryResource wraps repository for repositoryID; its tags client is
+// built on the first call to Tags.
+func (c *Client) newRepositoryResource(repository *repmod.Repository, repositoryID string) *RepositoryResource {
+	return &RepositoryResource{Repository: repository, client: c, repositoryID: repositoryID}
+}
+
+// NewRepositoryResource wraps repository with the given tag operations.
+func NewRepositoryResource(repository *repmod.Repository, tags TagOperations) *RepositoryResource {
+	return &RepositoryResource{Repository: repository, tagOps: tags}
 }
 
 // Tags returns the tag operations for this repository.
 func (rr *RepositoryResource) Tags() TagOperations {
+	if rr.client == nil {
+		return rr.tagOps
+	}
+	rr.tagsOnce.Do(func() {
+		rr.tagOps = &TagsClient{baseClient: rr.client.baseClient, repositoryID: rr.repositoryID, basePath: rr.client.basePath}
+	})
 	return rr.tagOps
 }
 
 // TagOperations defines operations on repository tags (sub-resource).
 type TagOperations interface {
-	List(ctx context.Context, opts *tagmod.ListTagsOptions) ([]*tagmod.Tag, error)
-	Create(ctx context.Context, req *tagmod.CreateTagRequest) (*tagmod.Tag, error)
+	List(ctx context.Context, opts *tagmod.ListTagsOptions, callOpts ...internalhttp.CallOption) ([]*tagmod.Tag, error)
+	Iterate(ctx context.Context, opts *tagmod.ListTagsOptions, callOpts ...internalhttp.CallOption) *pager.Iterator[*tagmod.Tag]
+	ListAll(ctx context.Context, opts *tagmod.ListTagsOptions, callOpts ...internalhttp.CallOption) ([]*tagmod.Tag, error)
+	Create(ctx context.Context, req *tagmod.CreateTagRequest, callOpts ...internalhttp.CallOption) (*tagmod.Tag, error)
+	// Deprecated: Use Create with cloudsdk.WithNamespace(namespace).
 	CreateWithNamespace(ctx context.Context, req *tagmod.CreateTagRequest, namespace string) (*tagmod.Tag, error)
 }
 
@@ -385,12 +383,42 @@ type TagsClient struct {
 
 // List retrieves all tags in the repository.
 // GET /api/v1/project/{project-id}/repository/{repository-id}/tags
-func (tc *TagsClient) List(ctx context.Context, opts *tagmod.ListTagsOptions) ([]*tagmod.Tag, error) {
+func (tc *TagsClient) List(ctx context.Context, opts *tagmod.ListTagsOptions, callOpts ...internalhttp.CallOption) ([]*tagmod.Tag, error) {
+	page, err := tc.listPage(ctx, opts, callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+// Iterate returns an iterator over the tags in the repository matching opts,
+// fetching opts.Limit tags per request (pager.DefaultPageSize when not positive)
+// from opts.Offset on.
+func (tc *TagsClient) Iterate(ctx context.Context, opts *tagmod.ListTagsOptions, callOpts ...internalhttp.CallOption) *pager.Iterator[*tagmod.Tag] {
+	base := tagmod.ListTagsOptions{}
+	if opts != nil {
+		base = *opts
+	}
+	fetch := func(ctx context.Context, offset, limit int) (pager.Page[*tagmod.Tag], error) {
+		pageOpts := base
+		pageOpts.Offset, pageOpts.Limit = offset, limit
+		return tc.listPage(ctx, &pageOpts, callOpts...)
+	}
+	return pager.New(ctx, fetch, base.Offset, base.Limit)
+}
+
+// ListAll retrieves every tag in the repository matching opts, paging through the listing.
+func (tc *TagsClient) ListAll(ctx context.Context, opts *tagmod.ListTagsOptions, callOpts ...internalhttp.CallOption) ([]*tagmod.Tag, error) {
+	return pager.All(tc.Iterate(ctx, opts, callOpts...))
+}
+
+// listPage fetches one page of the repository's tags together with the listing total.
+func (tc *TagsClient) listPage(ctx context.Context, opts *tagmod.ListTagsOptions, callOpts ...internalhttp.CallOption) (pager.Page[*tagmod.Tag], error) {
 	if opts == nil {
 		opts = &tagmod.ListTagsOptions{}
 	}
 	if err := opts.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid list options: %w", err)
+		return pager.Page[*tagmod.Tag]{}, fmt.Errorf("invalid list options: %w", err)
 	}
 
 	path := tc.basePath + "/repository/" + url.PathEscape(tc.repositoryID) + "/tags"
@@ -417,30 +445,23 @@ func (tc *TagsClient) List(ctx context.Context, opts *tagmod.ListTagsOptions) ([
 	}
 
 	req := &internalhttp.Request{
-		Method:  "GET",
-		Path:    path,
-		Headers: headers,
+		Method:    "GET",
+		Path:      path,
+		Headers:   headers,
+		Operation: "vrm.repositories.tags.List",
 	}
 
 	var resp tagmod.ListTagsResponse
-	if err := tc.baseClient.Do(ctx, req, &resp); err != nil {
-		return nil, fmt.Errorf("failed to list tags by repository %s: %w", tc.repositoryID, err)
+	if err := tc.baseClient.Do(ctx, req, &resp, callOpts...); err != nil {
+		return pager.Page[*tagmod.Tag]{}, fmt.Errorf("failed to list tags by repository %s: %w", tc.repositoryID, err)
 	}
 
-	return resp.Tags, nil
+	return pager.Page[*tagmod.Tag]{Items: resp.Tags, Total: resp.Total}, nil
 }
 
 // Create creates a new tag in the repository.
 // POST /api/v1/project/{project-id}/repository/{repository-id}/tag
-// Uses default namespace handling (no X-Namespace header).
-func (tc *TagsClient) Create(ctx context.Context, req *tagmod.CreateTagRequest) (*tagmod.Tag, error) {
-	return tc.CreateWithNamespace(ctx, req, "")
-}
-
-// CreateWithNamespace creates a new tag in the repository with optional namespace header.
-// POST /api/v1/project/{project-id}/repository/{repository-id}/tag
-// If namespace is provided, sets the X-Namespace header for multi-tenant operations.
-func (tc *TagsClient) CreateWithNamespace(ctx context.Context, req *tagmod.CreateTagRequest, namespace string) (*tagmod.Tag, error) {
+func (tc *TagsClient) Create(ctx context.Context, req *tagmod.CreateTagRequest, callOpts ...internalhttp.CallOption) (*tagmod.Tag, error) {
 	if req == nil {
 		return nil, fmt.Errorf("create request cannot be nil")
 	}
@@ -450,22 +471,24 @@ func (tc *TagsClient) CreateWithNamespace(ctx context.Context, req *tagmod.Creat
 
 	path := tc.basePath + "/repository/" + url.PathEscape(tc.repositoryID) + "/tag"
 
-	headers := make(map[string]string)
-	if namespace != "" {
-		headers["X-Namespace"] = namespace
-	}
-
 	httpReq := &internalhttp.Request{
-		Method:  "POST",
-		Path:    path,
-		Body:    req,
-		Headers: headers,
+		Method:    "POST",
+		Path:      path,
+		Body:      req,
+		Operation: "vrm.repositories.tags.Create",
 	}
 
 	var tag tagmod.Tag
-	if err := tc.baseClient.Do(ctx, httpReq, &tag); err != nil {
+	if err := tc.baseClient.Do(ctx, httpReq, &tag, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to create tag: %w", err)
 	}
 
 	return &tag, nil
 }
+
+// CreateWithNamespace creates a new tag in the repository in namespace.
+//
+// Deprecated: Use Create with cloudsdk.WithNamespace(namespace).
+func (tc *TagsClient) CreateWithNamespace(ctx context.Context, req *tagmod.CreateTagRequest, namespace string) (*tagmod.Tag, error) {
+	return tc.Create(ctx, req, internalhttp.WithCallNamespace(namespace))
+}
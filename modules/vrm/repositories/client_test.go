@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
@@ -1708,3 +1709,58 @@ func TestClient_DeleteWithNamespace(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_Iterate(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path+"?"+r.URL.RawQuery)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/project/proj-123/repositories" {
+			var page []*repositories.Repository
+			for i := offset; i < offset+2 && i < 3; i++ {
+				page = append(page, &repositories.Repository{ID: "repo-" + strconv.Itoa(i)})
+			}
+			_ = json.NewEncoder(w).Encode(repositories.ListRepositoriesResponse{Repositories: page, Total: 3})
+			return
+		}
+		var page []*tagmod.Tag
+		for i := offset; i < offset+2 && i < 4; i++ {
+			page = append(page, &tagmod.Tag{ID: "tag-" + strconv.Itoa(i)})
+		}
+		_ = json.NewEncoder(w).Encode(tagmod.ListTagsResponse{Tags: page, Total: 4})
+	}))
+	defer server.Close()
+
+	baseClient := internalhttp.NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil)
+	client := NewClient(baseClient, "proj-123", "/api/v1/project/proj-123")
+	ctx := context.Background()
+
+	it := client.Iterate(ctx, &repositories.ListRepositoriesOptions{Limit: 2})
+	var repos []*RepositoryResource
+	for it.Next() {
+		repos = append(repos, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Iterate() failed: %v", err)
+	}
+	if len(repos) != 3 || it.Total() != 3 || len(paths) != 2 {
+		t.Fatalf("expected 3 repositories over 2 pages, got %d over %v", len(repos), paths)
+	}
+
+	if repos[2].Tags() != repos[2].Tags() {
+		t.Error("expected the repository to reuse its tags client")
+	}
+
+	paths = nil
+	allTags, err := repos[2].Tags().ListAll(ctx, &tagmod.ListTagsOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("ListAll() failed: %v", err)
+	}
+	if len(allTags) != 4 || len(paths) != 2 {
+		t.Errorf("expected 4 tags over 2 pages, got %d over %v", len(allTags), paths)
+	}
+	if want := "/api/v1/project/proj-123/repository/repo-2/tags?limit=2&offset=2"; len(paths) == 2 && paths[1] != want {
+		t.Errorf("expected second page %s, got %s", want, paths[1])
+	}
+}
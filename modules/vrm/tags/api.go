@@ -0,0 +1,33 @@
+package tags
+
+import (
+	"context"
+
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+	tagmod "github.com/Zillaforge/cloud-sdk/models/vrm/tags"
+	"github.com/Zillaforge/cloud-sdk/pager"
+)
+
+//go:generate go run github.com/Zillaforge/cloud-sdk/hack/mockgen
+
+// TagsAPI manages image tags across all repositories of a project.
+// Client implements it.
+type TagsAPI interface {
+	List(ctx context.Context, opts *tagmod.ListTagsOptions, callOpts ...internalhttp.CallOption) ([]*tagmod.Tag, error)
+	Iterate(ctx context.Context, opts *tagmod.ListTagsOptions, callOpts ...internalhttp.CallOption) *pager.Iterator[*tagmod.Tag]
+	ListAll(ctx context.Context, opts *tagmod.ListTagsOptions, callOpts ...internalhttp.CallOption) ([]*tagmod.Tag, error)
+	Get(ctx context.Context, tagID string, callOpts ...internalhttp.CallOption) (*tagmod.Tag, error)
+	// Deprecated: Use Get with cloudsdk.WithNamespace(namespace).
+	GetWithNamespace(ctx context.Context, tagID string, namespace string) (*tagmod.Tag, error)
+	Update(ctx context.Context, tagID string, req *tagmod.UpdateTagRequest, callOpts ...internalhttp.CallOption) (*tagmod.Tag, error)
+	// Deprecated: Use Update with cloudsdk.WithNamespace(namespace).
+	UpdateWithNamespace(ctx context.Context, tagID string, req *tagmod.UpdateTagRequest, namespace string) (*tagmod.Tag, error)
+	Delete(ctx context.Context, tagID string, callOpts ...internalhttp.CallOption) error
+	// Deprecated: Use Delete with cloudsdk.WithNamespace(namespace).
+	DeleteWithNamespace(ctx context.Context, tagID string, namespace string) error
+	Download(ctx context.Context, tagID string, req *tagmod.DownloadTagRequest, callOpts ...internalhttp.CallOption) error
+	// Deprecated: Use Download with cloudsdk.WithNamespace(namespace).
+	DownloadWithNamespace(ctx context.Context, tagID string, req *tagmod.DownloadTagRequest, namespace string) error
+}
+
+var _ TagsAPI = (*Client)(nil)
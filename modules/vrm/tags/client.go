@@ -8,6 +8,7 @@ import (
 
 	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
 	tagmod "github.com/Zillaforge/cloud-sdk/models/vrm/tags"
+	"github.com/Zillaforge/cloud-sdk/pager"
 )
 
 // Client provides access to tag operations for a specific project.
@@ -33,13 +34,42 @@ func NewClient(baseClient *internalhttp.Client, projectID, basePath string) *Cli
 // List retrieves all tags in the project.
 // GET /api/v1/project/{project-id}/tags
 // Supports pagination via limit/offset and filtering via where conditions.
-// Uses default namespace handling (no X-Namespace header).
-func (c *Client) List(ctx context.Context, opts *tagmod.ListTagsOptions) ([]*tagmod.Tag, error) {
+// opts.Namespace selects the namespace; a namespace call option takes precedence.
+func (c *Client) List(ctx context.Context, opts *tagmod.ListTagsOptions, callOpts ...internalhttp.CallOption) ([]*tagmod.Tag, error) {
+	page, err := c.listPage(ctx, opts, callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+// Iterate returns an iterator over the tags matching opts, fetching opts.Limit
+// tags per request (pager.DefaultPageSize when not positive) from opts.Offset on.
+func (c *Client) Iterate(ctx context.Context, opts *tagmod.ListTagsOptions, callOpts ...internalhttp.CallOption) *pager.Iterator[*tagmod.Tag] {
+	base := tagmod.ListTagsOptions{}
+	if opts != nil {
+		base = *opts
+	}
+	fetch := func(ctx context.Context, offset, limit int) (pager.Page[*tagmod.Tag], error) {
+		pageOpts := base
+		pageOpts.Offset, pageOpts.Limit = offset, limit
+		return c.listPage(ctx, &pageOpts, callOpts...)
+	}
+	return pager.New(ctx, fetch, base.Offset, base.Limit)
+}
+
+// ListAll retrieves every tag matching opts, paging through the listing.
+func (c *Client) ListAll(ctx context.Context, opts *tagmod.ListTagsOptions, callOpts ...internalhttp.CallOption) ([]*tagmod.Tag, error) {
+	return pager.All(c.Iterate(ctx, opts, callOpts...))
+}
+
+// listPage fetches one page of tags together with the listing total.
+func (c *Client) listPage(ctx context.Context, opts *tagmod.ListTagsOptions, callOpts ...internalhttp.CallOption) (pager.Page[*tagmod.Tag], error) {
 	if opts == nil {
 		opts = &tagmod.ListTagsOptions{}
 	}
 	if err := opts.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid list options: %w", err)
+		return pager.Page[*tagmod.Tag]{}, fmt.Errorf("invalid list options: %w", err)
 	}
 
 	path := c.basePath + "/tags"
@@ -66,66 +96,53 @@ func (c *Client) List(ctx context.Context, opts *tagmod.ListTagsOptions) ([]*tag
 	}
 
 	req := &internalhttp.Request{
-		Method:  "GET",
-		Path:    path,
-		Headers: headers,
+		Method:    "GET",
+		Path:      path,
+		Headers:   headers,
+		Operation: "vrm.tags.List",
 	}
 
 	var resp tagmod.ListTagsResponse
-	if err := c.baseClient.Do(ctx, req, &resp); err != nil {
-		return nil, fmt.Errorf("failed to list tags: %w", err)
+	if err := c.baseClient.Do(ctx, req, &resp, callOpts...); err != nil {
+		return pager.Page[*tagmod.Tag]{}, fmt.Errorf("failed to list tags: %w", err)
 	}
 
-	return resp.Tags, nil
+	return pager.Page[*tagmod.Tag]{Items: resp.Tags, Total: resp.Total}, nil
 }
 
 // Get retrieves a specific tag.
 // GET /api/v1/project/{project-id}/tag/{tag-id}
-// Uses default namespace handling (no X-Namespace header).
-func (c *Client) Get(ctx context.Context, tagID string) (*tagmod.Tag, error) {
-	return c.GetWithNamespace(ctx, tagID, "")
-}
-
-// GetWithNamespace retrieves a specific tag with optional namespace header.
-// GET /api/v1/project/{project-id}/tag/{tag-id}
-// If namespace is provided, sets the X-Namespace header for multi-tenant operations.
-func (c *Client) GetWithNamespace(ctx context.Context, tagID string, namespace string) (*tagmod.Tag, error) {
+func (c *Client) Get(ctx context.Context, tagID string, callOpts ...internalhttp.CallOption) (*tagmod.Tag, error) {
 	if tagID == "" {
 		return nil, fmt.Errorf("tag ID cannot be empty")
 	}
 
 	path := c.basePath + "/tag/" + url.PathEscape(tagID)
 
-	headers := make(map[string]string)
-	if namespace != "" {
-		headers["X-Namespace"] = namespace
-	}
-
 	req := &internalhttp.Request{
-		Method:  "GET",
-		Path:    path,
-		Headers: headers,
+		Method:    "GET",
+		Path:      path,
+		Operation: "vrm.tags.Get",
 	}
 
 	var tag tagmod.Tag
-	if err := c.baseClient.Do(ctx, req, &tag); err != nil {
+	if err := c.baseClient.Do(ctx, req, &tag, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to get tag %s: %w", tagID, err)
 	}
 
 	return &tag, nil
 }
 
-// Update updates an existing tag.
-// PUT /api/v1/project/{project-id}/tag/{tag-id}
-// Uses default namespace handling (no X-Namespace header).
-func (c *Client) Update(ctx context.Context, tagID string, req *tagmod.UpdateTagRequest) (*tagmod.Tag, error) {
-	return c.UpdateWithNamespace(ctx, tagID, req, "")
+// GetWithNamespace retrieves a specific tag from namespace.
+//
+// Deprecated: Use Get with cloudsdk.WithNamespace(namespace).
+func (c *Client) GetWithNamespace(ctx context.Context, tagID string, namespace string) (*tagmod.Tag, error) {
+	return c.Get(ctx, tagID, internalhttp.WithCallNamespace(namespace))
 }
 
-// UpdateWithNamespace updates an existing tag with optional namespace header.
+// Update updates an existing tag.
 // PUT /api/v1/project/{project-id}/tag/{tag-id}
-// If namespace is provided, sets the X-Namespace header for multi-tenant operations.
-func (c *Client) UpdateWithNamespace(ctx context.Context, tagID string, req *tagmod.UpdateTagRequest, namespace string) (*tagmod.Tag, error) {
+func (c *Client) Update(ctx context.Context, tagID string, req *tagmod.UpdateTagRequest, callOpts ...internalhttp.CallOption) (*tagmod.Tag, error) {
 	if tagID == "" {
 		return nil, fmt.Errorf("tag ID cannot be empty")
 	}
@@ -138,70 +155,60 @@ func (c *Client) UpdateWithNamespace(ctx context.Context, tagID string, req *tag
 
 	path := c.basePath + "/tag/" + url.PathEscape(tagID)
 
-	headers := make(map[string]string)
-	if namespace != "" {
-		headers["X-Namespace"] = namespace
-	}
-
 	httpReq := &internalhttp.Request{
-		Method:  "PUT",
-		Path:    path,
-		Body:    req,
-		Headers: headers,
+		Method:    "PUT",
+		Path:      path,
+		Body:      req,
+		Operation: "vrm.tags.Update",
 	}
 
 	var tag tagmod.Tag
-	if err := c.baseClient.Do(ctx, httpReq, &tag); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, &tag, callOpts...); err != nil {
 		return nil, fmt.Errorf("failed to update tag %s: %w", tagID, err)
 	}
 
 	return &tag, nil
 }
 
-// Delete deletes a tag.
-// DELETE /api/v1/project/{project-id}/tag/{tag-id}
-// Uses default namespace handling (no X-Namespace header).
-func (c *Client) Delete(ctx context.Context, tagID string) error {
-	return c.DeleteWithNamespace(ctx, tagID, "")
+// UpdateWithNamespace updates an existing tag in namespace.
+//
+// Deprecated: Use Update with cloudsdk.WithNamespace(namespace).
+func (c *Client) UpdateWithNamespace(ctx context.Context, tagID string, req *tagmod.UpdateTagRequest, namespace string) (*tagmod.Tag, error) {
+	return c.Update(ctx, tagID, req, internalhttp.WithCallNamespace(namespace))
 }
 
-// DeleteWithNamespace deletes a tag with optional namespace header.
+// Delete deletes a tag.
 // DELETE /api/v1/project/{project-id}/tag/{tag-id}
-// If namespace is provided, sets the X-Namespace header for multi-tenant operations.
-func (c *Client) DeleteWithNamespace(ctx context.Context, tagID string, namespace string) error {
+func (c *Client) Delete(ctx context.Context, tagID string, callOpts ...internalhttp.CallOption) error {
 	if tagID == "" {
 		return fmt.Errorf("tag ID cannot be empty")
 	}
 
 	path := c.basePath + "/tag/" + url.PathEscape(tagID)
 
-	headers := make(map[string]string)
-	if namespace != "" {
-		headers["X-Namespace"] = namespace
-	}
-
 	req := &internalhttp.Request{
-		Method:  "DELETE",
-		Path:    path,
-		Headers: headers,
+		Method:    "DELETE",
+		Path:      path,
+		Operation: "vrm.tags.Delete",
 	}
 
-	if err := c.baseClient.Do(ctx, req, nil); err != nil {
+	if err := c.baseClient.Do(ctx, req, nil, callOpts...); err != nil {
 		return fmt.Errorf("failed to delete tag %s: %w", tagID, err)
 	}
 
 	return nil
 }
 
-// Download exports the image behind a tag to external storage.
-// POST /api/v1/project/{project-id}/tag/{tag-id}/download
-func (c *Client) Download(ctx context.Context, tagID string, req *tagmod.DownloadTagRequest) error {
-	return c.DownloadWithNamespace(ctx, tagID, req, "")
+// DeleteWithNamespace deletes a tag from namespace.
+//
+// Deprecated: Use Delete with cloudsdk.WithNamespace(namespace).
+func (c *Client) DeleteWithNamespace(ctx context.Context, tagID string, namespace string) error {
+	return c.Delete(ctx, tagID, internalhttp.WithCallNamespace(namespace))
 }
 
-// DownloadWithNamespace exports the tag image with optional namespace scoping via X-Namespace header.
+// Download exports the image behind a tag to external storage.
 // POST /api/v1/project/{project-id}/tag/{tag-id}/download
-func (c *Client) DownloadWithNamespace(ctx context.Context, tagID string, req *tagmod.DownloadTagRequest, namespace string) error {
+func (c *Client) Download(ctx context.Context, tagID string, req *tagmod.DownloadTagRequest, callOpts ...internalhttp.CallOption) error {
 	if strings.TrimSpace(tagID) == "" {
 		return fmt.Errorf("tag ID cannot be empty")
 	}
@@ -214,21 +221,23 @@ func (c *Client) DownloadWithNamespace(ctx context.Context, tagID string, req *t
 
 	path := c.basePath + "/tag/" + url.PathEscape(tagID) + "/download"
 
-	headers := make(map[string]string)
-	if namespace != "" {
-		headers["X-Namespace"] = namespace
-	}
-
 	httpReq := &internalhttp.Request{
-		Method:  "POST",
-		Path:    path,
-		Body:    req,
-		Headers: headers,
+		Method:    "POST",
+		Path:      path,
+		Body:      req,
+		Operation: "vrm.tags.Download",
 	}
 
-	if err := c.baseClient.Do(ctx, httpReq, nil); err != nil {
+	if err := c.baseClient.Do(ctx, httpReq, nil, callOpts...); err != nil {
 		return fmt.Errorf("failed to download tag %s: %w", tagID, err)
 	}
 
 	return nil
 }
+
+// DownloadWithNamespace exports the image behind a tag in namespace.
+//
+// Deprecated: Use Download with cloudsdk.WithNamespace(namespace).
+func (c *Client) DownloadWithNamespace(ctx context.Context, tagID string, req *tagmod.DownloadTagRequest, namespace string) error {
+	return c.Download(ctx, tagID, req, internalhttp.WithCallNamespace(namespace))
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
@@ -765,3 +766,52 @@ func TestClient_UpdateWithNamespace(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_Iterate(t *testing.T) {
+	var offsets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		offsets = append(offsets, q.Get("offset"))
+		if q.Get("where") != "status=active" || r.Header.Get("X-Namespace") != "public" {
+			t.Errorf("expected filters on every page, got %s (namespace %q)", r.URL.RawQuery, r.Header.Get("X-Namespace"))
+		}
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		var page []*tags.Tag
+		for i := offset; i < offset+limit && i < 5; i++ {
+			page = append(page, &tags.Tag{ID: "tag-" + strconv.Itoa(i)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(tags.ListTagsResponse{Tags: page, Total: 5})
+	}))
+	defer server.Close()
+
+	baseClient := internalhttp.NewClient(server.URL, "test-token", &http.Client{Timeout: 5 * time.Second}, nil)
+	client := NewClient(baseClient, "proj-123", "/api/v1/project/proj-123")
+	opts := &tags.ListTagsOptions{Limit: 2, Where: []string{"status=active"}, Namespace: "public"}
+
+	all, err := client.ListAll(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("ListAll() failed: %v", err)
+	}
+	if len(all) != 5 || all[4].ID != "tag-4" {
+		t.Errorf("expected 5 tags, got %d", len(all))
+	}
+	if len(offsets) != 3 || offsets[0] != "" || offsets[1] != "2" || offsets[2] != "4" {
+		t.Errorf("unexpected page offsets: %v", offsets)
+	}
+	if opts.Offset != 0 {
+		t.Errorf("expected the caller's options to be left untouched, got offset %d", opts.Offset)
+	}
+
+	offsets = nil
+	it := client.Iterate(context.Background(), opts)
+	for it.Next() {
+		if it.Item().ID == "tag-1" {
+			break
+		}
+	}
+	if len(offsets) != 1 || it.Total() != 5 {
+		t.Errorf("expected a single page with total 5, got %v (total %d)", offsets, it.Total())
+	}
+}
@@ -0,0 +1,134 @@
+// Package pager walks offset-paginated list endpoints one page at a time.
+//
+// List methods of the SDK return a single page. Their Iterate counterparts return
+// an Iterator that fetches further pages on demand, and ListAll collects every
+// page into one slice:
+//
+//	it := vrmClient.Repositories().Iterate(ctx, &repositories.ListRepositoriesOptions{Limit: 50})
+//	for it.Next() {
+//		repo := it.Item()
+//		if repo.Name == "ubuntu" {
+//			break // no further pages are fetched
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+//	fmt.Println("total:", it.Total())
+package pager
+
+import (
+	"context"
+)
+
+// DefaultPageSize is the page size used when the caller does not set one.
+const DefaultPageSize = 100
+
+// Page is one page of a listing.
+type Page[T any] struct {
+	// Items are the items of the page
+	Items []T
+
+	// Total is the size of the whole listing as reported by the server, or zero
+	// when the endpoint does not report it
+	Total int
+}
+
+// FetchFunc fetches the page of limit items starting at offset.
+type FetchFunc[T any] func(ctx context.Context, offset, limit int) (Page[T], error)
+
+// Iterator yields the items of a paginated listing, fetching a page whenever the
+// previous one is exhausted. The zero value and a nil Iterator yield nothing.
+// An Iterator is not safe for concurrent use.
+type Iterator[T any] struct {
+	ctx      context.Context
+	fetch    FetchFunc[T]
+	pageSize int
+	offset   int
+
+	page  []T
+	index int
+	total int
+	done  bool
+	err   error
+}
+
+// New returns an iterator over the listing served by fetch, starting at offset
+// and requesting pageSize items per page. A pageSize of zero or less uses
+// DefaultPageSize.
+func New[T any](ctx context.Context, fetch FetchFunc[T], offset, pageSize int) *Iterator[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return &Iterator[T]{ctx: ctx, fetch: fetch, pageSize: pageSize, offset: offset, index: -1}
+}
+
+// Next advances to the next item, fetching the next page when needed. It returns
+// false when the listing is exhausted or a fetch failed; check Err to tell them
+// apart. Stopping before Next returns false leaves the remaining pages unfetched.
+func (it *Iterator[T]) Next() bool {
+	if it == nil || it.fetch == nil || it.err != nil {
+		return false
+	}
+	if it.index+1 < len(it.page) {
+		it.index++
+		return true
+	}
+	if it.done {
+		return false
+	}
+
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+	page, err := it.fetch(it.ctx, it.offset, it.pageSize)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.total = page.Total
+	it.offset += len(page.Items)
+	// With a reported total the listing ends there, since servers may cap the
+	// page size below the requested one; without it a short page is the last
+	if len(page.Items) == 0 || (page.Total > 0 && it.offset >= page.Total) || (page.Total <= 0 && len(page.Items) < it.pageSize) {
+		it.done = true
+	}
+	it.page, it.index = page.Items, 0
+	return len(it.page) > 0
+}
+
+// Item returns the current item. It is only valid after Next returned true.
+func (it *Iterator[T]) Item() T {
+	return it.page[it.index]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	if it == nil {
+		return nil
+	}
+	return it.err
+}
+
+// Total returns the size of the whole listing as reported by the most recently
+// fetched page. It is zero before the first call to Next and for endpoints that
+// do not report a total.
+func (it *Iterator[T]) Total() int {
+	if it == nil {
+		return 0
+	}
+	return it.total
+}
+
+// All drains it and returns the remaining items.
+func All[T any](it *Iterator[T]) ([]T, error) {
+	var items []T
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
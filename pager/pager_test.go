@@ -0,0 +1,150 @@
+package pager
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// listing serves items in pages, recording the requested offsets.
+type listing struct {
+	items       []int
+	reportTotal bool
+	maxLimit    int
+	failAt      int
+	offsets     []int
+}
+
+func (l *listing) fetch(_ context.Context, offset, limit int) (Page[int], error) {
+	l.offsets = append(l.offsets, offset)
+	if l.failAt > 0 && offset >= l.failAt {
+		return Page[int]{}, errors.New("boom")
+	}
+	if l.maxLimit > 0 && limit > l.maxLimit {
+		limit = l.maxLimit
+	}
+	end := offset + limit
+	if end > len(l.items) {
+		end = len(l.items)
+	}
+	if offset > end {
+		offset = end
+	}
+	page := Page[int]{Items: l.items[offset:end]}
+	if l.reportTotal {
+		page.Total = len(l.items)
+	}
+	return page, nil
+}
+
+func seq(n int) []int {
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i
+	}
+	return items
+}
+
+func TestIterator(t *testing.T) {
+	tests := []struct {
+		name        string
+		items       int
+		reportTotal bool
+		offset      int
+		pageSize    int
+		wantOffsets []int
+		wantTotal   int
+	}{
+		{name: "short last page", items: 25, pageSize: 10, wantOffsets: []int{0, 10, 20}},
+		{name: "exact multiple without total", items: 20, pageSize: 10, wantOffsets: []int{0, 10, 20}},
+		{name: "exact multiple with total", items: 20, reportTotal: true, pageSize: 10, wantOffsets: []int{0, 10}, wantTotal: 20},
+		{name: "starting offset", items: 25, reportTotal: true, offset: 5, pageSize: 10, wantOffsets: []int{5, 15}, wantTotal: 25},
+		{name: "default page size", items: DefaultPageSize + 1, wantOffsets: []int{0, DefaultPageSize}},
+		{name: "empty", items: 0, pageSize: 10, wantOffsets: []int{0}},
+		{name: "empty with total", items: 0, reportTotal: true, pageSize: 10, wantOffsets: []int{0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &listing{items: seq(tt.items), reportTotal: tt.reportTotal}
+			it := New(context.Background(), l.fetch, tt.offset, tt.pageSize)
+
+			got, err := All(it)
+			if err != nil {
+				t.Fatalf("All() failed: %v", err)
+			}
+			if want := seq(tt.items)[tt.offset:]; len(got) != len(want) || (len(want) > 0 && !reflect.DeepEqual(got, want)) {
+				t.Errorf("expected %d items from %d, got %v", len(want), tt.offset, got)
+			}
+			if !reflect.DeepEqual(l.offsets, tt.wantOffsets) {
+				t.Errorf("expected offsets %v, got %v", tt.wantOffsets, l.offsets)
+			}
+			if it.Total() != tt.wantTotal {
+				t.Errorf("expected total %d, got %d", tt.wantTotal, it.Total())
+			}
+			if it.Next() {
+				t.Error("expected an exhausted iterator to stay exhausted")
+			}
+		})
+	}
+}
+
+func TestIterator_CappedPageSize(t *testing.T) {
+	l := &listing{items: seq(25), reportTotal: true, maxLimit: 10}
+	it := New(context.Background(), l.fetch, 0, 100)
+
+	got, err := All(it)
+	if err != nil {
+		t.Fatalf("All() failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, seq(25)) {
+		t.Errorf("expected every item despite the capped page size, got %v", got)
+	}
+	if !reflect.DeepEqual(l.offsets, []int{0, 10, 20}) {
+		t.Errorf("expected offsets [0 10 20], got %v", l.offsets)
+	}
+}
+
+func TestIterator_EarlyStop(t *testing.T) {
+	l := &listing{items: seq(100), reportTotal: true}
+	it := New(context.Background(), l.fetch, 0, 10)
+
+	for it.Next() {
+		if it.Item() == 12 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(l.offsets, []int{0, 10}) {
+		t.Errorf("expected only the pages up to the break to be fetched, got %v", l.offsets)
+	}
+	if it.Total() != 100 {
+		t.Errorf("expected total 100, got %d", it.Total())
+	}
+}
+
+func TestIterator_Errors(t *testing.T) {
+	l := &listing{items: seq(30), failAt: 10}
+	it := New(context.Background(), l.fetch, 0, 10)
+	n := 0
+	for it.Next() {
+		n++
+	}
+	if n != 10 || it.Err() == nil {
+		t.Errorf("expected 10 items then an error, got %d items and %v", n, it.Err())
+	}
+	if _, err := All(New(context.Background(), l.fetch, 0, 10)); err == nil {
+		t.Error("expected All to return the fetch error")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	it = New(ctx, (&listing{items: seq(5)}).fetch, 0, 10)
+	if it.Next() || !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("expected a canceled context to stop the iterator, got %v", it.Err())
+	}
+
+	var nilIt *Iterator[int]
+	if nilIt.Next() || nilIt.Err() != nil || nilIt.Total() != 0 {
+		t.Error("expected a nil iterator to yield nothing")
+	}
+}
@@ -0,0 +1,52 @@
+package cloudsdk
+
+import (
+	"net/http"
+	"time"
+)
+
+// ConnectionPool configures the HTTP connection pool shared by every service
+// client. All services are served from the same host, so the per-host limits are
+// the ones that matter.
+type ConnectionPool struct {
+	// MaxIdleConns caps idle connections across all hosts. Zero means no limit.
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost caps idle connections kept for reuse per host
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost caps the connections per host, idle or in use. Zero means no limit.
+	MaxConnsPerHost int
+
+	// IdleConnTimeout closes connections that have been idle for longer. Zero means no limit.
+	IdleConnTimeout time.Duration
+}
+
+// DefaultConnectionPool returns the pool used when none is configured. It keeps
+// enough idle connections for concurrent callers to reuse instead of the
+// net/http default of two per host.
+func DefaultConnectionPool() ConnectionPool {
+	return ConnectionPool{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 32,
+		IdleConnTimeout:     90 * time.Second,
+	}
+}
+
+// WithConnectionPool configures the connection pool of the client's HTTP
+// transport. It has no effect when WithHTTPClient supplies the HTTP client.
+func WithConnectionPool(pool ConnectionPool) ClientOption {
+	return func(c *Client) {
+		c.connectionPool = &pool
+	}
+}
+
+// transport returns an HTTP transport with the default settings and p's limits.
+func (p ConnectionPool) transport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = p.MaxIdleConns
+	transport.MaxIdleConnsPerHost = p.MaxIdleConnsPerHost
+	transport.MaxConnsPerHost = p.MaxConnsPerHost
+	transport.IdleConnTimeout = p.IdleConnTimeout
+	return transport
+}
@@ -0,0 +1,352 @@
+package cloudsdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	iamcommon "github.com/Zillaforge/cloud-sdk/models/iam/common"
+	iamprojects "github.com/Zillaforge/cloud-sdk/models/iam/projects"
+)
+
+// DefaultProjectCacheTTL is how long resolved projects are cached by default.
+const DefaultProjectCacheTTL = 5 * time.Minute
+
+// projectPageSize is the page size used when listing every project.
+const projectPageSize = 100
+
+// Project is the metadata of a project the caller is a member of.
+type Project struct {
+	// ID is the project ID
+	ID string
+
+	// DisplayName is the human-readable project name
+	DisplayName string
+
+	// Description is the project description
+	Description string
+
+	// SysCode is the projectSysCode from extra.iservice, empty when unset
+	SysCode string
+
+	// Namespace is the namespace the project belongs to
+	Namespace string
+
+	// Frozen reports whether the project, or the caller's membership in it, is frozen
+	Frozen bool
+
+	// GlobalPermission is the permission granted to every project member
+	GlobalPermission *iamcommon.Permission
+
+	// UserPermission is the caller's own permission in the project
+	UserPermission *iamcommon.Permission
+
+	// Extra holds the raw extra attributes of the project
+	Extra map[string]interface{}
+
+	CreatedAt string
+	UpdatedAt string
+}
+
+// WithProjectCacheTTL sets how long Project, ProjectByName and DefaultProject
+// cache resolved projects and the project listing. A zero or negative ttl
+// disables caching. The default is DefaultProjectCacheTTL.
+func WithProjectCacheTTL(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.projectCacheTTL = ttl
+	}
+}
+
+// InvalidateProjectCache drops every cached project, so the next lookup asks IAM
+// again.
+func (c *Client) InvalidateProjectCache() {
+	c.resolver().invalidate()
+}
+
+// ProjectByName creates a project-scoped client for the project whose display
+// name is displayName. Display names are not unique; an error is returned when
+// none or several of the caller's projects carry it.
+func (c *Client) ProjectByName(ctx context.Context, displayName string) (*ProjectClient, error) {
+	project, err := c.resolver().byName(ctx, displayName)
+	if err != nil {
+		return nil, err
+	}
+	return c.projectClientFor(project), nil
+}
+
+// projectClientFor returns the shared ProjectClient for project with its
+// metadata refreshed.
+func (c *Client) projectClientFor(project *Project) *ProjectClient {
+	pc := c.projectClient(project.ID)
+	pc.mu.Lock()
+	pc.project = project
+	pc.mu.Unlock()
+	return pc
+}
+
+// resolver returns the client's project resolver, creating it on first use.
+func (c *Client) resolver() *projectResolver {
+	c.projectsOnce.Do(func() {
+		c.projects = newProjectResolver(c, c.projectCacheTTL)
+	})
+	return c.projects
+}
+
+// projectResolver resolves project IDs, projectSysCodes and display names to
+// projects, caching the results for ttl. It is safe for concurrent use; lookups
+// never hold the lock across requests, so concurrent misses may fetch twice.
+type projectResolver struct {
+	client *Client
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	byID    map[string]projectEntry
+	listing *projectListing
+}
+
+// projectEntry is a cached project and when it expires.
+type projectEntry struct {
+	project *Project
+	expires time.Time
+}
+
+// projectListing indexes a full listing of the caller's projects.
+type projectListing struct {
+	bySysCode map[string][]*Project
+	byName    map[string][]*Project
+	byID      map[string]*Project
+	expires   time.Time
+}
+
+func newProjectResolver(client *Client, ttl time.Duration) *projectResolver {
+	return &projectResolver{
+		client: client,
+		ttl:    ttl,
+		now:    time.Now,
+		byID:   make(map[string]projectEntry),
+	}
+}
+
+func (r *projectResolver) invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byID = make(map[string]projectEntry)
+	r.listing = nil
+}
+
+// resolve returns the project identified by a project ID or projectSysCode.
+// Keys shaped like project IDs are fetched directly, and looked up in the listing
+// only when no project has that ID; any other key is a projectSysCode, so
+// resolving one does not cost a failed fetch.
+func (r *projectResolver) resolve(ctx context.Context, projectIDOrCode string) (*Project, error) {
+	if project := r.cached(projectIDOrCode); project != nil {
+		return project, nil
+	}
+	if !looksLikeProjectID(projectIDOrCode) {
+		return r.bySysCode(ctx, projectIDOrCode)
+	}
+
+	project, err := r.get(ctx, projectIDOrCode)
+	if err == nil || !IsNotFound(err) {
+		return project, err
+	}
+	// A projectSysCode may be shaped like an ID as well
+	byCode, codeErr := r.bySysCode(ctx, projectIDOrCode)
+	if codeErr == nil || !IsNotFound(codeErr) {
+		return byCode, codeErr
+	}
+	return nil, err
+}
+
+// cached returns the unexpired project cached for an ID or a unique projectSysCode.
+func (r *projectResolver) cached(key string) *Project {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if entry, ok := r.byID[key]; ok && now.Before(entry.expires) {
+		return entry.project
+	}
+	if r.listing != nil && now.Before(r.listing.expires) {
+		if matches := r.listing.bySysCode[key]; len(matches) == 1 {
+			return matches[0]
+		}
+	}
+	return nil
+}
+
+// get fetches a project by ID and caches it.
+func (r *projectResolver) get(ctx context.Context, projectID string) (*Project, error) {
+	resp, err := r.client.IAM().Projects().Get(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	project := projectFromResponse(resp)
+	if r.ttl > 0 {
+		r.mu.Lock()
+		r.byID[project.ID] = projectEntry{project: project, expires: r.now().Add(r.ttl)}
+		r.mu.Unlock()
+	}
+	return project, nil
+}
+
+// bySysCode returns the single project with projectSysCode code.
+func (r *projectResolver) bySysCode(ctx context.Context, code string) (*Project, error) {
+	matches, err := r.lookup(ctx, func(l *projectListing) []*Project { return l.bySysCode[code] })
+	if err != nil {
+		return nil, err
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no project found with projectSysCode %s, please use projectID instead: %w", code, ErrNotFound)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("multiple projects found with projectSysCode %s, please use projectID instead: %w", code, ErrConflict)
+	}
+}
+
+// byName returns the single project with display name name.
+func (r *projectResolver) byName(ctx context.Context, name string) (*Project, error) {
+	matches, err := r.lookup(ctx, func(l *projectListing) []*Project { return l.byName[name] })
+	if err != nil {
+		return nil, err
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no project found with display name %s: %w", name, ErrNotFound)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("multiple projects found with display name %s, please use projectID instead: %w", name, ErrConflict)
+	}
+}
+
+// lookup runs find against the cached listing, refreshing it when it has
+// expired or when find comes up empty, since the project may be new.
+func (r *projectResolver) lookup(ctx context.Context, find func(*projectListing) []*Project) ([]*Project, error) {
+	r.mu.Lock()
+	listing := r.listing
+	if listing != nil && !r.now().Before(listing.expires) {
+		listing = nil
+	}
+	r.mu.Unlock()
+
+	if listing != nil {
+		if matches := find(listing); len(matches) > 0 {
+			return matches, nil
+		}
+	}
+
+	listing, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return find(listing), nil
+}
+
+// list pages through every project of the caller, indexes them and caches the
+// listing together with an ID entry per project.
+func (r *projectResolver) list(ctx context.Context) (*projectListing, error) {
+	listing := &projectListing{
+		bySysCode: make(map[string][]*Project),
+		byName:    make(map[string][]*Project),
+		byID:      make(map[string]*Project),
+	}
+
+	limit := projectPageSize
+	it := r.client.IAM().Projects().Iterate(ctx, &iamprojects.ListProjectsOptions{Limit: &limit})
+	for it.Next() {
+		pm := it.Item()
+		if pm == nil || pm.Project == nil {
+			continue
+		}
+		project := projectFromMembership(pm)
+		listing.byID[project.ID] = project
+		listing.byName[project.DisplayName] = append(listing.byName[project.DisplayName], project)
+		if project.SysCode != "" {
+			listing.bySysCode[project.SysCode] = append(listing.bySysCode[project.SysCode], project)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	if r.ttl > 0 {
+		r.mu.Lock()
+		listing.expires = r.now().Add(r.ttl)
+		r.listing = listing
+		for id, project := range listing.byID {
+			r.byID[id] = projectEntry{project: project, expires: listing.expires}
+		}
+		r.mu.Unlock()
+	}
+	return listing, nil
+}
+
+// projectFromMembership converts an entry of the project listing.
+func projectFromMembership(pm *iamprojects.ProjectMembership) *Project {
+	p := pm.Project
+	return &Project{
+		ID:               p.ProjectID,
+		DisplayName:      p.DisplayName,
+		Description:      p.Description,
+		SysCode:          projectSysCode(p.Extra),
+		Namespace:        p.Namespace,
+		Frozen:           p.Frozen || pm.Frozen,
+		GlobalPermission: pm.GlobalPermission,
+		UserPermission:   pm.UserPermission,
+		Extra:            p.Extra,
+		CreatedAt:        p.CreatedAt,
+		UpdatedAt:        p.UpdatedAt,
+	}
+}
+
+// projectFromResponse converts a project fetched by ID.
+func projectFromResponse(resp *iamprojects.GetProjectResponse) *Project {
+	return &Project{
+		ID:               resp.ProjectID,
+		DisplayName:      resp.DisplayName,
+		Description:      resp.Description,
+		SysCode:          projectSysCode(resp.Extra),
+		Namespace:        resp.Namespace,
+		Frozen:           resp.Frozen,
+		GlobalPermission: resp.GlobalPermission,
+		UserPermission:   resp.UserPermission,
+		Extra:            resp.Extra,
+		CreatedAt:        resp.CreatedAt,
+		UpdatedAt:        resp.UpdatedAt,
+	}
+}
+
+// projectSysCode returns extra.iservice.projectSysCode, or "" when unset.
+func projectSysCode(extra map[string]interface{}) string {
+	iservice, ok := extra["iservice"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	sysCode, _ := iservice["projectSysCode"].(string)
+	return sysCode
+}
+
+// looksLikeProjectID reports whether s has the UUID shape of project IDs.
+func looksLikeProjectID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, ch := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if ch != '-' {
+				return false
+			}
+		default:
+			if !('0' <= ch && ch <= '9' || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,286 @@
+package cloudsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	iamcommon "github.com/Zillaforge/cloud-sdk/models/iam/common"
+	iamprojects "github.com/Zillaforge/cloud-sdk/models/iam/projects"
+)
+
+// projectsBackend serves a paginated project listing and project lookups,
+// counting the requests it receives.
+type projectsBackend struct {
+	mu       sync.Mutex
+	projects []*iamprojects.ProjectMembership
+	lists    int
+	gets     int
+
+	// getStatus, when set, fails every project fetch with that status
+	getStatus int
+}
+
+func (b *projectsBackend) add(id, name, sysCode string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.projects = append(b.projects, &iamprojects.ProjectMembership{
+		Project: &iamprojects.Project{
+			ProjectID:   id,
+			DisplayName: name,
+			Namespace:   "public",
+			Extra:       map[string]interface{}{"iservice": map[string]interface{}{"projectSysCode": sysCode}},
+		},
+		UserPermission: &iamcommon.Permission{ID: "perm-1", Label: "ADMIN"},
+		Frozen:         name == "frozen",
+	})
+}
+
+func (b *projectsBackend) counts() (lists, gets int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.lists, b.gets
+}
+
+func (b *projectsBackend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.URL.Path == "/iam/api/v1/projects" {
+		b.lists++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		end := len(b.projects)
+		if offset > end {
+			offset = end
+		}
+		if limit > 0 && offset+limit < end {
+			end = offset + limit
+		}
+		_ = json.NewEncoder(w).Encode(iamprojects.ListProjectsResponse{Projects: b.projects[offset:end], Total: len(b.projects)})
+		return
+	}
+
+	b.gets++
+	if b.getStatus != 0 {
+		w.WriteHeader(b.getStatus)
+		_, _ = w.Write([]byte(`{"errorCode":2001,"message":"Internal Server Error"}`))
+		return
+	}
+	for _, pm := range b.projects {
+		if r.URL.Path == "/iam/api/v1/project/"+pm.Project.ProjectID {
+			_ = json.NewEncoder(w).Encode(iamprojects.GetProjectResponse{
+				ProjectID:      pm.Project.ProjectID,
+				DisplayName:    pm.Project.DisplayName,
+				Namespace:      pm.Project.Namespace,
+				Extra:          pm.Project.Extra,
+				UserPermission: pm.UserPermission,
+			})
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = w.Write([]byte(`{"errorCode":1004,"message":"project not found"}`))
+}
+
+func TestProjectResolver_PaginatesAndCaches(t *testing.T) {
+	backend := &projectsBackend{}
+	for i := 0; i < 2*projectPageSize+5; i++ {
+		backend.add(fmt.Sprintf("00000000-0000-4000-8000-%012d", i), fmt.Sprintf("project-%d", i), fmt.Sprintf("CODE%d", i))
+	}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	client, err := New(server.URL, "test-token")
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	now := time.Now()
+	client.resolver().now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// A code on the last page is found by paging through the whole listing
+	pc, err := client.Project(ctx, fmt.Sprintf("CODE%d", 2*projectPageSize+4))
+	if err != nil {
+		t.Fatalf("Project() failed: %v", err)
+	}
+	if want := fmt.Sprintf("00000000-0000-4000-8000-%012d", 2*projectPageSize+4); pc.ProjectID() != want {
+		t.Errorf("expected project %s, got %s", want, pc.ProjectID())
+	}
+	if lists, gets := backend.counts(); lists != 3 || gets != 0 {
+		t.Errorf("expected 3 list pages and no fetch, got %d lists and %d gets", lists, gets)
+	}
+
+	// Codes, IDs and display names are now served from the cache
+	if _, err := client.Project(ctx, "CODE7"); err != nil {
+		t.Fatalf("Project() by code failed: %v", err)
+	}
+	if _, err := client.Project(ctx, "00000000-0000-4000-8000-000000000007"); err != nil {
+		t.Fatalf("Project() by ID failed: %v", err)
+	}
+	byName, err := client.ProjectByName(ctx, "project-7")
+	if err != nil {
+		t.Fatalf("ProjectByName() failed: %v", err)
+	}
+	if byName.ProjectID() != "00000000-0000-4000-8000-000000000007" {
+		t.Errorf("unexpected project %s", byName.ProjectID())
+	}
+	if lists, gets := backend.counts(); lists != 3 || gets != 0 {
+		t.Errorf("expected cached lookups, got %d lists and %d gets", lists, gets)
+	}
+
+	// Expired entries are resolved again
+	now = now.Add(DefaultProjectCacheTTL)
+	if _, err := client.Project(ctx, "00000000-0000-4000-8000-000000000007"); err != nil {
+		t.Fatalf("Project() failed: %v", err)
+	}
+	if lists, gets := backend.counts(); lists != 3 || gets != 1 {
+		t.Errorf("expected one fetch by ID after expiry, got %d lists and %d gets", lists, gets)
+	}
+
+	// A name missing from the cached listing refreshes it once
+	backend.add("00000000-0000-4000-8000-999999999999", "new-project", "NEW")
+	if _, err := client.ProjectByName(ctx, "new-project"); err != nil {
+		t.Fatalf("ProjectByName() for a new project failed: %v", err)
+	}
+	if _, err := client.ProjectByName(ctx, "missing"); !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestProjectResolver_Fallbacks(t *testing.T) {
+	const idShapedCode = "11111111-2222-4333-8444-555555555555"
+	backend := &projectsBackend{}
+	backend.add("00000000-0000-4000-8000-000000000001", "one", "ONE")
+	backend.add("00000000-0000-4000-8000-000000000002", "two", idShapedCode)
+	server := httptest.NewServer(backend)
+	defer server.Close()
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		key       string
+		getStatus int
+		wantID    string
+		wantErr   func(error) bool
+		wantLists int
+		wantGets  int
+	}{
+		{name: "ID", key: "00000000-0000-4000-8000-000000000001", wantID: "00000000-0000-4000-8000-000000000001", wantGets: 1},
+		{name: "code shaped like an ID", key: idShapedCode, wantID: "00000000-0000-4000-8000-000000000002", wantLists: 1, wantGets: 1},
+		{name: "unknown ID", key: "00000000-0000-4000-8000-000000000009", wantErr: IsNotFound, wantLists: 1, wantGets: 1},
+		{
+			name:      "failed fetch is not masked by the listing",
+			key:       "00000000-0000-4000-8000-000000000001",
+			getStatus: http.StatusInternalServerError,
+			wantErr:   func(err error) bool { return errors.Is(err, ErrInternal) },
+			wantGets:  1,
+		},
+		{name: "code", key: "ONE", wantID: "00000000-0000-4000-8000-000000000001", wantLists: 1},
+		{name: "unknown code is not fetched as an ID", key: "NONE", wantErr: IsNotFound, wantLists: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend.mu.Lock()
+			backend.lists, backend.gets, backend.getStatus = 0, 0, tt.getStatus
+			backend.mu.Unlock()
+
+			client, err := New(server.URL, "test-token", WithRetryPolicy(NoRetryPolicy()))
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			pc, err := client.Project(ctx, tt.key)
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Errorf("unexpected error %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("Project() failed: %v", err)
+			} else if pc.ProjectID() != tt.wantID {
+				t.Errorf("expected project %s, got %s", tt.wantID, pc.ProjectID())
+			}
+			if lists, gets := backend.counts(); lists != tt.wantLists || gets != tt.wantGets {
+				t.Errorf("expected %d lists and %d gets, got %d and %d", tt.wantLists, tt.wantGets, lists, gets)
+			}
+		})
+	}
+}
+
+func TestProjectResolver_Metadata(t *testing.T) {
+	backend := &projectsBackend{}
+	backend.add("proj-1", "frozen", "FRZ")
+	backend.add("proj-2", "dup", "DUP")
+	backend.add("proj-3", "dup", "DUP")
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	client, err := New(server.URL, "test-token")
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	ctx := context.Background()
+
+	pc, err := client.Project(ctx, "FRZ")
+	if err != nil {
+		t.Fatalf("Project() failed: %v", err)
+	}
+	project := pc.Project()
+	if project == nil {
+		t.Fatal("expected project metadata")
+	}
+	if project.ID != "proj-1" || project.SysCode != "FRZ" || project.Namespace != "public" || !project.Frozen {
+		t.Errorf("unexpected project metadata: %+v", project)
+	}
+	if project.UserPermission == nil || project.UserPermission.Label != "ADMIN" {
+		t.Errorf("expected the user permission, got %+v", project.UserPermission)
+	}
+
+	if _, err := client.Project(ctx, "DUP"); !IsConflict(err) {
+		t.Errorf("expected a conflict for a duplicate code, got %v", err)
+	}
+	if _, err := client.ProjectByName(ctx, "dup"); !IsConflict(err) {
+		t.Errorf("expected a conflict for a duplicate name, got %v", err)
+	}
+}
+
+func TestProjectResolver_CacheDisabled(t *testing.T) {
+	backend := &projectsBackend{}
+	backend.add("00000000-0000-4000-8000-000000000001", "one", "ONE")
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	client, err := New(server.URL, "test-token", WithProjectCacheTTL(0))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Project(ctx, "00000000-0000-4000-8000-000000000001"); err != nil {
+			t.Fatalf("Project() failed: %v", err)
+		}
+	}
+	if lists, gets := backend.counts(); lists != 0 || gets != 2 {
+		t.Errorf("expected every lookup to fetch, got %d lists and %d gets", lists, gets)
+	}
+
+	client, _ = New(server.URL, "test-token")
+	if _, err := client.Project(ctx, "ONE"); err != nil {
+		t.Fatalf("Project() failed: %v", err)
+	}
+	client.InvalidateProjectCache()
+	if _, err := client.Project(ctx, "ONE"); err != nil {
+		t.Fatalf("Project() failed: %v", err)
+	}
+	if lists, _ := backend.counts(); lists != 2 {
+		t.Errorf("expected the invalidated listing to be fetched again, got %d lists", lists)
+	}
+}
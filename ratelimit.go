@@ -0,0 +1,84 @@
+package cloudsdk
+
+import (
+	"sync"
+
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+)
+
+// RateLimitInfo describes the request quota reported by the server in
+// X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset headers.
+type RateLimitInfo = internalhttp.RateLimitInfo
+
+// WithRateLimitCallback registers a callback that receives the server-reported quota
+// from every response carrying rate-limit headers, so callers can slow down before
+// they are throttled. The callback runs on the request goroutine and must be fast
+// and safe for concurrent use.
+func WithRateLimitCallback(fn func(info RateLimitInfo)) ClientOption {
+	return func(c *Client) {
+		c.rateLimitCallback = fn
+	}
+}
+
+// RateLimitScope controls how the client-side rate limit is shared.
+type RateLimitScope int
+
+const (
+	// RateLimitShared shares one bucket across every IAM, VPS and VRM client
+	// derived from the same Client (default).
+	RateLimitShared RateLimitScope = iota
+
+	// RateLimitPerService gives each service (iam, vps, vrm) its own bucket.
+	RateLimitPerService
+
+	// RateLimitPerProject gives each project its own bucket, shared by the project's
+	// VPS and VRM clients. Non-project-scoped IAM calls share a separate bucket.
+	RateLimitPerProject
+)
+
+// WithRateLimit limits the client to rps requests per second with the given burst,
+// using a token bucket. Every attempt, including retries, consumes a token.
+// The limit applies per scope (see RateLimitScope); the default is RateLimitShared.
+func WithRateLimit(rps float64, burst int, scope ...RateLimitScope) ClientOption {
+	return func(c *Client) {
+		c.limiters = &limiterSet{
+			rps:      rps,
+			burst:    burst,
+			limiters: make(map[string]*internalhttp.Limiter),
+		}
+		if len(scope) > 0 {
+			c.limiters.scope = scope[0]
+		}
+	}
+}
+
+// limiterSet hands out the token bucket for each scope key, creating it on first use.
+type limiterSet struct {
+	rps   float64
+	burst int
+	scope RateLimitScope
+
+	mu       sync.Mutex
+	limiters map[string]*internalhttp.Limiter
+}
+
+// get returns the limiter shared by clients of service within projectID.
+func (s *limiterSet) get(service, projectID string) *internalhttp.Limiter {
+	var key string
+	switch s.scope {
+	case RateLimitPerService:
+		key = service
+	case RateLimitPerProject:
+		key = projectID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, ok := s.limiters[key]
+	if !ok {
+		l = internalhttp.NewLimiter(s.rps, s.burst)
+		s.limiters[key] = l
+	}
+	return l
+}
@@ -0,0 +1,82 @@
+package cloudsdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// TestWithRateLimitCallback tests that service clients report server quota headers
+func TestWithRateLimitCallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "10")
+		w.Header().Set("X-RateLimit-Remaining", "3")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"userId": "user-1"})
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var infos []RateLimitInfo
+	client, err := New(server.URL, "test-token", WithRateLimitCallback(func(info RateLimitInfo) {
+		mu.Lock()
+		defer mu.Unlock()
+		infos = append(infos, info)
+	}))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if _, err := client.IAM().Users().Get(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(infos) != 1 || infos[0].Remaining != 3 || infos[0].Limit != 10 {
+		t.Errorf("unexpected rate limit info: %+v", infos)
+	}
+}
+
+// TestWithRateLimit tests how limiters are shared between service clients
+func TestWithRateLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		scope       []RateLimitScope
+		sameIAMVPS  bool
+		sameProject bool
+		sameAcross  bool
+	}{
+		{name: "shared by default", sameIAMVPS: true, sameProject: true, sameAcross: true},
+		{name: "per service", scope: []RateLimitScope{RateLimitPerService}, sameIAMVPS: false, sameProject: false, sameAcross: true},
+		{name: "per project", scope: []RateLimitScope{RateLimitPerProject}, sameIAMVPS: false, sameProject: true, sameAcross: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := New("https://api.example.com", "test-token", WithRateLimit(10, 5, tt.scope...))
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+
+			iam := client.limiters.get("iam", "")
+			vpsA := client.limiters.get("vps", "proj-a")
+			vrmA := client.limiters.get("vrm", "proj-a")
+			vpsB := client.limiters.get("vps", "proj-b")
+
+			if (iam == vpsA) != tt.sameIAMVPS {
+				t.Errorf("iam/vps sharing = %v, want %v", iam == vpsA, tt.sameIAMVPS)
+			}
+			if (vpsA == vrmA) != tt.sameProject {
+				t.Errorf("vps/vrm in same project sharing = %v, want %v", vpsA == vrmA, tt.sameProject)
+			}
+			if (vpsA == vpsB) != tt.sameAcross {
+				t.Errorf("vps across projects sharing = %v, want %v", vpsA == vpsB, tt.sameAcross)
+			}
+			if vpsA != client.limiters.get("vps", "proj-a") {
+				t.Error("expected limiter to be reused for the same scope key")
+			}
+		})
+	}
+}
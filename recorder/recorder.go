@@ -0,0 +1,356 @@
+// Package recorder provides an http.RoundTripper that records SDK traffic to
+// cassette files and replays it, so tests can run without a live platform.
+//
+//	rec, err := recorder.New("testdata/servers.json", recorder.ModeReplayOrRecord)
+//	if err != nil { ... }
+//	defer rec.Stop()
+//
+//	client, err := cloudsdk.New(baseURL, token, cloudsdk.WithHTTPClient(rec.Client()))
+//
+// Authorization headers are scrubbed before interactions are written, and
+// secrets in JSON bodies (passwords, private keys, boot scripts, tokens) are
+// masked with the redact package.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Zillaforge/cloud-sdk/redact"
+)
+
+// Mode selects whether a Recorder talks to the real API.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette and never touches the network.
+	// Requests without a matching interaction fail.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends every request to the real API and records the interactions,
+	// replacing the cassette on Stop.
+	ModeRecord
+
+	// ModeReplayOrRecord replays when the cassette file exists and records otherwise.
+	ModeReplayOrRecord
+)
+
+// ScrubbedValue replaces the value of scrubbed headers in cassettes.
+const ScrubbedValue = "[REDACTED]"
+
+// ErrNoInteraction is returned in replay mode when no recorded interaction matches a request.
+var ErrNoInteraction = errors.New("no matching interaction in cassette")
+
+// Cassette is the on-disk format: the recorded interactions in order.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request/response pair.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the recorded form of an HTTP request.
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	Path    string      `json:"path"`
+	Query   string      `json:"query,omitempty"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the recorded form of an HTTP response.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Matcher reports whether a recorded request matches an incoming one.
+type Matcher func(r *http.Request, body []byte, recorded RecordedRequest) bool
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithTransport sets the transport used to reach the real API in record mode
+// (default: http.DefaultTransport).
+func WithTransport(rt http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = rt
+	}
+}
+
+// WithScrubHeaders adds request or response headers whose values are replaced by
+// ScrubbedValue in the cassette. Authorization is always scrubbed.
+func WithScrubHeaders(names ...string) Option {
+	return func(r *Recorder) {
+		r.scrubHeaders = append(r.scrubHeaders, names...)
+	}
+}
+
+// WithBodyScrubber replaces the function applied to request and response bodies
+// before they are written (default: redact.JSON). Call redact.JSON from fn to keep
+// the default masking.
+func WithBodyScrubber(fn func(body []byte) []byte) Option {
+	return func(r *Recorder) {
+		r.scrubBody = fn
+	}
+}
+
+// WithMatcher replaces the default matcher, which compares method, path,
+// query parameters and body (JSON bodies are compared semantically).
+func WithMatcher(m Matcher) Option {
+	return func(r *Recorder) {
+		r.matcher = m
+	}
+}
+
+// Recorder is an http.RoundTripper that records or replays interactions.
+// It is safe for concurrent use.
+type Recorder struct {
+	path         string
+	mode         Mode
+	transport    http.RoundTripper
+	scrubHeaders []string
+	scrubBody    func([]byte) []byte
+	matcher      Matcher
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// New creates a Recorder for the cassette at path.
+// In replay mode the cassette is loaded immediately.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:         path,
+		mode:         mode,
+		transport:    http.DefaultTransport,
+		scrubHeaders: []string{"Authorization"},
+		scrubBody:    redact.JSON,
+		matcher:      DefaultMatcher,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mode == ModeReplayOrRecord {
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		} else {
+			r.mode = ModeRecord
+		}
+	}
+
+	if r.mode == ModeReplay {
+		data, err := os.ReadFile(path) //nolint:gosec // Cassette path is chosen by the test author
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette %s: %w", path, err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Mode returns the effective mode, resolving ModeReplayOrRecord.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an HTTP client that uses the recorder as its transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r, Timeout: 30 * time.Second}
+}
+
+// Interactions returns a copy of the interactions recorded or loaded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// Stop writes the cassette in record mode. It is a no-op in replay mode.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o750); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write cassette %s: %w", r.path, err)
+	}
+	return nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		// Compare against the body as it would have been recorded
+		return r.replay(req, r.scrub(body))
+	}
+	return r.record(req, body)
+}
+
+// replay serves the first unused interaction matching req.
+// Interactions are consumed in order, so repeated identical requests
+// (such as status polling) replay their recorded responses in sequence.
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !r.matcher(req, body, interaction.Request) {
+			continue
+		}
+		r.used[i] = true
+
+		resp := interaction.Response
+		header := resp.Headers.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+			StatusCode:    resp.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(resp.Body)),
+			ContentLength: int64(len(resp.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL.RequestURI())
+}
+
+// record forwards req to the real transport and stores the scrubbed interaction.
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method:  req.Method,
+			Path:    req.URL.Path,
+			Query:   req.URL.RawQuery,
+			Headers: r.scrubHeader(req.Header),
+			Body:    string(r.scrub(body)),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    r.scrubHeader(resp.Header),
+			Body:       string(r.scrub(respBody)),
+		},
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// scrubHeader returns a copy of h with sensitive header values replaced.
+func (r *Recorder) scrubHeader(h http.Header) http.Header {
+	clone := h.Clone()
+	for _, name := range r.scrubHeaders {
+		if _, ok := clone[http.CanonicalHeaderKey(name)]; ok {
+			clone.Set(name, ScrubbedValue)
+		}
+	}
+	return clone
+}
+
+// scrub applies the body scrubber, if any.
+func (r *Recorder) scrub(body []byte) []byte {
+	if r.scrubBody == nil || len(body) == 0 {
+		return body
+	}
+	return r.scrubBody(body)
+}
+
+// readBody reads and restores the request body.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// DefaultMatcher matches on method, path, query parameters (in any order) and body.
+// Bodies that are valid JSON are compared semantically, ignoring key order and whitespace.
+func DefaultMatcher(req *http.Request, body []byte, recorded RecordedRequest) bool {
+	if req.Method != recorded.Method || req.URL.Path != recorded.Path {
+		return false
+	}
+
+	recordedQuery, err := url.ParseQuery(recorded.Query)
+	if err != nil || recordedQuery.Encode() != req.URL.Query().Encode() {
+		return false
+	}
+
+	return bodiesEqual(body, []byte(recorded.Body))
+}
+
+// bodiesEqual compares bodies as JSON when both parse, and byte-wise otherwise.
+func bodiesEqual(a, b []byte) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) == nil && json.Unmarshal(b, &vb) == nil {
+		ca, _ := json.Marshal(va)
+		cb, _ := json.Marshal(vb)
+		return bytes.Equal(ca, cb)
+	}
+	return bytes.Equal(a, b)
+}
@@ -0,0 +1,54 @@
+package redact_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Zillaforge/cloud-sdk/models/vps/keypairs"
+	"github.com/Zillaforge/cloud-sdk/models/vps/servers"
+	"github.com/Zillaforge/cloud-sdk/redact"
+)
+
+// TestModelTagsAreRegistered keeps DefaultKeys in sync with the redact tags on
+// the SDK models, so raw bodies are masked like typed ones.
+func TestModelTagsAreRegistered(t *testing.T) {
+	models := []interface{}{
+		servers.Server{},
+		servers.ServerCreateRequest{},
+		servers.ServerActionRequest{},
+		servers.ServerActionResponse{},
+		keypairs.Keypair{},
+	}
+	tagged := 0
+	for _, model := range models {
+		typ := reflect.TypeOf(model)
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if field.Tag.Get("redact") != "true" {
+				continue
+			}
+			tagged++
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !redact.IsSensitiveKey(name) {
+				t.Errorf("%s.%s is tagged but %q is not a registered key", typ.Name(), field.Name, name)
+			}
+		}
+	}
+	if tagged != 6 {
+		t.Errorf("expected 6 tagged model fields, got %d", tagged)
+	}
+}
+
+func TestMarshal_ServerCreateRequest(t *testing.T) {
+	body, err := redact.Marshal(&servers.ServerCreateRequest{Name: "web", Password: "aHVudGVyMg==", BootScript: "IyEvYmluL3No"})
+	if err != nil {
+		t.Fatalf("Marshal() failed: %v", err)
+	}
+	if strings.Contains(string(body), "aHVudGVyMg==") || strings.Contains(string(body), "IyEvYmluL3No") {
+		t.Errorf("expected the password and boot script to be masked, got %s", body)
+	}
+	if !strings.Contains(string(body), `"name":"web"`) {
+		t.Errorf("expected other fields to be kept, got %s", body)
+	}
+}
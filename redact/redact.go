@@ -0,0 +1,228 @@
+// Package redact masks secrets such as passwords, private keys and bearer tokens
+// before SDK traffic is logged, recorded or formatted into errors.
+//
+// Sensitive struct fields are marked with a redact tag:
+//
+//	type ServerCreateRequest struct {
+//		Password string `json:"password,omitempty" redact:"true"`
+//	}
+//
+// Raw JSON bodies carry no type information, so they are masked by object key
+// instead. The key registry starts with DefaultKeys, which covers the tagged fields
+// of the SDK models; RegisterKeys adds more.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mask replaces redacted values.
+const Mask = "[REDACTED]"
+
+// DefaultKeys are the JSON object keys masked in raw bodies by default.
+var DefaultKeys = []string{"password", "private_key", "boot_script", "token", "access_token", "refresh_token"}
+
+var (
+	keysMu sync.RWMutex
+	keys   = newKeySet(DefaultKeys)
+)
+
+func newKeySet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[strings.ToLower(name)] = true
+	}
+	return set
+}
+
+// RegisterKeys adds JSON object keys whose values are masked in raw bodies.
+// Keys match case-insensitively.
+func RegisterKeys(names ...string) {
+	keysMu.Lock()
+	defer keysMu.Unlock()
+	for _, name := range names {
+		keys[strings.ToLower(name)] = true
+	}
+}
+
+// Keys returns the registered keys in sorted order.
+func Keys() []string {
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+	out := make([]string, 0, len(keys))
+	for name := range keys {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// IsSensitiveKey reports whether values under the JSON key name are masked.
+func IsSensitiveKey(name string) bool {
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+	return keys[strings.ToLower(name)]
+}
+
+// JSON returns body with the values of sensitive keys replaced by Mask, at any
+// depth. Bodies that are not valid JSON, or contain nothing to mask, are returned
+// unchanged; masked bodies are re-encoded compactly.
+func JSON(body []byte) []byte {
+	return maskJSON(body, IsSensitiveKey)
+}
+
+// Marshal encodes v as JSON with its sensitive values masked: fields tagged
+// redact:"true" anywhere in v's type, and registered keys. Tagged fields are
+// masked by their JSON name wherever that name appears in the encoding.
+func Marshal(v interface{}) ([]byte, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	tagged := taggedKeys(reflect.TypeOf(v))
+	return maskJSON(body, func(name string) bool {
+		return tagged[name] || IsSensitiveKey(name)
+	}), nil
+}
+
+// Map returns a copy of m with the values of sensitive keys masked, at any depth.
+// It returns m itself when nothing needs masking.
+func Map(m map[string]interface{}) map[string]interface{} {
+	if masked, changed := maskValue(m, IsSensitiveKey); changed {
+		return masked.(map[string]interface{})
+	}
+	return m
+}
+
+// String replaces every occurrence of the given secrets in s with Mask.
+// Empty secrets are ignored.
+func String(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, Mask)
+		}
+	}
+	return s
+}
+
+// maskJSON masks the values of keys accepted by sensitive in body.
+func maskJSON(body []byte, sensitive func(string) bool) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return body
+	}
+	masked, changed := maskValue(v, sensitive)
+	if !changed {
+		return body
+	}
+	out, err := json.Marshal(masked)
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+// maskValue returns a copy of the decoded JSON value v with sensitive keys masked,
+// and whether anything was masked. Unchanged values are returned as is.
+func maskValue(v interface{}, sensitive func(string) bool) (interface{}, bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		var out map[string]interface{}
+		for key, value := range v {
+			var masked interface{}
+			var changed bool
+			if sensitive(key) {
+				masked, changed = Mask, value != nil && value != ""
+			} else {
+				masked, changed = maskValue(value, sensitive)
+			}
+			if !changed {
+				continue
+			}
+			if out == nil {
+				out = make(map[string]interface{}, len(v))
+				for k, existing := range v {
+					out[k] = existing
+				}
+			}
+			out[key] = masked
+		}
+		if out == nil {
+			return v, false
+		}
+		return out, true
+	case []interface{}:
+		var out []interface{}
+		for i, value := range v {
+			masked, changed := maskValue(value, sensitive)
+			if !changed {
+				continue
+			}
+			if out == nil {
+				out = append([]interface{}(nil), v...)
+			}
+			out[i] = masked
+		}
+		if out == nil {
+			return v, false
+		}
+		return out, true
+	default:
+		return v, false
+	}
+}
+
+// taggedCache holds the tagged JSON names of each type inspected by taggedKeys.
+var taggedCache sync.Map // map[reflect.Type]map[string]bool
+
+// taggedKeys returns the JSON names of the fields tagged redact:"true" in t and
+// the types it contains.
+func taggedKeys(t reflect.Type) map[string]bool {
+	if t == nil {
+		return nil
+	}
+	if cached, ok := taggedCache.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+	names := make(map[string]bool)
+	collectTagged(t, names, make(map[reflect.Type]bool))
+	taggedCache.Store(t, names)
+	return names
+}
+
+func collectTagged(t reflect.Type, names map[string]bool, seen map[reflect.Type]bool) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return
+	}
+	seen[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Tag.Get("redact") == "true" {
+			names[jsonName(field)] = true
+		}
+		collectTagged(field.Type, names, seen)
+	}
+}
+
+// jsonName returns the key encoding/json uses for field.
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
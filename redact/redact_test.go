@@ -0,0 +1,106 @@
+package redact
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "top level", body: `{"name":"web","password":"aHVudGVyMg=="}`, want: `{"name":"web","password":"[REDACTED]"}`},
+		{name: "nested and in arrays", body: `{"keypairs":[{"id":"k1","private_key":"-----BEGIN"},{"id":"k2"}]}`, want: `{"keypairs":[{"id":"k1","private_key":"[REDACTED]"},{"id":"k2"}]}`},
+		{name: "case-insensitive key", body: `{"Token":"abc"}`, want: `{"Token":"[REDACTED]"}`},
+		{name: "nothing to mask is untouched", body: `{ "name": "web" }`, want: `{ "name": "web" }`},
+		{name: "empty values are kept", body: `{"password":""}`, want: `{"password":""}`},
+		{name: "numbers keep their precision", body: `{"size":12345678901234567890,"password":"x"}`, want: `{"password":"[REDACTED]","size":12345678901234567890}`},
+		{name: "not JSON", body: `password=hunter2`, want: `password=hunter2`},
+		{name: "empty", body: ``, want: ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(JSON([]byte(tt.body))); got != tt.want {
+				t.Errorf("JSON(%s) = %s, want %s", tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	type inner struct {
+		Secret string `json:"secret_value" redact:"true"`
+		Plain  string `json:"plain"`
+	}
+	type outer struct {
+		Name     string  `json:"name"`
+		Password string  `json:"password,omitempty"`
+		Items    []inner `json:"items"`
+		Untagged string
+	}
+
+	got, err := Marshal(&outer{Name: "web", Password: "p", Items: []inner{{Secret: "s", Plain: "x"}}, Untagged: "u"})
+	if err != nil {
+		t.Fatalf("Marshal() failed: %v", err)
+	}
+	want := `{"Untagged":"u","items":[{"plain":"x","secret_value":"[REDACTED]"}],"name":"web","password":"[REDACTED]"}`
+	if string(got) != want {
+		t.Errorf("Marshal() = %s, want %s", got, want)
+	}
+
+	if _, err := Marshal(func() {}); err == nil {
+		t.Error("expected an error for an unencodable value")
+	}
+}
+
+func TestMap(t *testing.T) {
+	meta := map[string]interface{}{"field": "password", "details": map[string]interface{}{"password": "hunter2"}}
+	got := Map(meta)
+	if got["details"].(map[string]interface{})["password"] != Mask || got["field"] != "password" {
+		t.Errorf("unexpected masked map: %v", got)
+	}
+	if meta["details"].(map[string]interface{})["password"] != "hunter2" {
+		t.Error("expected the original map to be left unchanged")
+	}
+
+	clean := map[string]interface{}{"id": "x"}
+	if got := Map(clean); !reflect.DeepEqual(got, clean) {
+		t.Errorf("expected an unchanged map, got %v", got)
+	}
+	if Map(nil) != nil {
+		t.Error("expected nil for a nil map")
+	}
+}
+
+func TestString(t *testing.T) {
+	got := String(`Get "https://api.test/?t=tok123": dial tcp`, "tok123", "")
+	if strings.Contains(got, "tok123") || !strings.Contains(got, Mask) {
+		t.Errorf("expected the secret to be masked, got %q", got)
+	}
+}
+
+func TestRegisterKeys(t *testing.T) {
+	if IsSensitiveKey("api_secret") {
+		t.Fatal("expected api_secret to be unregistered")
+	}
+	defer func() {
+		keysMu.Lock()
+		delete(keys, "api_secret")
+		keysMu.Unlock()
+	}()
+
+	RegisterKeys("API_Secret")
+	if got := string(JSON([]byte(`{"api_secret":"x"}`))); got != `{"api_secret":"[REDACTED]"}` {
+		t.Errorf("expected the registered key to be masked, got %s", got)
+	}
+	found := false
+	for _, key := range Keys() {
+		found = found || key == "api_secret"
+	}
+	if !found {
+		t.Errorf("expected Keys() to include api_secret, got %v", Keys())
+	}
+}
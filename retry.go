@@ -0,0 +1,36 @@
+package cloudsdk
+
+import (
+	"github.com/Zillaforge/cloud-sdk/internal/backoff"
+	internalhttp "github.com/Zillaforge/cloud-sdk/internal/http"
+)
+
+// RetryPolicy controls whether and how failed requests are retried.
+// See DefaultRetryPolicy for the behavior used when no policy is configured.
+type RetryPolicy = backoff.RetryPolicy
+
+// DefaultRetryPolicy returns the default policy: up to 4 attempts with 100ms to 5s
+// exponential backoff and jitter, retrying 429/502/503/504 for GET and HEAD requests.
+func DefaultRetryPolicy() *RetryPolicy {
+	return backoff.DefaultRetryPolicy()
+}
+
+// NoRetryPolicy returns a policy that never retries.
+func NoRetryPolicy() *RetryPolicy {
+	return backoff.NoRetryPolicy()
+}
+
+// WithRetryPolicy sets the retry policy for every request made by the client
+// and the service clients derived from it.
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithCallRetryPolicy overrides the retry policy for a single call.
+//
+//	server, err := vpsClient.Servers().Get(ctx, serverID, cloudsdk.WithCallRetryPolicy(cloudsdk.NoRetryPolicy()))
+func WithCallRetryPolicy(policy *RetryPolicy) CallOption {
+	return internalhttp.WithCallRetryPolicy(policy)
+}
@@ -0,0 +1,42 @@
+package cloudsdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestWithRetryPolicy tests client-wide and per-call retry policies
+func TestWithRetryPolicy(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := &RetryPolicy{MaxAttempts: 5, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond}
+	client, err := New(server.URL, "test-token", WithRetryPolicy(policy))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if _, err := client.IAM().Users().Get(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 5 {
+		t.Errorf("expected 5 attempts with client policy, got %d", got)
+	}
+
+	atomic.StoreInt32(&attempts, 0)
+	ctx := WithCallOptions(context.Background(), WithCallRetryPolicy(NoRetryPolicy()))
+	if _, err := client.IAM().Users().Get(ctx); err == nil {
+		t.Fatal("expected error")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("expected 1 attempt with per-call override, got %d", got)
+	}
+}
@@ -0,0 +1,25 @@
+package cloudsdk
+
+import (
+	"github.com/Zillaforge/cloud-sdk/tracing"
+)
+
+// tracerName identifies the SDK as the instrumentation source.
+const tracerName = "github.com/Zillaforge/cloud-sdk"
+
+// WithTracerProvider enables tracing of every SDK call. Each operation, such as
+// "vps.servers.Create", gets a span that is a child of the span in the caller's
+// context, with one child span per HTTP attempt. The attempt span is propagated
+// to the API in the W3C traceparent header, or by the tracer's own propagator
+// when it implements tracing.Propagator as the tracing/otel adapter does.
+// Retries are recorded as span events, and status codes and SDKError codes as
+// attributes.
+//
+// Tracing is off by default and adds no overhead until a provider is set.
+func WithTracerProvider(tp tracing.TracerProvider) ClientOption {
+	return func(c *Client) {
+		if tp != nil {
+			c.tracer = tp.Tracer(tracerName)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Package otel adapts OpenTelemetry tracing to the SDK's tracing interfaces.
+//
+//	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+//	client, err := cloudsdk.New(baseURL, token,
+//		cloudsdk.WithTracerProvider(sdkotel.NewTracerProvider(tp)),
+//	)
+//
+// Spans are created by the OpenTelemetry tracer, so they join the trace of the
+// span in the caller's context, and each HTTP attempt's context is written to the
+// request headers by an OpenTelemetry propagator.
+package otel
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Zillaforge/cloud-sdk/tracing"
+)
+
+// Option configures the adapter.
+type Option func(*TracerProvider)
+
+// WithPropagator sets the propagator that writes the trace headers of each HTTP
+// attempt (default: propagation.TraceContext, the W3C traceparent and tracestate
+// headers). Pass otel.GetTextMapPropagator() to use the globally configured one.
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return func(tp *TracerProvider) {
+		if p != nil {
+			tp.propagator = p
+		}
+	}
+}
+
+// TracerProvider is a tracing.TracerProvider backed by an OpenTelemetry
+// trace.TracerProvider.
+type TracerProvider struct {
+	provider   trace.TracerProvider
+	propagator propagation.TextMapPropagator
+}
+
+// NewTracerProvider wraps tp for use with cloudsdk.WithTracerProvider.
+func NewTracerProvider(tp trace.TracerProvider, opts ...Option) *TracerProvider {
+	p := &TracerProvider{provider: tp, propagator: propagation.TraceContext{}}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Tracer implements tracing.TracerProvider.
+func (p *TracerProvider) Tracer(name string) tracing.Tracer {
+	return &tracer{tracer: p.provider.Tracer(name), propagator: p.propagator}
+}
+
+// tracer implements tracing.Tracer and tracing.Propagator.
+type tracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// Start implements tracing.Tracer.
+func (t *tracer) Start(ctx context.Context, name string, attrs ...tracing.Attribute) (context.Context, tracing.Span) {
+	ctx, s := t.tracer.Start(ctx, name, trace.WithAttributes(convert(attrs)...))
+	return ctx, span{s}
+}
+
+// Inject implements tracing.Propagator.
+func (t *tracer) Inject(ctx context.Context, set func(key, value string)) {
+	carrier := propagation.MapCarrier{}
+	t.propagator.Inject(ctx, carrier)
+	for key, value := range carrier {
+		set(key, value)
+	}
+}
+
+// span implements tracing.Span around an OpenTelemetry span.
+type span struct {
+	span trace.Span
+}
+
+// SpanContext implements tracing.Span.
+func (s span) SpanContext() tracing.SpanContext {
+	sc := s.span.SpanContext()
+	return tracing.SpanContext{TraceID: sc.TraceID(), SpanID: sc.SpanID(), Sampled: sc.IsSampled()}
+}
+
+// SetAttributes implements tracing.Span.
+func (s span) SetAttributes(attrs ...tracing.Attribute) {
+	s.span.SetAttributes(convert(attrs)...)
+}
+
+// AddEvent implements tracing.Span.
+func (s span) AddEvent(name string, attrs ...tracing.Attribute) {
+	s.span.AddEvent(name, trace.WithAttributes(convert(attrs)...))
+}
+
+// RecordError implements tracing.Span and marks the span as failed.
+func (s span) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+// End implements tracing.Span.
+func (s span) End() {
+	s.span.End()
+}
+
+// convert turns SDK attributes into OpenTelemetry attributes.
+func convert(attrs []tracing.Attribute) []attribute.KeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	kvs := make([]attribute.KeyValue, len(attrs))
+	for i, a := range attrs {
+		switch v := a.Value.(type) {
+		case string:
+			kvs[i] = attribute.String(a.Key, v)
+		case int:
+			kvs[i] = attribute.Int(a.Key, v)
+		case int64:
+			kvs[i] = attribute.Int64(a.Key, v)
+		case bool:
+			kvs[i] = attribute.Bool(a.Key, v)
+		case float64:
+			kvs[i] = attribute.Float64(a.Key, v)
+		default:
+			kvs[i] = attribute.String(a.Key, fmt.Sprint(v))
+		}
+	}
+	return kvs
+}
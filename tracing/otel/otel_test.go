@@ -0,0 +1,76 @@
+package otel_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	cloudsdk "github.com/Zillaforge/cloud-sdk"
+	sdkotel "github.com/Zillaforge/cloud-sdk/tracing/otel"
+)
+
+func TestTracerProvider(t *testing.T) {
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+		w.Header().Set("Content-Type", "application/json")
+		if len(headers) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"errorCode":0,"message":"try again"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"userId": "user-1"})
+	}))
+	defer server.Close()
+
+	exporter := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(exporter))
+	propagator := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+	client, err := cloudsdk.New(server.URL, "test-token",
+		cloudsdk.WithTracerProvider(sdkotel.NewTracerProvider(tp, sdkotel.WithPropagator(propagator))),
+	)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	ctx, parent := tp.Tracer("app").Start(context.Background(), "handler")
+	if _, err := client.IAM().Users().Get(ctx); err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	parent.End()
+
+	spans := exporter.Ended()
+	if len(spans) != 4 {
+		t.Fatalf("expected 2 attempts, the operation and the caller's span, got %d spans", len(spans))
+	}
+	failed, attempt, op := spans[0], spans[1], spans[2]
+	if op.Name() != "iam.users.Get" || op.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("expected iam.users.Get under the caller's span, got %q", op.Name())
+	}
+	if op.SpanContext().TraceID() != parent.SpanContext().TraceID() {
+		t.Error("expected the operation to join the caller's trace")
+	}
+	if attempt.Parent().SpanID() != op.SpanContext().SpanID() || failed.Status().Code != codes.Error {
+		t.Errorf("expected attempt spans under the operation with the failure recorded, got %+v", failed.Status())
+	}
+	if events := op.Events(); len(events) != 1 || events[0].Name != "retry" {
+		t.Errorf("expected a retry event on the operation span, got %+v", events)
+	}
+
+	// Each attempt carries its own span context, extracted with the same propagator
+	for i, span := range []sdktrace.ReadOnlySpan{failed, attempt} {
+		got := trace.SpanContextFromContext(propagator.Extract(context.Background(), propagation.HeaderCarrier(headers[i])))
+		if got.SpanID() != span.SpanContext().SpanID() || got.TraceID() != parent.SpanContext().TraceID() {
+			t.Errorf("attempt %d: expected span %s in the request headers, got %s", i+1, span.SpanContext().SpanID(), got.SpanID())
+		}
+	}
+}
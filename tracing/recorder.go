@@ -0,0 +1,129 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Recorder is a TracerProvider that keeps finished spans in memory.
+// It is meant for tests and debugging; use an adapter around a real
+// tracing backend in production.
+type Recorder struct {
+	mu    sync.Mutex
+	spans []*RecordedSpan
+}
+
+// NewRecorder creates an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Tracer implements TracerProvider. All tracers share the recorder.
+func (r *Recorder) Tracer(_ string) Tracer {
+	return recorderTracer{r}
+}
+
+// Spans returns the finished spans in the order they ended.
+func (r *Recorder) Spans() []*RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*RecordedSpan(nil), r.spans...)
+}
+
+// Reset discards all recorded spans.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = nil
+}
+
+// RecordedSpan is a span captured by a Recorder.
+type RecordedSpan struct {
+	Name       string
+	Context    SpanContext
+	Parent     SpanContext
+	Attributes map[string]interface{}
+	Events     []RecordedEvent
+	Err        error
+	Start      time.Time
+	EndTime    time.Time
+
+	mu       sync.Mutex
+	recorder *Recorder
+}
+
+// RecordedEvent is an event added to a RecordedSpan.
+type RecordedEvent struct {
+	Name       string
+	Attributes map[string]interface{}
+	Time       time.Time
+}
+
+type recorderTracer struct {
+	recorder *Recorder
+}
+
+type spanKey struct{}
+
+// Start implements Tracer.
+func (t recorderTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	var parent SpanContext
+	if p, ok := ctx.Value(spanKey{}).(*RecordedSpan); ok {
+		parent = p.Context
+	}
+
+	span := &RecordedSpan{
+		Name:       name,
+		Context:    newSpanContext(parent),
+		Parent:     parent,
+		Attributes: make(map[string]interface{}),
+		Start:      time.Now(),
+		recorder:   t.recorder,
+	}
+	span.SetAttributes(attrs...)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// SpanContext implements Span.
+func (s *RecordedSpan) SpanContext() SpanContext {
+	return s.Context
+}
+
+// SetAttributes implements Span.
+func (s *RecordedSpan) SetAttributes(attrs ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, a := range attrs {
+		s.Attributes[a.Key] = a.Value
+	}
+}
+
+// AddEvent implements Span.
+func (s *RecordedSpan) AddEvent(name string, attrs ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	event := RecordedEvent{Name: name, Attributes: make(map[string]interface{}, len(attrs)), Time: time.Now()}
+	for _, a := range attrs {
+		event.Attributes[a.Key] = a.Value
+	}
+	s.Events = append(s.Events, event)
+}
+
+// RecordError implements Span.
+func (s *RecordedSpan) RecordError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Err = err
+}
+
+// End implements Span.
+func (s *RecordedSpan) End() {
+	s.mu.Lock()
+	s.EndTime = time.Now()
+	s.mu.Unlock()
+
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.recorder.spans = append(s.recorder.spans, s)
+}
@@ -0,0 +1,117 @@
+// Package tracing defines the minimal tracer interfaces the SDK uses to emit spans,
+// plus an in-memory recorder for tests.
+//
+// The interfaces mirror the shape of OpenTelemetry's trace API. The tracing/otel
+// package adapts an OpenTelemetry TracerProvider to them, so the SDK core stays
+// free of third-party dependencies.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// TracerProvider hands out named tracers.
+type TracerProvider interface {
+	Tracer(name string) Tracer
+}
+
+// Tracer starts spans.
+type Tracer interface {
+	// Start creates a span named name as a child of the span in ctx, if any,
+	// and returns a context carrying the new span.
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Propagator is implemented by tracers that write the trace propagation headers
+// themselves, such as the OpenTelemetry adapter with its configured propagator.
+// For other tracers the SDK sends the attempt span's context in traceparent.
+type Propagator interface {
+	// Inject calls set for every header propagating the span context in ctx.
+	Inject(ctx context.Context, set func(key, value string))
+}
+
+// Span is a single timed operation within a trace.
+type Span interface {
+	// SpanContext returns the identifiers propagated to the server in traceparent.
+	SpanContext() SpanContext
+
+	// SetAttributes sets key/value attributes on the span.
+	SetAttributes(attrs ...Attribute)
+
+	// AddEvent records a timestamped event, such as a retry.
+	AddEvent(name string, attrs ...Attribute)
+
+	// RecordError records err and marks the span as failed.
+	RecordError(err error)
+
+	// End completes the span.
+	End()
+}
+
+// Attribute is a key/value pair attached to a span or event.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns a string attribute.
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns an integer attribute.
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Attribute keys set by the SDK.
+const (
+	AttrOperation      = "sdk.operation"
+	AttrAttempt        = "sdk.attempt"
+	AttrAttempts       = "sdk.attempts"
+	AttrErrorCode      = "sdk.error_code"
+	AttrErrorCategory  = "sdk.error_category"
+	AttrHTTPMethod     = "http.request.method"
+	AttrHTTPStatusCode = "http.response.status_code"
+	AttrURLPath        = "url.path"
+	AttrRetryBackoffMS = "sdk.retry.backoff_ms"
+)
+
+// TraceparentHeader is the W3C Trace Context request header.
+const TraceparentHeader = "traceparent"
+
+// SpanContext identifies a span within a trace.
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	Sampled bool
+}
+
+// IsValid reports whether both the trace ID and span ID are non-zero.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// Traceparent formats sc as a W3C traceparent header value, e.g.
+// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", hex.EncodeToString(sc.TraceID[:]), hex.EncodeToString(sc.SpanID[:]), flags)
+}
+
+// newSpanContext returns a sampled span context with random IDs,
+// reusing the trace ID of parent when it is valid.
+func newSpanContext(parent SpanContext) SpanContext {
+	sc := SpanContext{TraceID: parent.TraceID, Sampled: true}
+	if !parent.IsValid() {
+		_, _ = rand.Read(sc.TraceID[:])
+	}
+	_, _ = rand.Read(sc.SpanID[:])
+	return sc
+}
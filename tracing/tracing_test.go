@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSpanContext_Traceparent(t *testing.T) {
+	sc := SpanContext{
+		TraceID: [16]byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  [8]byte{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		Sampled: true,
+	}
+	if got, want := sc.Traceparent(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if (SpanContext{}).IsValid() {
+		t.Error("expected the zero span context to be invalid")
+	}
+}
+
+func TestRecorder_ParentChild(t *testing.T) {
+	rec := NewRecorder()
+	tracer := rec.Tracer("test")
+
+	ctx, parent := tracer.Start(context.Background(), "parent", String("k", "v"))
+	_, child := tracer.Start(ctx, "child")
+	child.AddEvent("retry", Int("attempt", 1))
+	child.RecordError(errors.New("boom"))
+	child.End()
+	parent.End()
+
+	spans := rec.Spans()
+	if len(spans) != 2 || spans[0].Name != "child" || spans[1].Name != "parent" {
+		t.Fatalf("unexpected spans: %+v", spans)
+	}
+	if spans[0].Parent != spans[1].Context {
+		t.Error("expected child to reference parent span")
+	}
+	if spans[0].Context.TraceID != spans[1].Context.TraceID {
+		t.Error("expected child to share the parent's trace ID")
+	}
+	if spans[1].Parent.IsValid() {
+		t.Error("expected root span to have no parent")
+	}
+	if spans[1].Attributes["k"] != "v" {
+		t.Errorf("expected attribute k=v, got %v", spans[1].Attributes)
+	}
+	if len(spans[0].Events) != 1 || spans[0].Err == nil {
+		t.Errorf("expected event and error on child, got %+v", spans[0])
+	}
+
+	rec.Reset()
+	if len(rec.Spans()) != 0 {
+		t.Error("expected Reset to discard spans")
+	}
+}
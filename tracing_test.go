@@ -0,0 +1,45 @@
+package cloudsdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Zillaforge/cloud-sdk/tracing"
+)
+
+// TestWithTracerProvider tests that service calls produce operation spans
+func TestWithTracerProvider(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"userId": "user-1"})
+	}))
+	defer server.Close()
+
+	rec := tracing.NewRecorder()
+	client, err := New(server.URL, "test-token", WithTracerProvider(rec))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	ctx, parent := rec.Tracer("app").Start(context.Background(), "handler")
+	if _, err := client.IAM().Users().Get(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parent.End()
+
+	spans := rec.Spans()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+	if spans[1].Name != "iam.users.Get" || spans[1].Parent != parent.SpanContext() {
+		t.Errorf("expected iam.users.Get under the caller's span, got %q", spans[1].Name)
+	}
+	if traceparent != spans[0].Context.Traceparent() {
+		t.Errorf("expected attempt span in traceparent, got %q", traceparent)
+	}
+}
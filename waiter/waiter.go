@@ -1,6 +1,8 @@
 // Package waiter provides a generic framework for polling resource state changes.
 // It supports context-based cancellation, configurable intervals, backoff strategies,
-// and maximum wait durations. This package is reusable across all cloud services.
+// and maximum wait durations. This package is reusable across all cloud services
+// and is the same framework behind the SDK's built-in waiters, so callers can tune
+// those waiters with the options below or write their own state checks.
 package waiter
 
 import (